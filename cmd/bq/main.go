@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/raito-io/cli/base"
@@ -10,10 +12,15 @@ import (
 	"github.com/raito-io/cli/base/wrappers"
 
 	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/preflight"
 	"github.com/raito-io/cli-plugin-gcp/version"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == preflight.Command {
+		os.Exit(preflight.Run(context.Background(), os.Args[2:], os.Stdout, InitializePreflightChecker))
+	}
+
 	logger := base.Logger()
 	logger.SetLevel(hclog.Debug)
 
@@ -26,7 +33,10 @@ func main() {
 				Name:    "BigQuery",
 				Version: plugin.ParseVersion(version.Version),
 				Parameters: []*plugin.ParameterInfo{
-					{Name: common.GcpSAFileLocation, Description: "The location of the GCP Service Account Key JSON (if not set GOOGLE_APPLICATION_CREDENTIALS env var is used)", Mandatory: false},
+					{Name: common.GcpSAFileLocation, Description: "The location of the GCP Service Account Key JSON or external account (workload identity federation) configuration. If not set, Application Default Credentials are used (GOOGLE_APPLICATION_CREDENTIALS, gcloud credentials or the metadata server)", Mandatory: false},
+					{Name: common.GcpCredentialsMode, Description: "How the plugin authenticates: 'auto' (default) uses gcp-serviceaccount-json-location if set and Application Default Credentials otherwise, 'service-account-key' and 'external-account' require a credentials file of that type, 'adc' always uses Application Default Credentials.", Mandatory: false},
+					{Name: common.GcpImpersonateServiceAccount, Description: "Optional email address of a service account to impersonate with the configured credentials.", Mandatory: false},
+					{Name: common.GcpImpersonateDelegates, Description: "Optional comma-separated chain of service accounts through which gcp-impersonate-service-account is impersonated.", Mandatory: false},
 					{Name: common.GcpProjectId, Description: "The ID of the Google Cloud Platform Project", Mandatory: true},
 					{Name: common.GsuiteIdentityStoreSync, Description: "If set to true, users and groups are synced from GSuite, if set to false only users and groups from the GCP project IAM scope are retrieved. GSuite requires a service account with domain wide delegation set up", Mandatory: true},
 					{Name: common.GsuiteImpersonateSubject, Description: "The Subject email to impersonate when syncing from GSuite", Mandatory: false},
@@ -34,6 +44,34 @@ func main() {
 					{Name: common.BqExcludedDatasets, Description: "The optional comma-separated list of datasets that should be skipped.", Mandatory: false},
 					{Name: common.BqIncludeHiddenDatasets, Description: "The optional boolean indicating wether the CLI retrieves hidden BQ datasets.", Mandatory: false},
 					{Name: common.BqDataUsageWindow, Description: "The maximum number of days of BQ usage data to retrieve. Default and maximum is 90 days. ", Mandatory: false},
+					{Name: common.BqInformationSchemaCrawl, Description: "If set to true, the metadata of datasets, tables and columns is retrieved from the regional INFORMATION_SCHEMA views instead of one API call per object. Datasets that could not be loaded this way are retrieved through the BigQuery API.", Mandatory: false},
+					{Name: common.BqCatalogTagsEnabled, Description: "If set to true, the Data Catalog (Dataplex) tags attached to datasets, tables and columns are imported as tags with key '<template id>.<field id>'.", Mandatory: false},
+					{Name: common.BqCacheTtl, Description: "The number of minutes the BigQuery metadata and IAM policies are cached. Default is 60 minutes.", Mandatory: false},
+					{Name: common.BqCacheDir, Description: "Optional directory in which the BigQuery metadata and IAM policies are cached, so they can be reused by later runs within the cache TTL.", Mandatory: false},
+					{Name: common.BqIncrementalSync, Description: "If set to true, only tables that were modified since the previous data source sync are crawled. Requires bq-incremental-sync-dir to be set.", Mandatory: false},
+					{Name: common.BqIncrementalSyncDir, Description: "The directory in which the state of the incremental sync is stored.", Mandatory: false},
+					{Name: common.BqFullSyncInterval, Description: "The number of hours after which the incremental sync executes a full sync again. Default is 168 hours (one week). Set to 0 to disable scheduled full syncs.", Mandatory: false},
+					{Name: common.BqForceFullSync, Description: "If set to true, a full sync is executed, even if the incremental sync is enabled.", Mandatory: false},
+					{Name: common.BqDatasetAccessMode, Description: "How dataset access is managed: 'legacy' (default) uses the basic dataset roles (READER, WRITER, OWNER), 'iam' manages the dataset access as an IAM policy (version 3), supporting any role and IAM conditions.", Mandatory: false},
+					{Name: common.BqDatasetIamModeDatasets, Description: "Optional comma-separated list of datasets for which the dataset access is managed as an IAM policy, while the other datasets use the legacy mode. This allows migrating datasets one by one.", Mandatory: false},
+					{Name: common.BqMaskingTaxonomies, Description: "Optional comma-separated list of existing taxonomies (projects/<project>/locations/<location>/taxonomies/<id>), at most one per location, in which the policy tags of masks are created instead of a Raito taxonomy.", Mandatory: false},
+					{Name: common.BqMaskingParentPolicyTags, Description: "Optional comma-separated list of existing policy tags (projects/<project>/locations/<location>/taxonomies/<id>/policyTags/<id>), at most one per location, under which the policy tags of masks are created.", Mandatory: false},
+					{Name: common.BqPolicyTagConflictResolution, Description: "What happens when a mask or column access targets a column that already has another policy tag: 'fail' (default) reports an error for the column, 'replace' replaces the existing policy tag, 'keep' keeps the existing policy tag and reports a warning.", Mandatory: false},
+					{Name: common.BqMaskDefaultValueFallback, Description: "If set to true, columns of which the data type is not supported by the mask type are masked with the default masking value instead of being rejected.", Mandatory: false},
+					{Name: common.BqMaskDeleteOrphaned, Description: "If set to true, data policies of which the policy tag is not attached to any column, verified with a Data Catalog search, are deleted during the import, instead of being imported as masks without what items.", Mandatory: false},
+					{Name: common.GcpMetadataWriteBack, Description: "Optional JSON list of descriptions and labels to write back before the data source sync, passed by the CLI in the data source sync config. See 'Metadata write-back' in the README for the format.", Mandatory: false},
+					{Name: common.GcpManagedGroups, Description: "If set to true, each access control is materialized as a Google Group managed by Raito and the role bindings reference that group. This enables access control inheritance. Requires domain wide delegation with the Admin Directory group scope.", Mandatory: false},
+					{Name: common.GcpManagedGroupsDomain, Description: "The domain of the managed Google Groups. Required when managed groups are enabled.", Mandatory: false},
+					{Name: common.GcpManagedGroupsPrefix, Description: "The prefix of the email address of the managed Google Groups. Defaults to 'raito-'.", Mandatory: false},
+					{Name: common.GcpManagedGroupsThreshold, Description: "Optional minimum number of users and groups an access control needs to be materialized as managed group. Access controls with fewer users and groups are granted directly, unless they inherit or are inherited by another access control. Defaults to 0 (all access controls use a managed group).", Mandatory: false},
+					{Name: common.GcpAccessMaxRemovedBindings, Description: "Optional maximum number of bindings, managed group memberships, masked reader roles, masks and filters an access sync may remove. When exceeded, nothing is updated. Defaults to 0 (no limit).", Mandatory: false},
+					{Name: common.GcpAccessMaxRemovedBindingsPercentage, Description: "Optional maximum percentage of the existing bindings on the updated data objects and managed group memberships that an access sync may remove. When exceeded, nothing is updated. Defaults to 0 (no limit).", Mandatory: false},
+					{Name: common.GcpAccessMaxRemovedBindingsPerDataObject, Description: "Optional maximum number of bindings an access sync may remove from a single data object. When exceeded, the bindings of that data object are not updated. Defaults to 0 (no limit).", Mandatory: false},
+					{Name: common.GcpAccessGuardrailMode, Description: "What happens when an access guardrail is breached: 'abort' (default) skips the updates that breach the guardrail, 'dry-run' skips all binding updates of the access sync and logs them instead.", Mandatory: false},
+					{Name: common.GcpProtectedPrincipals, Description: "Optional comma-separated list of principals (e.g. 'user:break-glass@raito.io') or patterns (e.g. 'serviceAccount:ci-*@my-project.iam.gserviceaccount.com') of which the bindings are never imported or changed. Google-managed service agents are always protected.", Mandatory: false},
+					{Name: common.GcpProtectedRoles, Description: "Optional comma-separated list of roles or role patterns (e.g. 'roles/resourcemanager.*') of which the bindings are never imported or changed.", Mandatory: false},
+					{Name: common.GcpPreflightSampleSize, Description: "The number of resources of each type of which the permissions are tested by the 'preflight' command. Defaults to 3.", Mandatory: false},
+					{Name: common.GcpStateDir, Description: "Optional directory in which the plugin keeps state between access syncs, e.g. that the masked reader bindings of earlier versions have been removed. Without it, these bindings are not removed.", Mandatory: false},
 					{Name: common.GcpRolesToGroupByIdentity, Description: "The optional comma-separate list of role names. When set, the bindings with these roles will be grouped by identity (user or group) instead of by resource. Note that the resulting Access Controls will not be editable from Raito Cloud. This can be used to lower the amount of imported Access Controls for roles like 'roles/bigquery.dataOwner'.", Mandatory: false},
 				},
 				TagSource: common.TagSource,
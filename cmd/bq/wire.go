@@ -13,6 +13,7 @@ import (
 	"github.com/raito-io/cli-plugin-gcp/internal/admin"
 	bigquery "github.com/raito-io/cli-plugin-gcp/internal/bq"
 	"github.com/raito-io/cli-plugin-gcp/internal/org"
+	"github.com/raito-io/cli-plugin-gcp/internal/preflight"
 	"github.com/raito-io/cli-plugin-gcp/internal/syncer"
 )
 
@@ -54,6 +55,7 @@ func InitializeDataAccessSyncer(ctx context.Context, configMap *config.ConfigMap
 		bigquery.Wired,
 		syncer.Wired,
 		org.Wired,
+		admin.Wired,
 
 		wire.Bind(new(wrappers.AccessProviderSyncer), new(*syncer.AccessSyncer)),
 		wire.Bind(new(syncer.ProjectRepo), new(*org.ProjectRepository)),
@@ -61,6 +63,7 @@ func InitializeDataAccessSyncer(ctx context.Context, configMap *config.ConfigMap
 		wire.Bind(new(syncer.MaskingService), new(*bigquery.BqMaskingService)),
 		wire.Bind(new(bigquery.ProjectClient), new(*org.ProjectRepository)),
 		wire.Bind(new(syncer.FilteringService), new(*bigquery.BqFilteringService)),
+		wire.Bind(new(syncer.ManagedGroupRepository), new(*admin.ManagedGroupRepository)),
 	)
 
 	return nil, nil, nil
@@ -80,3 +83,18 @@ func InitializeDataUsageSyncer(ctx context.Context, configMap *config.ConfigMap)
 
 	return nil, nil, nil
 }
+
+func InitializePreflightChecker(ctx context.Context, configMap *config.ConfigMap) (*preflight.Checker, func(), error) {
+	wire.Build(
+		preflight.Wired,
+
+		bigquery.NewBiqQueryClient,
+		bigquery.NewServiceClient,
+		bigquery.NewPreflightSampler,
+		org.NewProjectsClient,
+
+		wire.Bind(new(preflight.ResourceSampler), new(*bigquery.PreflightSampler)),
+	)
+
+	return nil, nil, nil
+}
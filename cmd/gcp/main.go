@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/raito-io/cli/base"
@@ -11,10 +13,15 @@ import (
 	"github.com/raito-io/cli/base/wrappers"
 
 	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/preflight"
 	"github.com/raito-io/cli-plugin-gcp/version"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == preflight.Command {
+		os.Exit(preflight.Run(context.Background(), os.Args[2:], os.Stdout, InitializePreflightChecker))
+	}
+
 	logger := base.Logger()
 	logger.SetLevel(hclog.Debug)
 
@@ -27,7 +34,10 @@ func main() {
 				Name:    "gcp",
 				Version: plugin.ParseVersion(version.Version),
 				Parameters: []*plugin.ParameterInfo{
-					{Name: common.GcpSAFileLocation, Description: "The location of the GCP Service Account Key JSON (if not set GOOGLE_APPLICATION_CREDENTIALS env var is used)", Mandatory: false},
+					{Name: common.GcpSAFileLocation, Description: "The location of the GCP Service Account Key JSON or external account (workload identity federation) configuration. If not set, Application Default Credentials are used (GOOGLE_APPLICATION_CREDENTIALS, gcloud credentials or the metadata server)", Mandatory: false},
+					{Name: common.GcpCredentialsMode, Description: "How the plugin authenticates: 'auto' (default) uses gcp-serviceaccount-json-location if set and Application Default Credentials otherwise, 'service-account-key' and 'external-account' require a credentials file of that type, 'adc' always uses Application Default Credentials.", Mandatory: false},
+					{Name: common.GcpImpersonateServiceAccount, Description: "Optional email address of a service account to impersonate with the configured credentials.", Mandatory: false},
+					{Name: common.GcpImpersonateDelegates, Description: "Optional comma-separated chain of service accounts through which gcp-impersonate-service-account is impersonated.", Mandatory: false},
 					{Name: common.GcpOrgId, Description: "The ID of the GCP Organization to synchronise", Mandatory: true},
 					{Name: common.GsuiteIdentityStoreSync, Description: "If set to true, users and groups are synced from GSuite, if set to false only users and groups from the GCP project IAM scope are retrieved. Gsuite requires a service account with domain wide delegation set up", Mandatory: false},
 					{Name: common.GsuiteImpersonateSubject, Description: "The Subject email to impersonate when syncing from GSuite", Mandatory: false},
@@ -36,6 +46,21 @@ func main() {
 					{Name: common.GcpIncludePaths, Description: "Optional comma-separated list of paths to include. If specified, only these paths will be handled. For example: /folder1/subfolder,/folder2", Mandatory: false},
 					{Name: common.GcpExcludePaths, Description: "Optional comma-separated list of paths to exclude. If specified, these paths will not be handled. Excludes have preference over includes. For example: /folder2/subfolder", Mandatory: false},
 					{Name: common.GcpServiceAccountsInIdentitySyncEnabled, Description: "Optional flag to enable/disable the retrieving of service accounts during the identity-store sync. By default this will be enabled", Mandatory: false},
+					{Name: common.GcpMetadataWriteBack, Description: "Optional JSON list of descriptions and labels to write back before the data source sync, passed by the CLI in the data source sync config. See 'Metadata write-back' in the README for the format.", Mandatory: false},
+					{Name: common.GcpManagedGroups, Description: "If set to true, each access control is materialized as a Google Group managed by Raito and the role bindings reference that group. This enables access control inheritance. Requires domain wide delegation with the Admin Directory group scope.", Mandatory: false},
+					{Name: common.GcpManagedGroupsDomain, Description: "The domain of the managed Google Groups. Required when managed groups are enabled.", Mandatory: false},
+					{Name: common.GcpManagedGroupsPrefix, Description: "The prefix of the email address of the managed Google Groups. Defaults to 'raito-'.", Mandatory: false},
+					{Name: common.GcpManagedGroupsThreshold, Description: "Optional minimum number of users and groups an access control needs to be materialized as managed group. Access controls with fewer users and groups are granted directly, unless they inherit or are inherited by another access control. Defaults to 0 (all access controls use a managed group).", Mandatory: false},
+					{Name: common.GcpAccessMaxRemovedBindings, Description: "Optional maximum number of bindings, managed group memberships, masked reader roles, masks and filters an access sync may remove. When exceeded, nothing is updated. Defaults to 0 (no limit).", Mandatory: false},
+					{Name: common.GcpAccessMaxRemovedBindingsPercentage, Description: "Optional maximum percentage of the existing bindings on the updated data objects and managed group memberships that an access sync may remove. When exceeded, nothing is updated. Defaults to 0 (no limit).", Mandatory: false},
+					{Name: common.GcpAccessMaxRemovedBindingsPerDataObject, Description: "Optional maximum number of bindings an access sync may remove from a single data object. When exceeded, the bindings of that data object are not updated. Defaults to 0 (no limit).", Mandatory: false},
+					{Name: common.GcpAccessGuardrailMode, Description: "What happens when an access guardrail is breached: 'abort' (default) skips the updates that breach the guardrail, 'dry-run' skips all binding updates of the access sync and logs them instead.", Mandatory: false},
+					{Name: common.GcpProtectedPrincipals, Description: "Optional comma-separated list of principals (e.g. 'user:break-glass@raito.io') or patterns (e.g. 'serviceAccount:ci-*@my-project.iam.gserviceaccount.com') of which the bindings are never imported or changed. Google-managed service agents are always protected.", Mandatory: false},
+					{Name: common.GcpProtectedRoles, Description: "Optional comma-separated list of roles or role patterns (e.g. 'roles/resourcemanager.*') of which the bindings are never imported or changed.", Mandatory: false},
+					{Name: common.GcpPreflightSampleSize, Description: "The number of resources of each type of which the permissions are tested by the 'preflight' command. Defaults to 3.", Mandatory: false},
+					{Name: common.GcpStateDir, Description: "Optional directory in which the plugin keeps state between access syncs, e.g. that the masked reader bindings of earlier versions have been removed. Without it, these bindings are not removed.", Mandatory: false},
+					{Name: common.GcpMaskingEnabled, Description: "If set to true, the BigQuery data policies of all projects in the organization are imported as masks. The masking type and fine-grained readers of imported masks can be updated, and their data policies deleted.", Mandatory: false},
+					{Name: common.GcpMaskingLocations, Description: "The comma-separated list of locations of which the data policies are imported when gcp-masking-enabled is set. Defaults to 'eu,us', which does not cover regional locations such as europe-west1.", Mandatory: false},
 				},
 				TagSource: common.TagSource,
 			},
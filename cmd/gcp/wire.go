@@ -13,6 +13,7 @@ import (
 	"github.com/raito-io/cli-plugin-gcp/internal/admin"
 	"github.com/raito-io/cli-plugin-gcp/internal/gcp"
 	"github.com/raito-io/cli-plugin-gcp/internal/org"
+	"github.com/raito-io/cli-plugin-gcp/internal/preflight"
 	"github.com/raito-io/cli-plugin-gcp/internal/syncer"
 )
 
@@ -48,13 +49,31 @@ func InitializeDataAccessSyncer(ctx context.Context, configMap *config.ConfigMap
 	wire.Build(
 		gcp.Wired,
 		org.Wired,
+		admin.Wired,
 		syncer.Wired,
 
 		wire.Bind(new(wrappers.AccessProviderSyncer), new(*syncer.AccessSyncer)),
 		wire.Bind(new(syncer.ProjectRepo), new(*org.ProjectRepository)),
 		wire.Bind(new(syncer.BindingRepository), new(*org.GcpDataObjectIterator)),
-		wire.Bind(new(syncer.MaskingService), new(*gcp.NoMasking)),
+		wire.Bind(new(syncer.MaskingService), new(*gcp.GcpMaskingService)),
+		wire.Bind(new(gcp.DataObjectIterator), new(*org.GcpDataObjectIterator)),
 		wire.Bind(new(syncer.FilteringService), new(*gcp.NoFiltering)),
+		wire.Bind(new(syncer.ManagedGroupRepository), new(*admin.ManagedGroupRepository)),
+	)
+
+	return nil, nil, nil
+}
+
+func InitializePreflightChecker(ctx context.Context, configMap *config.ConfigMap) (*preflight.Checker, func(), error) {
+	wire.Build(
+		preflight.Wired,
+
+		org.NewOrganizationsClient,
+		org.NewFoldersClient,
+		org.NewProjectsClient,
+		org.NewPreflightSampler,
+
+		wire.Bind(new(preflight.ResourceSampler), new(*org.PreflightSampler)),
 	)
 
 	return nil, nil, nil
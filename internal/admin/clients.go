@@ -3,36 +3,26 @@ package admin
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/raito-io/cli/base/util/config"
-	"golang.org/x/oauth2/google"
 	gcpadmin "google.golang.org/api/admin/directory/v1"
 	"google.golang.org/api/option"
 
 	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/credentials"
 )
 
 func NewGcpAdminService(ctx context.Context, configMap *config.ConfigMap) (*gcpadmin.Service, error) {
-	key := configMap.GetString(common.GcpSAFileLocation)
-
-	if key == "" {
-		key = os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
-	}
-
-	serviceAccountJSON, err := os.ReadFile(key)
-	if err != nil {
-		return nil, fmt.Errorf("read file %q: %w", key, err)
-	}
+	return newGcpAdminService(ctx, configMap, gcpadmin.AdminDirectoryGroupReadonlyScope, gcpadmin.AdminDirectoryUserReadonlyScope)
+}
 
-	config, err := google.JWTConfigFromJSON(serviceAccountJSON, gcpadmin.AdminDirectoryGroupReadonlyScope, gcpadmin.AdminDirectoryUserReadonlyScope)
+func newGcpAdminService(ctx context.Context, configMap *config.ConfigMap, scopes ...string) (*gcpadmin.Service, error) {
+	creds, err := credentials.NewWithSubject(ctx, configMap, configMap.GetString(common.GsuiteImpersonateSubject), scopes...)
 	if err != nil {
-		return nil, fmt.Errorf("create jwt config from file %q: %w", key, err)
+		return nil, fmt.Errorf("gcp admin credentials: %w", err)
 	}
 
-	config.Subject = configMap.GetString(common.GsuiteImpersonateSubject)
-
-	service, err := gcpadmin.NewService(ctx, option.WithHTTPClient(config.Client(ctx)))
+	service, err := gcpadmin.NewService(ctx, option.WithCredentials(creds))
 	if err != nil {
 		return nil, fmt.Errorf("create gcp admin service: %w", err)
 	}
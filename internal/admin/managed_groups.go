@@ -0,0 +1,183 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/raito-io/cli/base/util/config"
+	gcpadmin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+)
+
+// ManagedGroupRepository manages the Google Groups that are created by Raito to materialize access providers.
+type ManagedGroupRepository struct {
+	client *gcpadmin.Service
+}
+
+// NewManagedGroupRepository creates a repository with write access to the groups of the Admin Directory.
+// The Admin Directory client is only created if managed groups are enabled, so no domain-wide delegation is required otherwise.
+func NewManagedGroupRepository(ctx context.Context, configMap *config.ConfigMap) (*ManagedGroupRepository, error) {
+	if !configMap.GetBoolWithDefault(common.GcpManagedGroups, false) {
+		return &ManagedGroupRepository{}, nil
+	}
+
+	client, err := newGcpAdminService(ctx, configMap, gcpadmin.AdminDirectoryGroupScope)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ManagedGroupRepository{client: client}, nil
+}
+
+// UpsertGroup creates a group if it does not exist yet, or updates its name and description otherwise.
+func (r *ManagedGroupRepository) UpsertGroup(ctx context.Context, email string, name string, description string) error {
+	if r.client == nil {
+		return errors.New("managed groups are not enabled")
+	}
+
+	group := &gcpadmin.Group{
+		Email:       email,
+		Name:        name,
+		Description: description,
+	}
+
+	existing, err := r.client.Groups.Get(email).Context(ctx).Do()
+	if isNotFoundError(err) {
+		common.Logger.Info(fmt.Sprintf("Creating managed group %q", email))
+
+		_, err = r.client.Groups.Insert(group).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("create group %q: %w", email, err)
+		}
+
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("get group %q: %w", email, err)
+	}
+
+	if existing.Name == name && existing.Description == description {
+		return nil
+	}
+
+	_, err = r.client.Groups.Patch(email, group).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("update group %q: %w", email, err)
+	}
+
+	return nil
+}
+
+// ListGroups returns the description of each group of the domain of which the email address starts with the prefix, keyed by the lowercase email address.
+func (r *ManagedGroupRepository) ListGroups(ctx context.Context, domain string, prefix string) (map[string]string, error) {
+	if r.client == nil {
+		return nil, errors.New("managed groups are not enabled")
+	}
+
+	groups := make(map[string]string)
+
+	err := r.client.Groups.List().Domain(domain).Query(fmt.Sprintf("email:%s*", prefix)).MaxResults(maxPageItems).Pages(ctx, func(page *gcpadmin.Groups) error {
+		for _, g := range page.Groups {
+			groups[strings.ToLower(g.Email)] = g.Description
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list groups of domain %q: %w", domain, err)
+	}
+
+	return groups, nil
+}
+
+// GroupMembers returns the direct members of a group (e.g. user:a@raito.io or group:b@raito.io).
+func (r *ManagedGroupRepository) GroupMembers(ctx context.Context, email string) ([]string, error) {
+	if r.client == nil {
+		return nil, errors.New("managed groups are not enabled")
+	}
+
+	var members []string
+
+	err := r.client.Members.List(email).MaxResults(maxPageItems).Pages(ctx, func(page *gcpadmin.Members) error {
+		for _, m := range page.Members {
+			members = append(members, fmt.Sprintf("%s:%s", strings.ToLower(m.Type), m.Email))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list members of group %q: %w", email, err)
+	}
+
+	return members, nil
+}
+
+// AddGroupMember adds a user, service account or group (e.g. user:a@raito.io) to a group. Adding an existing member is not an error.
+func (r *ManagedGroupRepository) AddGroupMember(ctx context.Context, email string, member string) error {
+	if r.client == nil {
+		return errors.New("managed groups are not enabled")
+	}
+
+	_, err := r.client.Members.Insert(email, &gcpadmin.Member{Email: memberEmail(member), Role: "MEMBER"}).Context(ctx).Do()
+	if isConflictError(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("add %q to group %q: %w", member, email, err)
+	}
+
+	return nil
+}
+
+// RemoveGroupMember removes a member from a group. Removing a member that is not part of the group is not an error.
+func (r *ManagedGroupRepository) RemoveGroupMember(ctx context.Context, email string, member string) error {
+	if r.client == nil {
+		return errors.New("managed groups are not enabled")
+	}
+
+	err := r.client.Members.Delete(email, memberEmail(member)).Context(ctx).Do()
+	if err != nil && !isNotFoundError(err) {
+		return fmt.Errorf("remove %q from group %q: %w", member, email, err)
+	}
+
+	return nil
+}
+
+// DeleteGroup deletes a group. Deleting a group that does not exist is not an error.
+func (r *ManagedGroupRepository) DeleteGroup(ctx context.Context, email string) error {
+	if r.client == nil {
+		return errors.New("managed groups are not enabled")
+	}
+
+	common.Logger.Info(fmt.Sprintf("Deleting managed group %q", email))
+
+	err := r.client.Groups.Delete(email).Context(ctx).Do()
+	if err != nil && !isNotFoundError(err) {
+		return fmt.Errorf("delete group %q: %w", email, err)
+	}
+
+	return nil
+}
+
+func memberEmail(member string) string {
+	if _, email, found := strings.Cut(member, ":"); found {
+		return email
+	}
+
+	return member
+}
+
+func isNotFoundError(err error) bool {
+	var apiError *googleapi.Error
+
+	return errors.As(err, &apiError) && apiError.Code == http.StatusNotFound
+}
+
+func isConflictError(err error) bool {
+	var apiError *googleapi.Error
+
+	return errors.As(err, &apiError) && apiError.Code == http.StatusConflict
+}
@@ -93,7 +93,7 @@ func (r *AdminRepository) GetGroups(ctx context.Context, fn func(ctx context.Con
 				return fmt.Errorf("group members of group %q: %w", g.Id, err2)
 			}
 
-			err2 = fn(ctx, &iam.GroupEntity{ExternalId: fmt.Sprintf("group:%s", g.Email), Email: g.Email, Members: groupMembers})
+			err2 = fn(ctx, &iam.GroupEntity{ExternalId: fmt.Sprintf("group:%s", g.Email), Email: g.Email, Description: g.Description, Members: groupMembers})
 			if err2 != nil {
 				return err2
 			}
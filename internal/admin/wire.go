@@ -12,6 +12,7 @@ import (
 
 var Wired = wire.NewSet(
 	NewAdminRepository,
+	NewManagedGroupRepository,
 
 	NewGcpAdminService,
 )
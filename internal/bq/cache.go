@@ -0,0 +1,117 @@
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/raito-io/cli/base/util/config"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	iam2 "github.com/raito-io/cli-plugin-gcp/internal/iam"
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+const defaultCacheTtlMinutes = 60
+
+// repositoryCache caches the data objects and IAM policies of a project.
+// The data objects are cached by the full name of their parent, the policies by the id of the data object and the masking routines by the project id.
+type repositoryCache struct {
+	dataObjects     *common.Cache[[]*org.GcpOrgEntity]
+	policies        *common.Cache[[]iam2.IamBinding]
+	maskingRoutines *common.Cache[[]BQMaskingRoutine]
+}
+
+var (
+	repositoryCachesMutex sync.Mutex
+	repositoryCaches      = make(map[string]*repositoryCache)
+)
+
+// getRepositoryCache returns the cache of the configured project. The cache is shared by all repositories of the project in this process,
+// so the data objects and policies loaded during the data source sync are reused by the access and usage syncs.
+func getRepositoryCache(configMap *config.ConfigMap) *repositoryCache {
+	projectId := configMap.GetString(common.GcpProjectId)
+
+	repositoryCachesMutex.Lock()
+	defer repositoryCachesMutex.Unlock()
+
+	if cache, found := repositoryCaches[projectId]; found {
+		return cache
+	}
+
+	ttl := time.Duration(configMap.GetIntWithDefault(common.BqCacheTtl, defaultCacheTtlMinutes)) * time.Minute
+
+	var dataObjectsDir, policiesDir, routinesDir string
+
+	if dir := configMap.GetString(common.BqCacheDir); dir != "" {
+		dataObjectsDir = filepath.Join(dir, projectId, "dataobjects")
+		policiesDir = filepath.Join(dir, projectId, "policies")
+		routinesDir = filepath.Join(dir, projectId, "routines")
+	}
+
+	cache := &repositoryCache{
+		dataObjects:     common.NewCache[[]*org.GcpOrgEntity](ttl, dataObjectsDir),
+		policies:        common.NewCache[[]iam2.IamBinding](ttl, policiesDir),
+		maskingRoutines: common.NewCache[[]BQMaskingRoutine](ttl, routinesDir),
+	}
+
+	repositoryCaches[projectId] = cache
+
+	return cache
+}
+
+func (c *Repository) cacheDataObjects(parent *org.GcpOrgEntity, dataObjects []*org.GcpOrgEntity) {
+	if c.cache == nil {
+		return
+	}
+
+	// The parent is not cached, as it is known when the data objects are loaded from the cache
+	cached := make([]*org.GcpOrgEntity, 0, len(dataObjects))
+
+	for _, entity := range dataObjects {
+		entityCopy := *entity
+		entityCopy.Parent = nil
+
+		cached = append(cached, &entityCopy)
+	}
+
+	c.cache.dataObjects.Set(parent.FullName, cached)
+}
+
+func (c *Repository) loadDataObjectsFromCache(ctx context.Context, parent *org.GcpOrgEntity, fn func(ctx context.Context, item *org.GcpOrgEntity) error) (error, bool) {
+	if c.cache == nil {
+		return nil, false
+	}
+
+	result, found := c.cache.dataObjects.Get(parent.FullName)
+	if !found {
+		return nil, false
+	}
+
+	for _, entity := range result {
+		entityCopy := *entity
+		entityCopy.Parent = parent
+
+		err := fn(ctx, &entityCopy)
+		if err != nil {
+			return err, true
+		}
+	}
+
+	return nil, true
+}
+
+// InvalidateDataObjects removes the cached children of a data object, e.g. after its metadata or schema was updated.
+func (c *Repository) InvalidateDataObjects(parentFullName string) {
+	if c.cache != nil {
+		c.cache.dataObjects.Invalidate(parentFullName)
+	}
+}
+
+// invalidateTable removes the cached table and its columns, e.g. after its row access policies changed.
+func (c *Repository) invalidateTable(table *BQReferencedTable) {
+	c.InvalidateDataObjects(fmt.Sprintf("%s.%s", table.Project, table.Dataset))
+	c.InvalidateDataObjects(fmt.Sprintf("%s.%s.%s", table.Project, table.Dataset, table.Table))
+}
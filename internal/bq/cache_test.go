@@ -0,0 +1,75 @@
+package bigquery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+func TestRepository_DataObjectsCache(t *testing.T) {
+	configMap := &config.ConfigMap{Parameters: map[string]string{
+		common.GcpProjectId: "cache-test-project",
+		common.BqCacheDir:   t.TempDir(),
+	}}
+
+	repo := &Repository{cache: getRepositoryCache(configMap)}
+	assert.Same(t, repo.cache, getRepositoryCache(configMap))
+
+	parent := &org.GcpOrgEntity{Id: "cache-test-project.ds", FullName: "cache-test-project.ds", Type: data_source.Dataset}
+	table := &org.GcpOrgEntity{Id: "cache-test-project.ds.table", FullName: "cache-test-project.ds.table", Name: "table", Type: data_source.Table, Parent: parent}
+
+	repo.cacheDataObjects(parent, []*org.GcpOrgEntity{table})
+	assert.Same(t, parent, table.Parent)
+
+	var loaded []*org.GcpOrgEntity
+
+	err, done := repo.loadDataObjectsFromCache(context.Background(), parent, func(ctx context.Context, item *org.GcpOrgEntity) error {
+		loaded = append(loaded, item)
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, []*org.GcpOrgEntity{table}, loaded)
+
+	repo.InvalidateDataObjects(parent.FullName)
+
+	_, done = repo.loadDataObjectsFromCache(context.Background(), parent, func(ctx context.Context, item *org.GcpOrgEntity) error {
+		return nil
+	})
+
+	assert.False(t, done)
+}
+
+func TestRepository_invalidateTable(t *testing.T) {
+	configMap := &config.ConfigMap{Parameters: map[string]string{
+		common.GcpProjectId: "cache-invalidate-project",
+	}}
+
+	repo := &Repository{cache: getRepositoryCache(configMap)}
+
+	dataset := &org.GcpOrgEntity{Id: "cache-invalidate-project.ds", FullName: "cache-invalidate-project.ds", Type: data_source.Dataset}
+	table := &org.GcpOrgEntity{Id: "cache-invalidate-project.ds.table", FullName: "cache-invalidate-project.ds.table", Name: "table", Type: data_source.Table, Parent: dataset}
+	column := &org.GcpOrgEntity{Id: "cache-invalidate-project.ds.table.column", FullName: "cache-invalidate-project.ds.table.column", Name: "column", Type: "column", Parent: table}
+
+	repo.cacheDataObjects(dataset, []*org.GcpOrgEntity{table})
+	repo.cacheDataObjects(table, []*org.GcpOrgEntity{column})
+
+	repo.invalidateTable(&BQReferencedTable{Project: "cache-invalidate-project", Dataset: "ds", Table: "table"})
+
+	for _, parent := range []*org.GcpOrgEntity{dataset, table} {
+		_, done := repo.loadDataObjectsFromCache(context.Background(), parent, func(ctx context.Context, item *org.GcpOrgEntity) error {
+			return nil
+		})
+
+		assert.False(t, done, parent.FullName)
+	}
+}
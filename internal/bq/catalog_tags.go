@@ -0,0 +1,151 @@
+package bigquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	datacatalog "cloud.google.com/go/datacatalog/apiv1"
+	"cloud.google.com/go/datacatalog/apiv1/datacatalogpb"
+	"github.com/raito-io/cli/base/util/config"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=catalogTagRepository --with-expecter --inpackage
+type catalogTagRepository interface {
+	ListEntryTags(ctx context.Context, linkedResource string) ([]*datacatalogpb.Tag, error)
+	PolicyTagInUse(ctx context.Context, policyTag *BQPolicyTag) (bool, error)
+}
+
+type CatalogTagRepository struct {
+	client    *datacatalog.Client
+	projectId string
+}
+
+func NewCatalogTagRepository(client *datacatalog.Client, configMap *config.ConfigMap) *CatalogTagRepository {
+	return &CatalogTagRepository{
+		client:    client,
+		projectId: configMap.GetString(common.GcpProjectId),
+	}
+}
+
+// ListEntryTags returns all Data Catalog (Dataplex) tags attached to the entry of a BigQuery resource, including the tags on its columns.
+// If no entry exists for the resource, no tags are returned.
+func (r *CatalogTagRepository) ListEntryTags(ctx context.Context, linkedResource string) ([]*datacatalogpb.Tag, error) {
+	entry, err := r.client.LookupEntry(ctx, &datacatalogpb.LookupEntryRequest{
+		TargetName: &datacatalogpb.LookupEntryRequest_LinkedResource{LinkedResource: linkedResource},
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("lookup entry %q: %w", linkedResource, err)
+	}
+
+	var tags []*datacatalogpb.Tag
+
+	it := r.client.ListTags(ctx, &datacatalogpb.ListTagsRequest{Parent: entry.Name})
+
+	for {
+		tag, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("list tags of entry %q: %w", entry.Name, err)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// PolicyTagInUse searches the Data Catalog of the project for columns with the policy tag, including the columns of datasets that are not synced.
+// The search matches the display name of the policy tag, so columns with another policy tag with the same name also count as usage.
+func (r *CatalogTagRepository) PolicyTagInUse(ctx context.Context, policyTag *BQPolicyTag) (bool, error) {
+	it := r.client.SearchCatalog(ctx, &datacatalogpb.SearchCatalogRequest{
+		Scope:   &datacatalogpb.SearchCatalogRequest_Scope{IncludeProjectIds: []string{r.projectId}},
+		Query:   fmt.Sprintf("policytag:%q", policyTag.Name),
+		OrderBy: "default",
+	})
+
+	_, err := it.Next()
+	if errors.Is(err, iterator.Done) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("search columns with policy tag %q: %w", policyTag.FullName, err)
+	}
+
+	return true, nil
+}
+
+// catalogTagKey returns the key of the Raito tag for a field of a Data Catalog tag: <template project>.<template location>.<template id>.<field id>
+// The project and location are included as templates with the same id can exist in multiple projects and locations.
+func catalogTagKey(tag *datacatalogpb.Tag, fieldId string) string {
+	// projects/{project}/locations/{location}/tagTemplates/{template}
+	parts := strings.Split(tag.GetTemplate(), "/")
+	if len(parts) != 6 {
+		return fmt.Sprintf("%s.%s", tag.GetTemplate(), fieldId)
+	}
+
+	return fmt.Sprintf("%s.%s.%s.%s", parts[1], parts[3], parts[5], fieldId)
+}
+
+func catalogTagFieldValue(field *datacatalogpb.TagField) string {
+	switch field.GetKind().(type) {
+	case *datacatalogpb.TagField_StringValue:
+		return field.GetStringValue()
+	case *datacatalogpb.TagField_RichtextValue:
+		return field.GetRichtextValue()
+	case *datacatalogpb.TagField_DoubleValue:
+		return strconv.FormatFloat(field.GetDoubleValue(), 'f', -1, 64)
+	case *datacatalogpb.TagField_BoolValue:
+		return strconv.FormatBool(field.GetBoolValue())
+	case *datacatalogpb.TagField_TimestampValue:
+		return formatTagTime(field.GetTimestampValue().AsTime())
+	case *datacatalogpb.TagField_EnumValue_:
+		return field.GetEnumValue().GetDisplayName()
+	default:
+		return ""
+	}
+}
+
+// entryTags holds the Raito tags of a Data Catalog entry and its columns
+type entryTags struct {
+	tags        map[string]string
+	columnsTags map[string]map[string]string
+}
+
+func newEntryTags(catalogTags []*datacatalogpb.Tag) *entryTags {
+	result := &entryTags{
+		tags:        make(map[string]string),
+		columnsTags: make(map[string]map[string]string),
+	}
+
+	for _, catalogTag := range catalogTags {
+		target := result.tags
+
+		if column := catalogTag.GetColumn(); column != "" {
+			if _, found := result.columnsTags[column]; !found {
+				result.columnsTags[column] = make(map[string]string)
+			}
+
+			target = result.columnsTags[column]
+		}
+
+		for fieldId, field := range catalogTag.GetFields() {
+			target[catalogTagKey(catalogTag, fieldId)] = catalogTagFieldValue(field)
+		}
+	}
+
+	return result
+}
+
+func isIgnorableCatalogTagError(err error) bool {
+	return common.IsGoogle403Error(err) || status.Code(err) == codes.NotFound
+}
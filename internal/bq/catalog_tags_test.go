@@ -0,0 +1,128 @@
+package bigquery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/datacatalog/apiv1/datacatalogpb"
+	ds "github.com/raito-io/cli/base/data_source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+func Test_newEntryTags(t *testing.T) {
+	catalogTags := []*datacatalogpb.Tag{
+		{
+			Template: "projects/governance/locations/eu/tagTemplates/data_governance",
+			Fields: map[string]*datacatalogpb.TagField{
+				"owner":     {Kind: &datacatalogpb.TagField_StringValue{StringValue: "data-team@raito.io"}},
+				"retention": {Kind: &datacatalogpb.TagField_DoubleValue{DoubleValue: 365}},
+				"reviewed":  {Kind: &datacatalogpb.TagField_TimestampValue{TimestampValue: timestamppb.New(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))}},
+			},
+		},
+		{
+			Template: "projects/other/locations/us/tagTemplates/data_governance",
+			Fields: map[string]*datacatalogpb.TagField{
+				"owner": {Kind: &datacatalogpb.TagField_StringValue{StringValue: "other-team@raito.io"}},
+			},
+		},
+		{
+			Template: "projects/governance/locations/eu/tagTemplates/pii",
+			Scope:    &datacatalogpb.Tag_Column{Column: "email"},
+			Fields: map[string]*datacatalogpb.TagField{
+				"classification": {Kind: &datacatalogpb.TagField_EnumValue_{EnumValue: &datacatalogpb.TagField_EnumValue{DisplayName: "CONFIDENTIAL"}}},
+				"is_pii":         {Kind: &datacatalogpb.TagField_BoolValue{BoolValue: true}},
+			},
+		},
+	}
+
+	result := newEntryTags(catalogTags)
+
+	assert.Equal(t, map[string]string{
+		"governance.eu.data_governance.owner":     "data-team@raito.io",
+		"governance.eu.data_governance.retention": "365",
+		"governance.eu.data_governance.reviewed":  "2024-05-01T00:00:00Z",
+		"other.us.data_governance.owner":          "other-team@raito.io",
+	}, result.tags)
+	assert.Equal(t, map[string]map[string]string{
+		"email": {
+			"governance.eu.pii.classification": "CONFIDENTIAL",
+			"governance.eu.pii.is_pii":         "true",
+		},
+	}, result.columnsTags)
+}
+
+func TestDataObjectIterator_addCatalogTags(t *testing.T) {
+	ctx := context.Background()
+
+	tagRepo := newMockCatalogTagRepository(t)
+	tagRepo.EXPECT().ListEntryTags(mock.Anything, "//bigquery.googleapis.com/projects/project1/datasets/ds1").Return([]*datacatalogpb.Tag{
+		{
+			Template: "projects/project1/locations/eu/tagTemplates/owner",
+			Fields:   map[string]*datacatalogpb.TagField{"team": {Kind: &datacatalogpb.TagField_StringValue{StringValue: "finance"}}},
+		},
+	}, nil).Once()
+	tagRepo.EXPECT().ListEntryTags(mock.Anything, "//bigquery.googleapis.com/projects/project1/datasets/ds1/tables/table1").Return([]*datacatalogpb.Tag{
+		{
+			Template: "projects/project1/locations/eu/tagTemplates/pii",
+			Scope:    &datacatalogpb.Tag_Column{Column: "email"},
+			Fields:   map[string]*datacatalogpb.TagField{"is_pii": {Kind: &datacatalogpb.TagField_BoolValue{BoolValue: true}}},
+		},
+	}, nil).Once()
+	tagRepo.EXPECT().ListEntryTags(mock.Anything, "//bigquery.googleapis.com/projects/project1/datasets/ds1/tables/table2").Return(nil, status.Error(codes.PermissionDenied, "denied")).Once()
+
+	it := &DataObjectIterator{tagRepo: tagRepo, projectId: "project1", catalogTagsEnabled: true}
+
+	project := &org.GcpOrgEntity{Id: "project1", FullName: "project1", Type: ds.Datasource}
+	dataset := &org.GcpOrgEntity{Name: "ds1", FullName: "project1.ds1", Type: ds.Dataset, Parent: project, Tags: map[string]string{"env": "prod"}}
+	table := &org.GcpOrgEntity{Name: "table1", FullName: "project1.ds1.table1", Type: ds.Table, Parent: dataset}
+	email := &org.GcpOrgEntity{Name: "email", FullName: "project1.ds1.table1.email", Type: ds.Column, Parent: table}
+	name := &org.GcpOrgEntity{Name: "name", FullName: "project1.ds1.table1.name", Type: ds.Column, Parent: table}
+	table2 := &org.GcpOrgEntity{Name: "table2", FullName: "project1.ds1.table2", Type: TypeExternalTable, Parent: dataset}
+
+	for _, object := range []*org.GcpOrgEntity{project, dataset, table, email, name, table2} {
+		require.NoError(t, it.addCatalogTags(ctx, object))
+	}
+
+	assert.Nil(t, project.Tags)
+	assert.Equal(t, map[string]string{"env": "prod", "project1.eu.owner.team": "finance"}, dataset.Tags)
+	assert.Nil(t, table.Tags)
+	assert.Equal(t, map[string]string{"project1.eu.pii.is_pii": "true"}, email.Tags)
+	assert.Nil(t, name.Tags)
+	assert.Nil(t, table2.Tags)
+}
+
+func TestDataObjectIterator_addCatalogTags_ColumnOfUnhandledTable(t *testing.T) {
+	ctx := context.Background()
+
+	tagRepo := newMockCatalogTagRepository(t)
+	tagRepo.EXPECT().ListEntryTags(mock.Anything, "//bigquery.googleapis.com/projects/project1/datasets/ds1/tables/table1").Return([]*datacatalogpb.Tag{
+		{
+			Template: "projects/project1/locations/eu/tagTemplates/pii",
+			Scope:    &datacatalogpb.Tag_Column{Column: "email"},
+			Fields:   map[string]*datacatalogpb.TagField{"is_pii": {Kind: &datacatalogpb.TagField_BoolValue{BoolValue: true}}},
+		},
+	}, nil).Once()
+
+	it := &DataObjectIterator{tagRepo: tagRepo, projectId: "project1", catalogTagsEnabled: true}
+
+	dataset := &org.GcpOrgEntity{Name: "ds1", FullName: "project1.ds1", Type: ds.Dataset}
+	table := &org.GcpOrgEntity{Name: "table1", FullName: "project1.ds1.table1", Type: ds.Table, Parent: dataset}
+	email := &org.GcpOrgEntity{Name: "email", FullName: "project1.ds1.table1.email", Type: ds.Column, Parent: table}
+	name := &org.GcpOrgEntity{Name: "name", FullName: "project1.ds1.table1.name", Type: ds.Column, Parent: table}
+
+	// The table is not handled, only its columns
+	for _, object := range []*org.GcpOrgEntity{email, name} {
+		require.NoError(t, it.addCatalogTags(ctx, object))
+	}
+
+	assert.Equal(t, map[string]string{"project1.eu.pii.is_pii": "true"}, email.Tags)
+	assert.Nil(t, name.Tags)
+}
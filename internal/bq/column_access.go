@@ -0,0 +1,323 @@
+package bigquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/raito-io/cli/base/access_provider"
+	"github.com/raito-io/cli/base/access_provider/sync_from_target"
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/access_provider/types"
+	ds "github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/wrappers"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+)
+
+// importColumnAccess imports a policy tag without data policy as grant on the tagged columns for the fine-grained readers of the policy tag.
+func (m *BqMaskingService) importColumnAccess(ctx context.Context, accessProviderHandler wrappers.AccessProviderHandler, tagId string, columns []string) error {
+	policyTag, err := m.datacatalogRepo.GetPolicyTag(ctx, tagId)
+	if err != nil {
+		return fmt.Errorf("get policy tag %q: %w", tagId, err)
+	}
+
+	if policyTag == nil {
+		common.Logger.Warn(fmt.Sprintf("Policy tag %s not found", tagId))
+
+		return nil
+	}
+
+	if policyTag.RaitoManaged {
+		common.Logger.Debug(fmt.Sprintf("Ignore raito created column access %q", tagId))
+
+		return nil
+	}
+
+	readers, err := m.datacatalogRepo.GetFineGrainedReaderMembers(ctx, policyTag.FullName)
+	if err != nil {
+		return fmt.Errorf("fine grained reader members for %q: %w", policyTag.FullName, err)
+	}
+
+	whatItems := make([]sync_from_target.WhatItem, 0, len(columns))
+
+	for _, column := range columns {
+		whatItems = append(whatItems, sync_from_target.WhatItem{
+			DataObject: &ds.DataObjectReference{
+				FullName: column,
+				Type:     ds.Column,
+			},
+			Permissions: []string{fineGrainedReaderRole},
+		})
+	}
+
+	whoItem := MembersToWhoItem(readers.Direct)
+
+	err = accessProviderHandler.AddAccessProviders(
+		&sync_from_target.AccessProvider{
+			Name:       policyTag.Name,
+			Type:       ptr.String(access_provider.AclSet),
+			What:       whatItems,
+			Action:     types.Grant,
+			ExternalId: policyTag.FullName,
+			Who:        &whoItem,
+			ActualName: policyTag.Name,
+			Tags:       readers.InheritedReadersTags(),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("add column access to ap handler: %w", err)
+	}
+
+	return nil
+}
+
+// ExportColumnAccess grants the who items of the access provider access to the columns in its what items.
+// Per location, the columns are tagged with a policy tag of which the who items are fine-grained reader.
+// Errors and the policy tags are added to the feedback of the access provider. The policy tags are also returned.
+// The errors and warnings of tagging the columns are only added to the feedback by ApplyColumnPolicyTags.
+func (m *BqMaskingService) ExportColumnAccess(ctx context.Context, accessProvider *importer.AccessProvider, feedback *importer.AccessProviderSyncFeedback) []string {
+	pending := &pendingColumnPolicyTagFeedback{feedback: feedback}
+
+	policyTags, err := m.exportColumnAccess(ctx, accessProvider, pending)
+	if err != nil {
+		common.Logger.Error(fmt.Sprintf("Failed to export column access of %q: %s", accessProvider.Name, err.Error()))
+
+		feedback.Errors = append(feedback.Errors, err.Error())
+	}
+
+	m.pendingFeedback = append(m.pendingFeedback, pending)
+
+	if len(policyTags) > 0 {
+		feedback.ExternalId = ptr.String(strings.Join(policyTags, ","))
+	}
+
+	return policyTags
+}
+
+func (m *BqMaskingService) exportColumnAccess(ctx context.Context, accessProvider *importer.AccessProvider, pending *pendingColumnPolicyTagFeedback) ([]string, error) {
+	if !m.maskingEnabled {
+		return nil, errors.New("column-level access requires the BigQuery catalog to be enabled")
+	}
+
+	policyTags := columnAccessPolicyTags(accessProvider.ExternalId)
+
+	doLocations, deletedDoLocations, err := m.columnAccessLocations(ctx, accessProvider)
+	if err != nil {
+		return sortedValues(policyTags), err
+	}
+
+	removedWho := importer.WhoItem{
+		Users:  accessProvider.Who.Users,
+		Groups: accessProvider.Who.Groups,
+	}
+
+	if accessProvider.DeletedWho != nil {
+		removedWho.Users = append(removedWho.Users, accessProvider.DeletedWho.Users...)
+		removedWho.Groups = append(removedWho.Groups, accessProvider.DeletedWho.Groups...)
+	}
+
+	if accessProvider.Delete {
+		common.Logger.Info(fmt.Sprintf("Remove column access of %s with %d policy tags", accessProvider.Name, len(policyTags)))
+
+		for location, tagId := range policyTags {
+			err = m.removeColumnAccess(ctx, tagId, &removedWho, append(doLocations[location], deletedDoLocations[location]...), pending)
+			if err != nil {
+				return sortedValues(policyTags), err
+			}
+		}
+
+		return nil, nil
+	}
+
+	common.Logger.Info(fmt.Sprintf("Update column access of %s", accessProvider.Name))
+
+	// First remove the policy tags of locations without columns
+	for location, tagId := range policyTags {
+		if _, found := doLocations[location]; found {
+			continue
+		}
+
+		err = m.removeColumnAccess(ctx, tagId, &removedWho, deletedDoLocations[location], pending)
+		if err != nil {
+			return sortedValues(policyTags), err
+		}
+
+		delete(policyTags, location)
+	}
+
+	// Create or update the policy tags of all locations with columns
+	for location, columns := range doLocations {
+		policyTag, err2 := m.getOrCreateColumnAccessPolicyTag(ctx, location, policyTags[location], accessProvider)
+		if err2 != nil {
+			return sortedValues(policyTags), err2
+		}
+
+		policyTags[location] = policyTag.FullName
+		maskingInformation := BQMaskingInformation{PolicyTag: *policyTag}
+
+		common.Logger.Debug(fmt.Sprintf("Update who for policy tag %q", policyTag.FullName))
+
+		err = m.datacatalogRepo.UpdateAccess(ctx, &maskingInformation, &accessProvider.Who, accessProvider.DeletedWho)
+		if err != nil {
+			return sortedValues(policyTags), fmt.Errorf("update fine grained readers of %q: %w", policyTag.FullName, err)
+		}
+
+		common.Logger.Debug(fmt.Sprintf("Update what for policy tag %q", policyTag.FullName))
+
+		pending.results = append(pending.results, m.datacatalogRepo.AddColumnPolicyTagUpdate(&maskingInformation, columns, deletedDoLocations[location]))
+	}
+
+	return sortedValues(policyTags), nil
+}
+
+func (m *BqMaskingService) getOrCreateColumnAccessPolicyTag(ctx context.Context, location string, tagId string, accessProvider *importer.AccessProvider) (*BQPolicyTag, error) {
+	if tagId != "" {
+		policyTag, err := m.datacatalogRepo.GetPolicyTag(ctx, tagId)
+		if err != nil {
+			return nil, fmt.Errorf("get policy tag %q: %w", tagId, err)
+		}
+
+		if policyTag != nil {
+			policyTag, err = m.datacatalogRepo.UpdateColumnAccessPolicyTag(ctx, policyTag, accessProvider)
+			if err != nil {
+				return nil, fmt.Errorf("update policy tag %q: %w", tagId, err)
+			}
+
+			return policyTag, nil
+		}
+	}
+
+	common.Logger.Info(fmt.Sprintf("Create new column access policy tag %s in location %s", accessProvider.Name, location))
+
+	policyTag, err := m.datacatalogRepo.CreateColumnAccessPolicyTag(ctx, location, accessProvider)
+	if err != nil {
+		return nil, fmt.Errorf("create column access policy tag in location %q: %w", location, err)
+	}
+
+	return policyTag, nil
+}
+
+// removeColumnAccess deletes the policy tag if it is created by Raito.
+// Otherwise, the who items are removed as fine-grained reader and the policy tag is detached from the columns.
+func (m *BqMaskingService) removeColumnAccess(ctx context.Context, tagId string, who *importer.WhoItem, columns []string, pending *pendingColumnPolicyTagFeedback) error {
+	policyTag, err := m.datacatalogRepo.GetPolicyTag(ctx, tagId)
+	if err != nil {
+		return fmt.Errorf("get policy tag %q: %w", tagId, err)
+	}
+
+	if policyTag == nil {
+		common.Logger.Warn(fmt.Sprintf("Cannot find policy tag %q. Assuming policy tag is already deleted", tagId))
+
+		return nil
+	}
+
+	deleted, err := m.datacatalogRepo.DeleteColumnAccessPolicyTag(ctx, policyTag)
+	if err != nil {
+		return fmt.Errorf("delete policy tag %q: %w", tagId, err)
+	} else if deleted {
+		return nil
+	}
+
+	maskingInformation := BQMaskingInformation{PolicyTag: *policyTag}
+
+	err = m.datacatalogRepo.UpdateAccess(ctx, &maskingInformation, &importer.WhoItem{}, who)
+	if err != nil {
+		return fmt.Errorf("remove fine grained readers of %q: %w", tagId, err)
+	}
+
+	if len(columns) > 0 {
+		pending.results = append(pending.results, m.datacatalogRepo.AddColumnPolicyTagUpdate(&maskingInformation, nil, columns))
+	}
+
+	return nil
+}
+
+// columnAccessLocations groups the (deleted) columns of the access provider by location.
+func (m *BqMaskingService) columnAccessLocations(ctx context.Context, accessProvider *importer.AccessProvider) (map[string][]string, map[string][]string, error) {
+	columnAccessProvider := *accessProvider
+	columnAccessProvider.What = columnWhatItems(accessProvider.What)
+	columnAccessProvider.DeleteWhat = columnWhatItems(accessProvider.DeleteWhat)
+
+	dataObjectLocationMap, deletedDataObjectLocationMap, err := m.datacatalogRepo.GetLocationsForDataObjects(ctx, &columnAccessProvider)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get location for columns: %w", err)
+	}
+
+	doLocations := map[string][]string{}
+	deletedDoLocations := map[string][]string{}
+
+	for column, location := range dataObjectLocationMap {
+		doLocations[location] = append(doLocations[location], column)
+	}
+
+	for column, location := range deletedDataObjectLocationMap {
+		deletedDoLocations[location] = append(deletedDoLocations[location], column)
+	}
+
+	return doLocations, deletedDoLocations, nil
+}
+
+// columnWhatItems returns the what items on columns.
+func columnWhatItems(whatItems []importer.WhatItem) []importer.WhatItem {
+	var result []importer.WhatItem
+
+	for _, whatItem := range whatItems {
+		if whatItem.DataObject != nil && whatItem.DataObject.Type == ds.Column {
+			result = append(result, whatItem)
+		}
+	}
+
+	return result
+}
+
+// columnAccessPolicyTags maps the policy tags in the external id of an access provider on their location.
+// Other external ids, e.g. of imported role bindings, are ignored.
+func columnAccessPolicyTags(externalId *string) map[string]string {
+	result := make(map[string]string)
+
+	if externalId == nil {
+		return result
+	}
+
+	for _, tagId := range strings.Split(*externalId, ",") {
+		parts := strings.Split(tagId, "/")
+		if len(parts) == 8 && parts[2] == "locations" && parts[6] == "policyTags" {
+			result[strings.ToLower(parts[3])] = tagId
+		}
+	}
+
+	return result
+}
+
+// MembersToWhoItem converts IAM members into a who item. Other members, e.g. domains, are ignored.
+func MembersToWhoItem(members []string) sync_from_target.WhoItem {
+	whoItem := sync_from_target.WhoItem{}
+
+	for _, member := range members {
+		if user, found := strings.CutPrefix(member, "user:"); found {
+			whoItem.Users = append(whoItem.Users, user)
+		} else if serviceAccount, found := strings.CutPrefix(member, "serviceAccount:"); found {
+			whoItem.Users = append(whoItem.Users, serviceAccount)
+		} else if group, found := strings.CutPrefix(member, "group:"); found {
+			whoItem.Groups = append(whoItem.Groups, group)
+		}
+	}
+
+	return whoItem
+}
+
+func sortedValues(m map[string]string) []string {
+	result := make([]string, 0, len(m))
+
+	for _, v := range m {
+		result = append(result, v)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
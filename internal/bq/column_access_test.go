@@ -0,0 +1,179 @@
+package bigquery
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/access_provider/types"
+	"github.com/raito-io/cli/base/data_source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBqMaskingService_ExportColumnAccess(t *testing.T) {
+	euTag := "projects/project1/locations/eu/taxonomies/1/policyTags/10"
+	usTag := "projects/project1/locations/us/taxonomies/2/policyTags/20"
+
+	who := importer.WhoItem{
+		Users:  []string{"user1@raito.io"},
+		Groups: []string{"sales@raito.io"},
+	}
+
+	columnWhat := func(columns ...string) []importer.WhatItem {
+		result := make([]importer.WhatItem, 0, len(columns))
+		for _, column := range columns {
+			result = append(result, importer.WhatItem{
+				DataObject:  &data_source.DataObjectReference{FullName: column, Type: data_source.Column},
+				Permissions: []string{fineGrainedReaderRole},
+			})
+		}
+
+		return result
+	}
+
+	tests := []struct {
+		name           string
+		accessProvider *importer.AccessProvider
+		maskingEnabled bool
+		setup          func(repository *mockMaskingDataCatalogRepository)
+		want           []string
+		wantErrors     []string
+		wantWarnings   []string
+	}{
+		{
+			name: "Create policy tag for new grant",
+			accessProvider: &importer.AccessProvider{
+				Id:     "ap1",
+				Name:   "ap1",
+				Action: types.Grant,
+				Who:    who,
+				What: append(columnWhat("project1.ds1.table1.column1"), importer.WhatItem{
+					DataObject:  &data_source.DataObjectReference{FullName: "project1.ds1.table1", Type: data_source.Table},
+					Permissions: []string{"roles/bigquery.dataViewer"},
+				}),
+			},
+			maskingEnabled: true,
+			setup: func(repository *mockMaskingDataCatalogRepository) {
+				repository.EXPECT().GetLocationsForDataObjects(mock.Anything, mock.MatchedBy(func(ap *importer.AccessProvider) bool {
+					return len(ap.What) == 1 && ap.What[0].DataObject.FullName == "project1.ds1.table1.column1"
+				})).Return(map[string]string{"project1.ds1.table1.column1": "eu"}, map[string]string{}, nil)
+				repository.EXPECT().CreateColumnAccessPolicyTag(mock.Anything, "eu", mock.Anything).Return(&BQPolicyTag{FullName: euTag, Name: "ap1_abc"}, nil)
+				repository.EXPECT().UpdateAccess(mock.Anything, &BQMaskingInformation{PolicyTag: BQPolicyTag{FullName: euTag, Name: "ap1_abc"}}, &who, (*importer.WhoItem)(nil)).Return(nil)
+				repository.EXPECT().AddColumnPolicyTagUpdate(&BQMaskingInformation{PolicyTag: BQPolicyTag{FullName: euTag, Name: "ap1_abc"}}, []string{"project1.ds1.table1.column1"}, []string(nil)).Return(&ColumnPolicyTagResult{})
+			},
+			want: []string{euTag},
+		},
+		{
+			name: "Update existing policy tag and remove policy tag of unused location",
+			accessProvider: &importer.AccessProvider{
+				Id:         "ap1",
+				Name:       "ap1",
+				Action:     types.Grant,
+				ExternalId: ptr.String(euTag + "," + usTag),
+				Who:        who,
+				What:       columnWhat("project1.ds1.table1.column1"),
+				DeleteWhat: columnWhat("project1.ds2.table1.column1"),
+			},
+			maskingEnabled: true,
+			setup: func(repository *mockMaskingDataCatalogRepository) {
+				repository.EXPECT().GetLocationsForDataObjects(mock.Anything, mock.Anything).Return(map[string]string{"project1.ds1.table1.column1": "eu"}, map[string]string{"project1.ds2.table1.column1": "us"}, nil)
+
+				repository.EXPECT().GetPolicyTag(mock.Anything, usTag).Return(&BQPolicyTag{FullName: usTag}, nil)
+				repository.EXPECT().DeleteColumnAccessPolicyTag(mock.Anything, &BQPolicyTag{FullName: usTag}).Return(true, nil)
+
+				repository.EXPECT().GetPolicyTag(mock.Anything, euTag).Return(&BQPolicyTag{FullName: euTag}, nil)
+				repository.EXPECT().UpdateColumnAccessPolicyTag(mock.Anything, &BQPolicyTag{FullName: euTag}, mock.Anything).Return(&BQPolicyTag{FullName: euTag, Name: "ap1_abc"}, nil)
+				repository.EXPECT().UpdateAccess(mock.Anything, mock.Anything, &who, (*importer.WhoItem)(nil)).Return(nil)
+				repository.EXPECT().AddColumnPolicyTagUpdate(mock.Anything, []string{"project1.ds1.table1.column1"}, []string(nil)).Return(&ColumnPolicyTagResult{})
+			},
+			want: []string{euTag},
+		},
+		{
+			name: "Delete grant on policy tag not created by Raito",
+			accessProvider: &importer.AccessProvider{
+				Id:         "ap1",
+				Name:       "ap1",
+				Action:     types.Grant,
+				ExternalId: ptr.String(euTag),
+				Who:        who,
+				What:       columnWhat("project1.ds1.table1.column1"),
+				Delete:     true,
+			},
+			maskingEnabled: true,
+			setup: func(repository *mockMaskingDataCatalogRepository) {
+				repository.EXPECT().GetLocationsForDataObjects(mock.Anything, mock.Anything).Return(map[string]string{"project1.ds1.table1.column1": "eu"}, map[string]string{}, nil)
+				repository.EXPECT().GetPolicyTag(mock.Anything, euTag).Return(&BQPolicyTag{FullName: euTag}, nil)
+				repository.EXPECT().DeleteColumnAccessPolicyTag(mock.Anything, &BQPolicyTag{FullName: euTag}).Return(false, nil)
+				repository.EXPECT().UpdateAccess(mock.Anything, &BQMaskingInformation{PolicyTag: BQPolicyTag{FullName: euTag}}, &importer.WhoItem{}, &who).Return(nil)
+				repository.EXPECT().AddColumnPolicyTagUpdate(&BQMaskingInformation{PolicyTag: BQPolicyTag{FullName: euTag}}, []string(nil), []string{"project1.ds1.table1.column1"}).Return(&ColumnPolicyTagResult{})
+			},
+		},
+		{
+			name: "Catalog not enabled",
+			accessProvider: &importer.AccessProvider{
+				Id:     "ap1",
+				Action: types.Grant,
+				What:   columnWhat("project1.ds1.table1.column1"),
+			},
+			setup:      func(repository *mockMaskingDataCatalogRepository) {},
+			wantErrors: []string{"column-level access requires the BigQuery catalog to be enabled"},
+		},
+		{
+			name: "Policy tag conflicts",
+			accessProvider: &importer.AccessProvider{
+				Id:         "ap1",
+				Name:       "ap1",
+				Action:     types.Grant,
+				ExternalId: ptr.String(euTag),
+				Who:        who,
+				What:       columnWhat("project1.ds1.table1.column1", "project1.ds1.table1.column2"),
+			},
+			maskingEnabled: true,
+			setup: func(repository *mockMaskingDataCatalogRepository) {
+				repository.EXPECT().GetLocationsForDataObjects(mock.Anything, mock.Anything).Return(map[string]string{"project1.ds1.table1.column1": "eu", "project1.ds1.table1.column2": "eu"}, map[string]string{}, nil)
+				repository.EXPECT().GetPolicyTag(mock.Anything, euTag).Return(&BQPolicyTag{FullName: euTag}, nil)
+				repository.EXPECT().UpdateColumnAccessPolicyTag(mock.Anything, &BQPolicyTag{FullName: euTag}, mock.Anything).Return(&BQPolicyTag{FullName: euTag}, nil)
+				repository.EXPECT().UpdateAccess(mock.Anything, mock.Anything, &who, (*importer.WhoItem)(nil)).Return(nil)
+				repository.EXPECT().AddColumnPolicyTagUpdate(mock.Anything, mock.Anything, []string(nil)).Return(&ColumnPolicyTagResult{Warnings: []string{"warning1"}, Errors: []string{"conflict1", "conflict2"}})
+			},
+			want:         []string{euTag},
+			wantErrors:   []string{"conflict1", "conflict2"},
+			wantWarnings: []string{"warning1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maskingService, repo := createMaskingService(t, "project1", tt.maskingEnabled)
+			tt.setup(repo)
+			repo.EXPECT().ApplyColumnPolicyTagUpdates(mock.Anything).Return()
+
+			feedback := importer.AccessProviderSyncFeedback{AccessProvider: tt.accessProvider.Id}
+
+			result := maskingService.ExportColumnAccess(context.Background(), tt.accessProvider, &feedback)
+
+			err := maskingService.ApplyColumnPolicyTags(context.Background())
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.want, result)
+			assert.Equal(t, tt.wantErrors, feedback.Errors)
+			assert.Equal(t, tt.wantWarnings, feedback.Warnings)
+
+			if len(tt.want) > 0 {
+				assert.Equal(t, strings.Join(tt.want, ","), *feedback.ExternalId)
+			} else {
+				assert.Nil(t, feedback.ExternalId)
+			}
+		})
+	}
+}
+
+func TestColumnAccessPolicyTags(t *testing.T) {
+	assert.Empty(t, columnAccessPolicyTags(nil))
+	assert.Equal(t, map[string]string{
+		"eu": "projects/project1/locations/EU/taxonomies/1/policyTags/10",
+	}, columnAccessPolicyTags(ptr.String("projects/project1/locations/EU/taxonomies/1/policyTags/10,datasource_project1_roles_bigquery.dataViewer")))
+}
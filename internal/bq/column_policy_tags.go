@@ -0,0 +1,160 @@
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/raito-io/golang-set/set"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+)
+
+// ColumnPolicyTagResult is the outcome of the column changes of a single mask or column access.
+// It is complete once ApplyColumnPolicyTagUpdates is executed.
+type ColumnPolicyTagResult struct {
+	Warnings []string
+	Errors   []string
+}
+
+type tableMaskUpdate struct {
+	ColumnsToAddMask    set.Set[string]
+	ColumnsToRemoveMask set.Set[string]
+}
+
+type columnPolicyTagUpdate struct {
+	policyTag string
+	columns   tableMaskUpdate
+	result    *ColumnPolicyTagResult
+}
+
+// AddColumnPolicyTagUpdate registers that the policy tag should be attached to the columns and detached from the deleted columns.
+// The changes of all masks and column accesses are applied by ApplyColumnPolicyTagUpdates, in a single schema update per table.
+func (r *DataCatalogRepository) AddColumnPolicyTagUpdate(policy *BQMaskingInformation, dataObjects []string, deletedDataObjects []string) *ColumnPolicyTagResult {
+	result := &ColumnPolicyTagResult{}
+	updatesPerTable := make(map[string]*columnPolicyTagUpdate)
+
+	parseColumnsToUpdatePerTable := func(dos []string, toRemove bool) {
+		for _, do := range dos {
+			doNameSplit := strings.Split(do, ".")
+			tableName := strings.Join(doNameSplit[0:len(doNameSplit)-1], ".")
+			columnName := doNameSplit[len(doNameSplit)-1]
+
+			update, found := updatesPerTable[tableName]
+			if !found {
+				update = &columnPolicyTagUpdate{
+					policyTag: policy.PolicyTag.FullName,
+					columns: tableMaskUpdate{
+						ColumnsToAddMask:    set.NewSet[string](),
+						ColumnsToRemoveMask: set.NewSet[string](),
+					},
+					result: result,
+				}
+
+				updatesPerTable[tableName] = update
+				r.pendingColumnUpdates[tableName] = append(r.pendingColumnUpdates[tableName], update)
+			}
+
+			if toRemove {
+				update.columns.ColumnsToRemoveMask.Add(columnName)
+			} else {
+				update.columns.ColumnsToAddMask.Add(columnName)
+			}
+		}
+	}
+
+	parseColumnsToUpdatePerTable(dataObjects, false)
+	parseColumnsToUpdatePerTable(deletedDataObjects, true)
+
+	return result
+}
+
+// ApplyColumnPolicyTagUpdates applies all registered column policy tag changes, with a single schema update per table.
+// Policy tags are first detached, so columns can move from one mask to another within the same sync.
+// Columns that already have another policy tag are resolved using the configured conflict resolution strategy.
+// Conflicts and failures are reported in the result of the mask or column access they belong to.
+func (r *DataCatalogRepository) ApplyColumnPolicyTagUpdates(ctx context.Context) {
+	tables := make([]string, 0, len(r.pendingColumnUpdates))
+	for table := range r.pendingColumnUpdates {
+		tables = append(tables, table)
+	}
+
+	sort.Strings(tables)
+
+	for _, table := range tables {
+		updates := r.pendingColumnUpdates[table]
+
+		err := r.applyTableColumnPolicyTagUpdates(ctx, table, updates)
+		if err != nil {
+			common.Logger.Error(fmt.Sprintf("Failed to update the policy tags of table %q: %s", table, err.Error()))
+
+			for _, update := range updates {
+				update.result.Errors = append(update.result.Errors, err.Error())
+			}
+		}
+	}
+
+	r.pendingColumnUpdates = make(map[string][]*columnPolicyTagUpdate)
+}
+
+func (r *DataCatalogRepository) applyTableColumnPolicyTagUpdates(ctx context.Context, table string, updates []*columnPolicyTagUpdate) error {
+	project, dataset, tableId, ok := splitTableFullName(table)
+	if !ok {
+		return fmt.Errorf("invalid table name %q", table)
+	}
+
+	bqTable := r.bigQueryClient.DatasetInProject(project, dataset).Table(tableId)
+
+	metadata, err := bqTable.Metadata(ctx)
+	if err != nil {
+		return fmt.Errorf("loading metadata for %q: %w", table, err)
+	}
+
+	schemaChanged := false
+
+	for _, update := range updates {
+		changed, _, _ := r.tagColumns(table, metadata.Schema, update.policyTag, tableMaskUpdate{ColumnsToRemoveMask: update.columns.ColumnsToRemoveMask})
+		schemaChanged = schemaChanged || changed
+	}
+
+	for _, update := range updates {
+		changed, warnings, conflicts := r.tagColumns(table, metadata.Schema, update.policyTag, tableMaskUpdate{ColumnsToAddMask: update.columns.ColumnsToAddMask})
+		schemaChanged = schemaChanged || changed
+
+		update.result.Warnings = append(update.result.Warnings, warnings...)
+		update.result.Errors = append(update.result.Errors, conflicts...)
+	}
+
+	if !schemaChanged {
+		return nil
+	}
+
+	common.Logger.Debug(fmt.Sprintf("Update policy tags of table %q for %d masks", table, len(updates)))
+
+	_, err = bqTable.Update(ctx, bigquery.TableMetadataToUpdate{
+		Schema: metadata.Schema,
+	}, metadata.ETag)
+	if err != nil {
+		return fmt.Errorf("update schema of table %q: %w", table, err)
+	}
+
+	// The policy tags of the columns are cached as children of the table
+	r.bigQueryRepo.InvalidateDataObjects(table)
+
+	return nil
+}
+
+// splitTableFullName splits the full name of a table (project.dataset.table) into its project, dataset and table id.
+func splitTableFullName(table string) (string, string, string, bool) {
+	// Dataset and table ids never contain a dot, but domain-scoped project ids do (e.g. example.com:project)
+	datasetIdx := strings.LastIndex(table, ".")
+	projectIdx := strings.LastIndex(table[:max(datasetIdx, 0)], ".")
+
+	if projectIdx <= 0 || datasetIdx <= projectIdx+1 || datasetIdx >= len(table)-1 {
+		return "", "", "", false
+	}
+
+	return table[:projectIdx], table[projectIdx+1 : datasetIdx], table[datasetIdx+1:], true
+}
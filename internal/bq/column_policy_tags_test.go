@@ -0,0 +1,66 @@
+package bigquery
+
+import (
+	"testing"
+
+	"github.com/raito-io/golang-set/set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataCatalogRepository_AddColumnPolicyTagUpdate(t *testing.T) {
+	repo := &DataCatalogRepository{projectId: "project1", pendingColumnUpdates: map[string][]*columnPolicyTagUpdate{}}
+
+	mask1 := &BQMaskingInformation{PolicyTag: BQPolicyTag{FullName: "policyTag1"}}
+	mask2 := &BQMaskingInformation{PolicyTag: BQPolicyTag{FullName: "policyTag2"}}
+
+	result1 := repo.AddColumnPolicyTagUpdate(mask1, []string{"project1.ds1.table1.column1", "project1.ds1.table2.column1"}, []string{"project1.ds1.table1.column2"})
+	result2 := repo.AddColumnPolicyTagUpdate(mask2, []string{"project1.ds1.table1.column2"}, nil)
+
+	require.Len(t, repo.pendingColumnUpdates, 2)
+
+	table1 := repo.pendingColumnUpdates["project1.ds1.table1"]
+	require.Len(t, table1, 2)
+
+	assert.Equal(t, "policyTag1", table1[0].policyTag)
+	assert.Equal(t, set.NewSet("column1"), table1[0].columns.ColumnsToAddMask)
+	assert.Equal(t, set.NewSet("column2"), table1[0].columns.ColumnsToRemoveMask)
+	assert.Same(t, result1, table1[0].result)
+
+	assert.Equal(t, "policyTag2", table1[1].policyTag)
+	assert.Equal(t, set.NewSet("column2"), table1[1].columns.ColumnsToAddMask)
+	assert.Empty(t, table1[1].columns.ColumnsToRemoveMask)
+	assert.Same(t, result2, table1[1].result)
+
+	table2 := repo.pendingColumnUpdates["project1.ds1.table2"]
+	require.Len(t, table2, 1)
+
+	assert.Equal(t, set.NewSet("column1"), table2[0].columns.ColumnsToAddMask)
+	assert.Same(t, result1, table2[0].result)
+}
+
+func TestSplitTableFullName(t *testing.T) {
+	tests := []struct {
+		name        string
+		table       string
+		wantProject string
+		wantDataset string
+		wantTable   string
+		wantOk      bool
+	}{
+		{name: "table", table: "project2.ds1.table1", wantProject: "project2", wantDataset: "ds1", wantTable: "table1", wantOk: true},
+		{name: "domain-scoped project", table: "example.com:project1.ds1.table1", wantProject: "example.com:project1", wantDataset: "ds1", wantTable: "table1", wantOk: true},
+		{name: "dataset", table: "project1.ds1", wantOk: false},
+		{name: "empty table id", table: "project1.ds1.", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, dataset, table, ok := splitTableFullName(tt.table)
+
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantProject, project)
+			assert.Equal(t, tt.wantDataset, dataset)
+			assert.Equal(t, tt.wantTable, table)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package bigquery
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"cloud.google.com/go/bigquery/datapolicies/apiv1/datapoliciespb"
 	"github.com/raito-io/cli/base/access_provider"
@@ -12,7 +14,36 @@ import (
 	"github.com/raito-io/cli-plugin-gcp/internal/common/roles"
 )
 
-func NewDataSourceMetaData(_ context.Context, configParams *config.ConfigMap) (*ds.MetaData, error) {
+const (
+	TypeExternalTable    = "external_table"
+	TypeSnapshot         = "snapshot"
+	TypeClone            = "clone"
+	TypeMaterializedView = "materialized_view"
+)
+
+// tableDataObjectTypes are all data object types that are backed by a BigQuery table resource.
+var tableDataObjectTypes = []string{ds.Table, ds.View, TypeExternalTable, TypeSnapshot, TypeClone, TypeMaterializedView}
+
+// filterableDataObjectTypes are the data object types that support row access policies and column policy tags.
+// Clones are standard tables with their own storage. Snapshots are read-only, materialized views do not support
+// row access policies and external tables only support them as BigLake table, which cannot be distinguished
+// from other external tables. Those types are therefore not filterable or maskable.
+var filterableDataObjectTypes = []string{ds.Table, TypeClone}
+
+func isFilterableDataObjectType(doType string) bool {
+	return slices.Contains(filterableDataObjectTypes, doType)
+}
+
+func isViewDataObjectType(doType string) bool {
+	return doType == ds.View || doType == TypeMaterializedView
+}
+
+//go:generate go run github.com/vektra/mockery/v2 --name=maskingRoutineRepository --with-expecter --inpackage
+type maskingRoutineRepository interface {
+	ListMaskingRoutines(ctx context.Context) ([]BQMaskingRoutine, error)
+}
+
+func NewDataSourceMetaData(ctx context.Context, configParams *config.ConfigMap, routineRepository maskingRoutineRepository) (*ds.MetaData, error) {
 	supportedFeatures := []string{ds.RowFiltering}
 
 	catalogEnabled := configParams.GetBoolWithDefault(common.BqCatalogEnabled, false)
@@ -68,7 +99,7 @@ func NewDataSourceMetaData(_ context.Context, configParams *config.ConfigMap) (*
 					roles.RolesBigQueryMetadataViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
 					roles.RolesBigQueryUser.ToDataObjectTypePermission(roles.ServiceBigQuery),
 				},
-				Children: []string{ds.Table, ds.View},
+				Children: tableDataObjectTypes,
 			},
 			{
 				Name: ds.Table,
@@ -150,10 +181,118 @@ func NewDataSourceMetaData(_ context.Context, configParams *config.ConfigMap) (*
 				},
 				Children: []string{ds.Column},
 			},
+			{
+				Name:  TypeExternalTable,
+				Type:  ds.Table,
+				Label: "External Table",
+				Permissions: []*ds.DataObjectTypePermission{
+					roles.RolesOwner.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesEditor.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryAdmin.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryEditor.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryDataOwner.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryDataViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryFilteredDataViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryMetadataViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+				},
+				Actions: []*ds.DataObjectTypeAction{
+					{
+						Action:        "SELECT",
+						GlobalActions: []string{ds.Read},
+					},
+				},
+				Children: []string{ds.Column},
+			},
+			{
+				Name:  TypeSnapshot,
+				Type:  ds.Table,
+				Label: "Snapshot",
+				Permissions: []*ds.DataObjectTypePermission{
+					roles.RolesOwner.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesEditor.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryAdmin.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryEditor.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryDataOwner.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryDataViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryFilteredDataViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryMetadataViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+				},
+				Actions: []*ds.DataObjectTypeAction{
+					{
+						Action:        "SELECT",
+						GlobalActions: []string{ds.Read},
+					},
+				},
+				Children: []string{ds.Column},
+			},
+			{
+				Name:  TypeClone,
+				Type:  ds.Table,
+				Label: "Clone",
+				Permissions: []*ds.DataObjectTypePermission{
+					roles.RolesOwner.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesEditor.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryAdmin.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryEditor.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryDataOwner.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryDataViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryFilteredDataViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryMetadataViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+				},
+				Actions: []*ds.DataObjectTypeAction{
+					{
+						Action:        "SELECT",
+						GlobalActions: []string{ds.Read},
+					},
+					{
+						Action:        "INSERT",
+						GlobalActions: []string{ds.Write},
+					},
+					{
+						Action:        "UPDATE",
+						GlobalActions: []string{ds.Write},
+					},
+					{
+						Action:        "DELETE",
+						GlobalActions: []string{ds.Write},
+					},
+					{
+						Action:        "TRUNCATE",
+						GlobalActions: []string{ds.Write},
+					},
+				},
+				Children: []string{ds.Column},
+			},
+			{
+				Name:  TypeMaterializedView,
+				Type:  ds.View,
+				Label: "Materialized View",
+				Permissions: []*ds.DataObjectTypePermission{
+					roles.RolesOwner.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesEditor.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryAdmin.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryEditor.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryDataOwner.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryDataViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryFilteredDataViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+					roles.RolesBigQueryMetadataViewer.ToDataObjectTypePermission(roles.ServiceBigQuery),
+				},
+				Actions: []*ds.DataObjectTypeAction{
+					{
+						Action:        "SELECT",
+						GlobalActions: []string{ds.Read},
+					},
+				},
+				Children: []string{ds.Column},
+			},
 			{
 				Name:        ds.Column,
 				Type:        ds.Column,
-				Permissions: []*ds.DataObjectTypePermission{},
+				Permissions: columnPermissions(catalogEnabled),
 				Children:    []string{},
 			},
 		},
@@ -162,7 +301,7 @@ func NewDataSourceMetaData(_ context.Context, configParams *config.ConfigMap) (*
 			Levels: []*ds.UsageMetaInputDetail{
 				{
 					Name:            ds.Table,
-					DataObjectTypes: []string{ds.Table, ds.View},
+					DataObjectTypes: tableDataObjectTypes,
 				},
 				{
 					Name:            ds.Dataset,
@@ -182,73 +321,106 @@ func NewDataSourceMetaData(_ context.Context, configParams *config.ConfigMap) (*
 		},
 		FilterMetadata: &ds.FilterMetadata{
 			FilterOverridePermissions: []string{roles.RolesBigQueryFilteredDataViewer.Name},
-			ApplicableTypes:           []string{ds.Table},
+			ApplicableTypes:           filterableDataObjectTypes,
 		},
 	}
 
 	if catalogEnabled {
 		metaData.MaskingMetadata = &ds.MaskingMetadata{
-			MaskTypes: []*ds.MaskingType{
-				{
-					DisplayName: "NULL",
-					ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_ALWAYS_NULL)],
-					Description: "Returns NULL instead of the column value. Use this when you want to hide both the value and the data type of the column. When this data masking rule is applied to a column, it makes it less useful in query JOIN operations for users with Masked Reader access. This is because a NULL value isn't sufficiently unique to be useful when joining tables.",
-				},
-				{
-					DisplayName: "Hash (SHA-256)",
-					ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_SHA256)],
-					Description: "Returns the SHA-256 hash of the column's value. You can only use this rule with columns that use the STRING data type.",
-					DataTypes: []string{
-						"STRING",
-					},
-				},
-				{
-					DisplayName: "Last four characters",
-					ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_LAST_FOUR_CHARACTERS)],
-					Description: "Returns the last 4 characters of the column's value, replacing the rest of the string with XXXXX. If the column's value is equal to or less than 4 characters in length, then it returns the column's value after it has been run through the SHA-256 hash function. You can only use this rule with columns that use the STRING data type.",
-					DataTypes: []string{
-						"STRING",
-					},
-				},
-				{
-					DisplayName: "First four characters",
-					ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS)],
-					Description: "Returns the first 4 characters of the column's value, replacing the rest of the string with XXXXX. If the column's value is equal to or less than 4 characters in length, then it returns the column's value after it has been run through the SHA-256 hash function. You can only use this rule with columns that use the STRING data type.",
-					DataTypes: []string{
-						"STRING",
-					},
-				},
-				{
-					DisplayName: "Email mask",
-					ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_EMAIL_MASK)],
-					Description: "Returns the column's value after replacing the username of a valid email with XXXXX. If the column's value is not a valid email address, then it returns the column's value after it has been run through the SHA-256 hash function. You can only use this rule with columns that use the STRING data type.",
-					DataTypes: []string{
-						"STRING",
-					},
-				},
-				{
-					DisplayName: "Default masking value",
-					ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_DEFAULT_MASKING_VALUE)],
-					Description: "Returns a default masking value for the column based on the column's data type. Use this when you want to hide the value of the column but reveal the data type. When this data masking rule is applied to a column, it makes it less useful in query JOIN operations for users with Masked Reader access. This is because a default value isn't sufficiently unique to be useful when joining tables.",
-				},
-				{
-					DisplayName: "Date year mask",
-					ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_DATE_YEAR_MASK)],
-					Description: "Returns the column's value after truncating the value to its year, setting all non-year parts of the value to the beginning of the year. You can only use this rule with columns that use the DATE, DATETIME, and TIMESTAMP data types.",
-					DataTypes: []string{
-						"DATE",
-						"DATETIME",
-						"TIMESTAMP",
-					},
-				},
-			},
+			MaskTypes:               PredefinedMaskTypes(),
 			DefaultMaskExternalName: datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_ALWAYS_NULL)],
 			MaskOverridePermissions: []string{
 				roles.RolesBigQueryCatalogFineGrainedAccess.Name,
 			},
-			ApplicableTypes: []string{ds.Table},
+			ApplicableTypes: filterableDataObjectTypes,
+		}
+
+		// Without the custom masking routines, masks can still use the predefined masking expressions
+		routines, err := routineRepository.ListMaskingRoutines(ctx)
+		if err != nil {
+			common.Logger.Warn(fmt.Sprintf("Unable to list custom masking routines. Only the predefined mask types are available: %s", err.Error()))
+		}
+
+		for i := range routines {
+			metaData.MaskingMetadata.MaskTypes = append(metaData.MaskingMetadata.MaskTypes, routineMaskType(&routines[i]))
 		}
 	}
 
 	return metaData, nil
 }
+
+// PredefinedMaskTypes returns the mask types of the predefined masking expressions of BigQuery data policies.
+func PredefinedMaskTypes() []*ds.MaskingType {
+	return []*ds.MaskingType{
+		{
+			DisplayName: "NULL",
+			ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_ALWAYS_NULL)],
+			Description: "Returns NULL instead of the column value. Use this when you want to hide both the value and the data type of the column. When this data masking rule is applied to a column, it makes it less useful in query JOIN operations for users with Masked Reader access. This is because a NULL value isn't sufficiently unique to be useful when joining tables.",
+		},
+		{
+			DisplayName: "Hash (SHA-256)",
+			ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_SHA256)],
+			Description: "Returns the SHA-256 hash of the column's value. You can only use this rule with columns that use the STRING data type.",
+			DataTypes:   predefinedExpressionDataTypes[datapoliciespb.DataMaskingPolicy_SHA256],
+		},
+		{
+			DisplayName: "Last four characters",
+			ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_LAST_FOUR_CHARACTERS)],
+			Description: "Returns the last 4 characters of the column's value, replacing the rest of the string with XXXXX. If the column's value is equal to or less than 4 characters in length, then it returns the column's value after it has been run through the SHA-256 hash function. You can only use this rule with columns that use the STRING data type.",
+			DataTypes:   predefinedExpressionDataTypes[datapoliciespb.DataMaskingPolicy_LAST_FOUR_CHARACTERS],
+		},
+		{
+			DisplayName: "First four characters",
+			ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS)],
+			Description: "Returns the first 4 characters of the column's value, replacing the rest of the string with XXXXX. If the column's value is equal to or less than 4 characters in length, then it returns the column's value after it has been run through the SHA-256 hash function. You can only use this rule with columns that use the STRING data type.",
+			DataTypes:   predefinedExpressionDataTypes[datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS],
+		},
+		{
+			DisplayName: "Email mask",
+			ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_EMAIL_MASK)],
+			Description: "Returns the column's value after replacing the username of a valid email with XXXXX. If the column's value is not a valid email address, then it returns the column's value after it has been run through the SHA-256 hash function. You can only use this rule with columns that use the STRING data type.",
+			DataTypes:   predefinedExpressionDataTypes[datapoliciespb.DataMaskingPolicy_EMAIL_MASK],
+		},
+		{
+			DisplayName: "Default masking value",
+			ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_DEFAULT_MASKING_VALUE)],
+			Description: "Returns a default masking value for the column based on the column's data type. Use this when you want to hide the value of the column but reveal the data type. When this data masking rule is applied to a column, it makes it less useful in query JOIN operations for users with Masked Reader access. This is because a default value isn't sufficiently unique to be useful when joining tables.",
+		},
+		{
+			DisplayName: "Date year mask",
+			ExternalId:  datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_DATE_YEAR_MASK)],
+			Description: "Returns the column's value after truncating the value to its year, setting all non-year parts of the value to the beginning of the year. You can only use this rule with columns that use the DATE, DATETIME, and TIMESTAMP data types.",
+			DataTypes:   predefinedExpressionDataTypes[datapoliciespb.DataMaskingPolicy_DATE_YEAR_MASK],
+		},
+	}
+}
+
+func routineMaskType(routine *BQMaskingRoutine) *ds.MaskingType {
+	description := routine.Description
+	if description == "" {
+		description = fmt.Sprintf("Custom masking routine %s.%s.%s", routine.Project, routine.Dataset, routine.Routine)
+	}
+
+	maskType := &ds.MaskingType{
+		DisplayName: routine.Routine,
+		ExternalId:  routine.MaskingType().String(),
+		Description: description,
+	}
+
+	if routine.DataType != "" {
+		maskType.DataTypes = []string{routine.DataType}
+	}
+
+	return maskType
+}
+
+// columnPermissions returns the permissions on columns. Column-level access is granted through policy tags, which requires the catalog.
+func columnPermissions(catalogEnabled bool) []*ds.DataObjectTypePermission {
+	if !catalogEnabled {
+		return []*ds.DataObjectTypePermission{}
+	}
+
+	return []*ds.DataObjectTypePermission{
+		roles.RolesBigQueryCatalogFineGrainedAccess.ToDataObjectTypePermission(roles.ServiceBigQuery),
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/raito-io/golang-set/set"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/raito-io/cli-plugin-gcp/internal/common"
 	"github.com/raito-io/cli-plugin-gcp/internal/org"
@@ -28,40 +29,90 @@ const (
 	fineGrainedReaderRole = "roles/datacatalog.categoryFineGrainedReader"
 	taxonomy_prefix       = "raito_taxonomy_"
 
+	// raitoManagedTagMarker is added to the description of policy tags created by Raito in taxonomies that are not managed by Raito
+	raitoManagedTagMarker = "[Managed by Raito]"
+
 	idAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 )
 
+// projectNumberRegex matches the project number in resource names returned by the data policy API
+var projectNumberRegex = regexp.MustCompile(`projects/\d*/`)
+
 //go:generate go run github.com/vektra/mockery/v2 --name=dataCatalogBqRepository --with-expecter --inpackage
 type dataCatalogBqRepository interface {
 	ListDataSets(ctx context.Context, parent *org.GcpOrgEntity, fn func(ctx context.Context, entity *org.GcpOrgEntity, dataset *bigquery.Dataset) error) error
+	ListColumns(ctx context.Context, tab *bigquery.Table, parent *org.GcpOrgEntity, fn func(ctx context.Context, entity *org.GcpOrgEntity) error) error
 	Project() *org.GcpOrgEntity
+	InvalidateDataObjects(parentFullName string)
 }
 
 type DataCatalogRepository struct {
 	bigQueryRepo     dataCatalogBqRepository
 	policyTagClient  *datacatalog.PolicyTagManagerClient
+	policyTagReaders *PolicyTagReaders
 	dataPolicyClient *datapolicies.DataPolicyClient
 	bigQueryClient   *bigquery.Client
 
 	projectId string
 
+	// Existing taxonomies and parent policy tags in which masks are created, per location
+	taxonomies map[string]string
+	parentTags map[string]string
+
+	conflictResolution string
+
 	// Cache
-	dataPolicies map[string]BQMaskingInformation
-	datasetCache map[string]org.GcpOrgEntity
+	dataPolicies    map[string]BQMaskingInformation
+	datasetCache    map[string]org.GcpOrgEntity
+	raitoTaxonomies map[string]bool
+
+	// Policy tags attached by Raito to columns during this sync, to detect multiple masks on the same column
+	assignedPolicyTags map[string]string
+
+	// Column policy tag changes that are applied in a single schema update per table
+	pendingColumnUpdates map[string][]*columnPolicyTagUpdate
 }
 
 func NewDataCatalogRepository(repository dataCatalogBqRepository, tagClient *datacatalog.PolicyTagManagerClient, dataPolicyClient *datapolicies.DataPolicyClient, bqClient *bigquery.Client, configMap *config.ConfigMap) *DataCatalogRepository {
 	return &DataCatalogRepository{
 		bigQueryRepo:     repository,
 		policyTagClient:  tagClient,
+		policyTagReaders: NewPolicyTagReaders(tagClient),
 		dataPolicyClient: dataPolicyClient,
 		bigQueryClient:   bqClient,
 
 		projectId: configMap.GetString(common.GcpProjectId),
 
-		dataPolicies: make(map[string]BQMaskingInformation),
-		datasetCache: make(map[string]org.GcpOrgEntity),
+		taxonomies: resourcesPerLocation(configMap.GetString(common.BqMaskingTaxonomies)),
+		parentTags: resourcesPerLocation(configMap.GetString(common.BqMaskingParentPolicyTags)),
+
+		conflictResolution: configMap.GetStringWithDefault(common.BqPolicyTagConflictResolution, PolicyTagConflictFail),
+
+		dataPolicies:       make(map[string]BQMaskingInformation),
+		datasetCache:       make(map[string]org.GcpOrgEntity),
+		raitoTaxonomies:    make(map[string]bool),
+		assignedPolicyTags: make(map[string]string),
+
+		pendingColumnUpdates: make(map[string][]*columnPolicyTagUpdate),
+	}
+}
+
+// resourcesPerLocation maps a comma-separated list of resource names (projects/{project}/locations/{location}/...) on their location.
+func resourcesPerLocation(value string) map[string]string {
+	result := make(map[string]string)
+
+	for _, resource := range strings.Split(value, ",") {
+		resource = strings.TrimSpace(resource)
+
+		parts := strings.Split(resource, "/")
+		if len(parts) < 4 || parts[2] != "locations" {
+			continue
+		}
+
+		result[strings.ToLower(parts[3])] = resource
 	}
+
+	return result
 }
 
 func (r *DataCatalogRepository) UpdateAccess(ctx context.Context, maskingInformation *BQMaskingInformation, who *sync_to_target.WhoItem, deletedWho *sync_to_target.WhoItem) error {
@@ -79,10 +130,10 @@ func (r *DataCatalogRepository) UpdateAccess(ctx context.Context, maskingInforma
 	membersToDelete := set.NewSet[string]()
 
 	if deletedWho != nil {
-		membersToDelete.Add(parseWhoToMembers(deletedWho)...)
+		membersToDelete.Add(ParseWhoToMembers(deletedWho)...)
 	}
 
-	membersToAdd := parseWhoToMembers(who)
+	membersToAdd := ParseWhoToMembers(who)
 
 	updatedFineGrainedAccess := false
 
@@ -125,16 +176,38 @@ func (r *DataCatalogRepository) UpdateAccess(ctx context.Context, maskingInforma
 	return nil
 }
 
-func (r *DataCatalogRepository) UpdatePolicyTag(ctx context.Context, location string, maskingType datapoliciespb.DataMaskingPolicy_PredefinedExpression, ap *sync_to_target.AccessProvider, dataPolicyId string) (*BQMaskingInformation, error) {
+func (r *DataCatalogRepository) UpdatePolicyTag(ctx context.Context, location string, maskingType BQMaskingType, ap *sync_to_target.AccessProvider, dataPolicyId string) (*BQMaskingInformation, error) {
 	maskInfo, err := r.GetMaskingInformationForDataPolicy(ctx, dataPolicyId)
 	if err != nil {
 		return nil, err
 	}
 
 	if maskInfo == nil {
+		if IsFallbackDataPolicy(dataPolicyId) {
+			return r.CreateFallbackPolicyTagWithDataPolicy(ctx, location, ap)
+		}
+
 		return r.CreatePolicyTagWithDataPolicy(ctx, location, maskingType, ap)
 	}
 
+	if maskInfo.DataPolicy.MaskingType().String() != maskingType.String() {
+		_, err = r.dataPolicyClient.UpdateDataPolicy(ctx, &datapoliciespb.UpdateDataPolicyRequest{
+			DataPolicy: &datapoliciespb.DataPolicy{
+				Name: maskInfo.DataPolicy.FullName,
+				Policy: &datapoliciespb.DataPolicy_DataMaskingPolicy{
+					DataMaskingPolicy: maskingType.DataMaskingPolicy(),
+				},
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"data_masking_policy"}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("update masking type of data policy %q: %w", maskInfo.DataPolicy.FullName, err)
+		}
+
+		maskInfo.DataPolicy.PolicyType = maskingType.PredefinedExpression
+		maskInfo.DataPolicy.Routine = maskingType.Routine
+	}
+
 	var displayName string
 
 	if strings.HasPrefix(maskInfo.PolicyTag.Name, ap.Name+"_") {
@@ -143,11 +216,16 @@ func (r *DataCatalogRepository) UpdatePolicyTag(ctx context.Context, location st
 		displayName = createTagDisplayname(ap)
 	}
 
+	description := ap.Description
+	if isRaitoManagedTag(maskInfo.PolicyTag.Description) {
+		description = raitoManagedTagDescription(ap.Description)
+	}
+
 	_, err = r.policyTagClient.UpdatePolicyTag(ctx, &datacatalogpb.UpdatePolicyTagRequest{
 		PolicyTag: &datacatalogpb.PolicyTag{
 			Name:            maskInfo.PolicyTag.FullName,
 			DisplayName:     displayName,
-			Description:     ap.Description,
+			Description:     description,
 			ParentPolicyTag: maskInfo.PolicyTag.ParentTag,
 		},
 	})
@@ -157,7 +235,7 @@ func (r *DataCatalogRepository) UpdatePolicyTag(ctx context.Context, location st
 	}
 
 	maskInfo.PolicyTag.Name = displayName
-	maskInfo.PolicyTag.Description = ap.Description
+	maskInfo.PolicyTag.Description = description
 
 	return maskInfo, nil
 }
@@ -214,8 +292,7 @@ func (r *DataCatalogRepository) listDataPoliciesForLocation(ctx context.Context,
 				continue
 			}
 
-			keyRegex := regexp.MustCompile(`projects/\d*/`)
-			key := keyRegex.ReplaceAllString(policy.GetPolicyTag(), fmt.Sprintf("projects/%s/", r.projectId))
+			key := projectNumberRegex.ReplaceAllString(policy.GetPolicyTag(), fmt.Sprintf("projects/%s/", r.projectId))
 
 			result[key] = *maskingInformation
 		}
@@ -238,6 +315,11 @@ func (r *DataCatalogRepository) GetMaskingInformationForDataPolicy(ctx context.C
 func (r *DataCatalogRepository) createBqMaskingInformation(ctx context.Context, policy *datapoliciespb.DataPolicy) (*BQMaskingInformation, error) {
 	maskType := policy.GetDataMaskingPolicy().GetPredefinedExpression()
 
+	routine := policy.GetDataMaskingPolicy().GetRoutine()
+	if routine != "" {
+		routine = projectNumberRegex.ReplaceAllString(routine, fmt.Sprintf("projects/%s/", r.projectId))
+	}
+
 	policyTag, err := r.policyTagClient.GetPolicyTag(ctx, &datacatalogpb.GetPolicyTagRequest{Name: policy.GetPolicyTag()})
 
 	var e *googleapi.Error
@@ -247,17 +329,18 @@ func (r *DataCatalogRepository) createBqMaskingInformation(ctx context.Context,
 		return nil, fmt.Errorf("get policy tag %q: %w", policy.GetPolicyTag(), err)
 	}
 
+	bqPolicyTag, err := r.bqPolicyTag(ctx, policyTag)
+	if err != nil {
+		return nil, err
+	}
+
 	maskingInformation := &BQMaskingInformation{
 		DataPolicy: BQDataPolicy{
 			FullName:   policy.Name,
 			PolicyType: maskType,
+			Routine:    routine,
 		},
-		PolicyTag: BQPolicyTag{
-			FullName:    policyTag.Name,
-			Description: policyTag.Description,
-			Name:        policyTag.DisplayName,
-			ParentTag:   policyTag.ParentPolicyTag,
-		},
+		PolicyTag: *bqPolicyTag,
 	}
 
 	return maskingInformation, nil
@@ -294,35 +377,78 @@ func (r *DataCatalogRepository) DeletePolicyAndTag(ctx context.Context, policyTa
 		return fmt.Errorf("get taxonomy %q: %w", taxonomyId, err)
 	}
 
-	if strings.HasPrefix(taxonomy.GetDisplayName(), taxonomy_prefix) {
-		common.Logger.Debug(fmt.Sprintf("Delete policyTag: %s", info.PolicyTag.FullName))
+	raitoTaxonomy := r.isRaitoTaxonomy(taxonomy)
 
-		err = r.deletePolicyTag(ctx, info.PolicyTag.FullName)
-		if err != nil {
-			return fmt.Errorf("delete policy tag %q: %w", info.PolicyTag.FullName, err)
-		}
+	if !raitoTaxonomy && !isRaitoManagedTag(info.PolicyTag.Description) {
+		common.Logger.Debug(fmt.Sprintf("Policy tag %s is not created by Raito and is not deleted", info.PolicyTag.FullName))
+
+		return nil
+	}
+
+	common.Logger.Debug(fmt.Sprintf("Delete policyTag: %s", info.PolicyTag.FullName))
+
+	err = r.deletePolicyTag(ctx, info.PolicyTag.FullName)
+	if err != nil {
+		return fmt.Errorf("delete policy tag %q: %w", info.PolicyTag.FullName, err)
+	}
+
+	if !raitoTaxonomy {
+		return nil
+	}
 
-		taxonomy, err = r.policyTagClient.GetTaxonomy(ctx, &datacatalogpb.GetTaxonomyRequest{
-			Name: taxonomyId,
+	return r.deleteEmptyTaxonomy(ctx, taxonomyId)
+}
+
+// deleteEmptyTaxonomy deletes the taxonomy if it does not contain any policy tag anymore.
+func (r *DataCatalogRepository) deleteEmptyTaxonomy(ctx context.Context, taxonomyId string) error {
+	taxonomy, err := r.policyTagClient.GetTaxonomy(ctx, &datacatalogpb.GetTaxonomyRequest{
+		Name: taxonomyId,
+	})
+	if err != nil {
+		return fmt.Errorf("reload taxonomy %q: %w", taxonomyId, err)
+	}
+
+	if taxonomy.GetPolicyTagCount() == 0 {
+		common.Logger.Debug(fmt.Sprintf("Delete taxonomy: %s", taxonomy.GetName()))
+
+		err = r.policyTagClient.DeleteTaxonomy(ctx, &datacatalogpb.DeleteTaxonomyRequest{
+			Name: taxonomy.GetName(),
 		})
+
 		if err != nil {
-			return fmt.Errorf("reload taxonomy %q: %w", taxonomyId, err)
+			return fmt.Errorf("delete taxonomy %q: %w", taxonomy.GetName(), err)
 		}
+	}
 
-		if taxonomy.GetPolicyTagCount() == 0 {
-			common.Logger.Debug(fmt.Sprintf("Delete taxonomy: %s", taxonomy.GetName()))
-
-			err = r.policyTagClient.DeleteTaxonomy(ctx, &datacatalogpb.DeleteTaxonomyRequest{
-				Name: taxonomy.GetName(),
-			})
+	return nil
+}
 
-			if err != nil {
-				return fmt.Errorf("delete taxonomy %q: %w", taxonomy.GetName(), err)
-			}
+// isRaitoTaxonomy returns true if the taxonomy is created by Raito. Configured taxonomies are never considered Raito taxonomies.
+func (r *DataCatalogRepository) isRaitoTaxonomy(taxonomy *datacatalogpb.Taxonomy) bool {
+	for _, configured := range r.taxonomies {
+		if configured == taxonomy.GetName() {
+			return false
 		}
 	}
 
-	return nil
+	return strings.HasPrefix(taxonomy.GetDisplayName(), taxonomy_prefix)
+}
+
+// IsRaitoManagedPolicyTag returns true if the policy tag is created by Raito, either in a Raito taxonomy or marked as managed by Raito in its description.
+func IsRaitoManagedPolicyTag(taxonomyDisplayName string, tagDescription string) bool {
+	return strings.HasPrefix(taxonomyDisplayName, taxonomy_prefix) || isRaitoManagedTag(tagDescription)
+}
+
+func isRaitoManagedTag(description string) bool {
+	return strings.HasSuffix(description, raitoManagedTagMarker)
+}
+
+func raitoManagedTagDescription(description string) string {
+	if description == "" {
+		return raitoManagedTagMarker
+	}
+
+	return description + "\n" + raitoManagedTagMarker
 }
 
 func (r *DataCatalogRepository) deletePolicyTag(ctx context.Context, id string) error {
@@ -342,34 +468,105 @@ func (r *DataCatalogRepository) deleteDataPolicy(ctx context.Context, id string)
 
 	return nil
 }
-func (r *DataCatalogRepository) GetFineGrainedReaderMembers(ctx context.Context, tagId string) ([]string, error) {
-	common.Logger.Debug(fmt.Sprintf("Getting iam policy for policy tag %s", tagId))
 
-	iamPolicy, err := r.policyTagClient.GetIamPolicy(ctx, &iampb.GetIamPolicyRequest{
-		Resource: tagId,
-	})
+// GetFineGrainedReaderMembers returns the fine-grained readers of the policy tag and the readers inherited from its parent tags.
+func (r *DataCatalogRepository) GetFineGrainedReaderMembers(ctx context.Context, tagId string) (*FineGrainedReaders, error) {
+	return r.policyTagReaders.FineGrainedReaders(ctx, tagId)
+}
+
+func (r *DataCatalogRepository) CreatePolicyTagWithDataPolicy(ctx context.Context, location string, maskingType BQMaskingType, ap *sync_to_target.AccessProvider) (*BQMaskingInformation, error) {
+	dataPolicyId := gonanoid.MustGenerate(idAlphabet, 24) // Must be unique in the project and location
+
+	return r.createPolicyTagWithDataPolicy(ctx, location, maskingType, ap, dataPolicyId)
+}
 
+// CreateFallbackPolicyTagWithDataPolicy creates a policy tag with a DEFAULT_MASKING_VALUE data policy for the columns of a mask of which the data type is not supported by the mask type.
+// The data policy is marked as fallback by the suffix of its id.
+func (r *DataCatalogRepository) CreateFallbackPolicyTagWithDataPolicy(ctx context.Context, location string, ap *sync_to_target.AccessProvider) (*BQMaskingInformation, error) {
+	dataPolicyId := gonanoid.MustGenerate(idAlphabet, 24) + fallbackDataPolicySuffix
+
+	return r.createPolicyTagWithDataPolicy(ctx, location, BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_DEFAULT_MASKING_VALUE}, ap, dataPolicyId)
+}
+
+func (r *DataCatalogRepository) createPolicyTagWithDataPolicy(ctx context.Context, location string, maskingType BQMaskingType, ap *sync_to_target.AccessProvider, dataPolicyId string) (_ *BQMaskingInformation, err error) {
+	location = strings.ToLower(location)
+
+	// 1. Create policy tag in the configured taxonomy or under the configured parent policy tag
+	policyTag, err := r.createPolicyTag(ctx, location, r.parentTags[location], ap)
 	if err != nil {
-		return nil, fmt.Errorf("get iam policy for policy tag %q: %w", tagId, err)
+		return nil, err
 	}
 
-	var result []string
+	defer func() {
+		if err != nil {
+			r.policyTagClient.DeletePolicyTag(ctx, &datacatalogpb.DeletePolicyTagRequest{Name: policyTag.Name}) //nolint:errcheck
+		}
+	}()
+
+	// 3. Create data policy
+	dataPolicy, err := r.dataPolicyClient.CreateDataPolicy(ctx, &datapoliciespb.CreateDataPolicyRequest{
+		Parent: fmt.Sprintf("projects/%s/locations/%s", r.projectId, location),
+		DataPolicy: &datapoliciespb.DataPolicy{
+			DataPolicyId:   dataPolicyId,
+			DataPolicyType: datapoliciespb.DataPolicy_DATA_MASKING_POLICY,
+			MatchingLabel: &datapoliciespb.DataPolicy_PolicyTag{
+				PolicyTag: policyTag.Name,
+			},
+			Policy: &datapoliciespb.DataPolicy_DataMaskingPolicy{
+				DataMaskingPolicy: maskingType.DataMaskingPolicy(),
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("create data policy %q in policy tag %q: %w", dataPolicyId, policyTag.Name, err)
+	}
 
-	for _, binding := range iamPolicy.Bindings {
-		common.Logger.Debug(fmt.Sprintf("Binding for %q with role %q: %v", tagId, binding.Role, binding.Members))
+	return r.createBqMaskingInformation(ctx, dataPolicy)
+}
 
-		if binding.Role == fineGrainedReaderRole {
-			result = binding.Members
+// createPolicyTag creates a policy tag for the access provider in the taxonomy used by Raito in the location, optionally under a parent policy tag.
+func (r *DataCatalogRepository) createPolicyTag(ctx context.Context, location string, parentTag string, ap *sync_to_target.AccessProvider) (*datacatalogpb.PolicyTag, error) {
+	var taxonomyId string
+
+	switch {
+	case parentTag != "":
+		taxonomyId = (&BQPolicyTag{FullName: parentTag}).Taxonomy()
+	case r.taxonomies[location] != "":
+		taxonomyId = r.taxonomies[location]
+	default:
+		taxonomy, err := r.getOrCreateRaitoTaxonomy(ctx, location)
+		if err != nil {
+			return nil, err
 		}
+
+		taxonomyId = taxonomy.Name
 	}
 
-	return result, nil
-}
+	displayName := createTagDisplayname(ap)
 
-func (r *DataCatalogRepository) CreatePolicyTagWithDataPolicy(ctx context.Context, location string, maskingType datapoliciespb.DataMaskingPolicy_PredefinedExpression, ap *sync_to_target.AccessProvider) (_ *BQMaskingInformation, err error) {
-	location = strings.ToLower(location)
+	description := ap.Description
+	if parentTag != "" || r.taxonomies[location] != "" {
+		description = raitoManagedTagDescription(ap.Description)
+	}
+
+	policyTag, err := r.policyTagClient.CreatePolicyTag(ctx, &datacatalogpb.CreatePolicyTagRequest{
+		Parent: taxonomyId,
+		PolicyTag: &datacatalogpb.PolicyTag{
+			DisplayName:     displayName,
+			Description:     description,
+			ParentPolicyTag: parentTag,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create policy tag %q in taxonomy %q: %w", displayName, taxonomyId, err)
+	}
 
-	// 1. Create taxonomy if not exists
+	return policyTag, nil
+}
+
+// getOrCreateRaitoTaxonomy returns the taxonomy managed by Raito in the location, and creates it if it does not exist yet.
+func (r *DataCatalogRepository) getOrCreateRaitoTaxonomy(ctx context.Context, location string) (*datacatalogpb.Taxonomy, error) {
 	taxonomyName := taxonomy_prefix + location
 	parent := fmt.Sprintf("projects/%s/locations/%s", r.projectId, location)
 
@@ -380,14 +577,14 @@ func (r *DataCatalogRepository) CreatePolicyTagWithDataPolicy(ctx context.Contex
 	var taxonomy *datacatalogpb.Taxonomy
 
 	for {
-		tmpTax, err2 := taxIt.Next()
+		tmpTax, err := taxIt.Next()
 
-		if errors.Is(err2, iterator.Done) {
+		if errors.Is(err, iterator.Done) {
 			break
-		} else if err2 != nil {
-			common.Logger.Error(fmt.Sprintf("failed to list taxonomies with parent %q: %s", parent, err2.Error()))
+		} else if err != nil {
+			common.Logger.Error(fmt.Sprintf("failed to list taxonomies with parent %q: %s", parent, err.Error()))
 
-			return nil, fmt.Errorf("list taxonomies: %w", err2)
+			return nil, fmt.Errorf("list taxonomies: %w", err)
 		}
 
 		if tmpTax.DisplayName == taxonomyName {
@@ -399,68 +596,176 @@ func (r *DataCatalogRepository) CreatePolicyTagWithDataPolicy(ctx context.Contex
 		}
 	}
 
-	if taxonomy == nil {
-		taxonomy, err = r.policyTagClient.CreateTaxonomy(ctx, &datacatalogpb.CreateTaxonomyRequest{
-			Taxonomy: &datacatalogpb.Taxonomy{
-				DisplayName:          taxonomyName,
-				Description:          fmt.Sprintf("Raito managed taxonomy for location %s", location),
-				ActivatedPolicyTypes: []datacatalogpb.Taxonomy_PolicyType{datacatalogpb.Taxonomy_FINE_GRAINED_ACCESS_CONTROL},
-			},
-			Parent: parent,
-		})
+	if taxonomy != nil {
+		return taxonomy, nil
+	}
 
-		if err != nil {
-			return nil, fmt.Errorf("create taxonomy %q: %w", taxonomyName, err)
-		}
+	taxonomy, err := r.policyTagClient.CreateTaxonomy(ctx, &datacatalogpb.CreateTaxonomyRequest{
+		Taxonomy: &datacatalogpb.Taxonomy{
+			DisplayName:          taxonomyName,
+			Description:          fmt.Sprintf("Raito managed taxonomy for location %s", location),
+			ActivatedPolicyTypes: []datacatalogpb.Taxonomy_PolicyType{datacatalogpb.Taxonomy_FINE_GRAINED_ACCESS_CONTROL},
+		},
+		Parent: parent,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("create taxonomy %q: %w", taxonomyName, err)
 	}
 
-	// 2. Create policy tag
-	displayName := createTagDisplayname(ap)
+	return taxonomy, nil
+}
 
-	policyTag, err := r.policyTagClient.CreatePolicyTag(ctx, &datacatalogpb.CreatePolicyTagRequest{
-		Parent: taxonomy.Name,
+// GetPolicyTag returns the policy tag, or nil if the policy tag does not exist.
+func (r *DataCatalogRepository) GetPolicyTag(ctx context.Context, tagId string) (*BQPolicyTag, error) {
+	policyTag, err := r.policyTagClient.GetPolicyTag(ctx, &datacatalogpb.GetPolicyTagRequest{Name: tagId})
+
+	var e *googleapi.Error
+	if ok := errors.As(err, &e); ok && e.Code == 404 {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("get policy tag %q: %w", tagId, err)
+	}
+
+	return r.bqPolicyTag(ctx, policyTag)
+}
+
+// bqPolicyTag converts the policy tag and determines whether it is created by Raito, based on its taxonomy and description.
+func (r *DataCatalogRepository) bqPolicyTag(ctx context.Context, policyTag *datacatalogpb.PolicyTag) (*BQPolicyTag, error) {
+	result := &BQPolicyTag{
+		FullName:    policyTag.Name,
+		Description: policyTag.Description,
+		Name:        policyTag.DisplayName,
+		ParentTag:   policyTag.ParentPolicyTag,
+	}
+
+	raitoTaxonomy, err := r.isRaitoTaxonomyId(ctx, result.Taxonomy())
+	if err != nil {
+		return nil, err
+	}
+
+	result.RaitoManaged = raitoTaxonomy || isRaitoManagedTag(result.Description)
+
+	return result, nil
+}
+
+// isRaitoTaxonomyId returns true if the taxonomy is created by Raito. The result is cached per taxonomy.
+func (r *DataCatalogRepository) isRaitoTaxonomyId(ctx context.Context, taxonomyId string) (bool, error) {
+	if raitoTaxonomy, found := r.raitoTaxonomies[taxonomyId]; found {
+		return raitoTaxonomy, nil
+	}
+
+	taxonomy, err := r.policyTagClient.GetTaxonomy(ctx, &datacatalogpb.GetTaxonomyRequest{
+		Name: taxonomyId,
+	})
+	if err != nil {
+		return false, fmt.Errorf("get taxonomy %q: %w", taxonomyId, err)
+	}
+
+	r.raitoTaxonomies[taxonomyId] = r.isRaitoTaxonomy(taxonomy)
+
+	return r.raitoTaxonomies[taxonomyId], nil
+}
+
+// CreateColumnAccessPolicyTag creates a policy tag without data policy, which grants its fine-grained readers access to the tagged columns.
+// Column access policy tags are never created under the parent policy tag of masks.
+func (r *DataCatalogRepository) CreateColumnAccessPolicyTag(ctx context.Context, location string, ap *sync_to_target.AccessProvider) (*BQPolicyTag, error) {
+	policyTag, err := r.createPolicyTag(ctx, strings.ToLower(location), "", ap)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BQPolicyTag{
+		FullName:     policyTag.Name,
+		Description:  policyTag.Description,
+		Name:         policyTag.DisplayName,
+		ParentTag:    policyTag.ParentPolicyTag,
+		RaitoManaged: true,
+	}, nil
+}
+
+// UpdateColumnAccessPolicyTag updates the name and description of a column access policy tag created by Raito. Other policy tags are left untouched.
+func (r *DataCatalogRepository) UpdateColumnAccessPolicyTag(ctx context.Context, policyTag *BQPolicyTag, ap *sync_to_target.AccessProvider) (*BQPolicyTag, error) {
+	managed, _, err := r.isRaitoManagedPolicyTag(ctx, policyTag)
+	if err != nil {
+		return nil, err
+	}
+
+	if !managed {
+		return policyTag, nil
+	}
+
+	displayName := policyTag.Name
+	if !strings.HasPrefix(displayName, validSqlName(ap.NamingHint)+"_") {
+		displayName = createTagDisplayname(ap)
+	}
+
+	description := ap.Description
+	if isRaitoManagedTag(policyTag.Description) {
+		description = raitoManagedTagDescription(ap.Description)
+	}
+
+	if displayName == policyTag.Name && description == policyTag.Description {
+		return policyTag, nil
+	}
+
+	_, err = r.policyTagClient.UpdatePolicyTag(ctx, &datacatalogpb.UpdatePolicyTagRequest{
 		PolicyTag: &datacatalogpb.PolicyTag{
-			DisplayName: displayName,
-			Description: ap.Description,
+			Name:            policyTag.FullName,
+			DisplayName:     displayName,
+			Description:     description,
+			ParentPolicyTag: policyTag.ParentTag,
 		},
 	})
+	if err != nil {
+		return nil, fmt.Errorf("update policy tag %q: %w", policyTag.FullName, err)
+	}
 
+	updated := *policyTag
+	updated.Name = displayName
+	updated.Description = description
+
+	return &updated, nil
+}
+
+// DeleteColumnAccessPolicyTag deletes a column access policy tag if it is created by Raito, and returns false if the policy tag is not managed by Raito.
+func (r *DataCatalogRepository) DeleteColumnAccessPolicyTag(ctx context.Context, policyTag *BQPolicyTag) (bool, error) {
+	managed, taxonomy, err := r.isRaitoManagedPolicyTag(ctx, policyTag)
 	if err != nil {
-		return nil, fmt.Errorf("create policy tag %q in taxonomy %q: %w", displayName, taxonomy.Name, err)
+		return false, err
 	}
 
-	defer func() {
+	if !managed {
+		return false, nil
+	}
+
+	common.Logger.Debug(fmt.Sprintf("Delete policyTag: %s", policyTag.FullName))
+
+	err = r.deletePolicyTag(ctx, policyTag.FullName)
+	if err != nil {
+		return false, err
+	}
+
+	if r.isRaitoTaxonomy(taxonomy) {
+		err = r.deleteEmptyTaxonomy(ctx, taxonomy.GetName())
 		if err != nil {
-			r.policyTagClient.DeletePolicyTag(ctx, &datacatalogpb.DeletePolicyTagRequest{Name: policyTag.Name}) //nolint:errcheck
+			return true, err
 		}
-	}()
+	}
 
-	// 3. Create data policy
-	dataPolicyId := gonanoid.MustGenerate(idAlphabet, 24) // Must be unique in the project and location
+	return true, nil
+}
 
-	dataPolicy, err := r.dataPolicyClient.CreateDataPolicy(ctx, &datapoliciespb.CreateDataPolicyRequest{
-		Parent: fmt.Sprintf("projects/%s/locations/%s", r.projectId, location),
-		DataPolicy: &datapoliciespb.DataPolicy{
-			DataPolicyId:   dataPolicyId,
-			DataPolicyType: datapoliciespb.DataPolicy_DATA_MASKING_POLICY,
-			MatchingLabel: &datapoliciespb.DataPolicy_PolicyTag{
-				PolicyTag: policyTag.Name,
-			},
-			Policy: &datapoliciespb.DataPolicy_DataMaskingPolicy{
-				DataMaskingPolicy: &datapoliciespb.DataMaskingPolicy{
-					MaskingExpression: &datapoliciespb.DataMaskingPolicy_PredefinedExpression_{
-						PredefinedExpression: maskingType,
-					},
-				},
-			},
-		},
+// isRaitoManagedPolicyTag returns true if the policy tag is created by Raito, together with the taxonomy of the policy tag.
+func (r *DataCatalogRepository) isRaitoManagedPolicyTag(ctx context.Context, policyTag *BQPolicyTag) (bool, *datacatalogpb.Taxonomy, error) {
+	taxonomy, err := r.policyTagClient.GetTaxonomy(ctx, &datacatalogpb.GetTaxonomyRequest{
+		Name: policyTag.Taxonomy(),
 	})
-
 	if err != nil {
-		return nil, fmt.Errorf("create data policy %q in policy tag %q: %w", dataPolicyId, policyTag.Name, err)
+		return false, nil, fmt.Errorf("get taxonomy %q: %w", policyTag.Taxonomy(), err)
 	}
 
-	return r.createBqMaskingInformation(ctx, dataPolicy)
+	return r.isRaitoTaxonomy(taxonomy) || isRaitoManagedTag(policyTag.Description), taxonomy, nil
 }
 
 func createTagDisplayname(ap *sync_to_target.AccessProvider) string {
@@ -498,81 +803,56 @@ func (r *DataCatalogRepository) GetLocationsForDataObjects(ctx context.Context,
 	return dos, deletedDos, nil
 }
 
-type tableMaskUpdate struct {
-	ColumnsToAddMask    set.Set[string]
-	ColumnsToRemoveMask set.Set[string]
-}
-
-func (r *DataCatalogRepository) UpdateWhatOfDataPolicy(ctx context.Context, policy *BQMaskingInformation, dataObjects []string, deletedDataObjects []string) error {
-	columnsToUpdatePerTable := make(map[string]tableMaskUpdate)
+// GetColumnDataTypes returns the data type of the columns, e.g. STRING, as listed by the BigQuery repository.
+// Columns that are not found are not included in the result.
+func (r *DataCatalogRepository) GetColumnDataTypes(ctx context.Context, columns []string) (map[string]string, error) {
+	datasets, err := r.getDataSets(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	parseColumnsToUpdatePerTable := func(dos []string, toRemove bool) {
-		for _, do := range dos {
-			doNameSplit := strings.Split(do, ".")
-			tableName := strings.Join(doNameSplit[0:len(doNameSplit)-1], ".")
-			columnName := doNameSplit[len(doNameSplit)-1]
+	tables := set.NewSet[string]()
 
-			if maskUpdates, found := columnsToUpdatePerTable[tableName]; found {
-				if toRemove {
-					maskUpdates.ColumnsToRemoveMask.Add(columnName)
-					columnsToUpdatePerTable[tableName] = maskUpdates
-				} else {
-					maskUpdates.ColumnsToAddMask.Add(columnName)
-					columnsToUpdatePerTable[tableName] = maskUpdates
-				}
-			} else {
-				if toRemove {
-					columnsToUpdatePerTable[tableName] = tableMaskUpdate{
-						ColumnsToRemoveMask: set.NewSet(columnName),
-					}
-				} else {
-					columnsToUpdatePerTable[tableName] = tableMaskUpdate{
-						ColumnsToAddMask: set.NewSet(columnName),
-					}
-				}
-			}
-		}
+	for _, column := range columns {
+		tables.Add(column[:strings.LastIndex(column, ".")])
 	}
 
-	parseColumnsToUpdatePerTable(dataObjects, false)
-	parseColumnsToUpdatePerTable(deletedDataObjects, true)
+	result := make(map[string]string)
 
-	for table, maskUpdates := range columnsToUpdatePerTable {
+	for table := range tables {
 		nameSplit := strings.Split(table, ".")
-		ds := r.bigQueryClient.Dataset(nameSplit[1])
-		bqTable := ds.Table(nameSplit[2])
 
-		metadata, err := bqTable.Metadata(ctx)
-		if err != nil {
-			return fmt.Errorf("loading metadata for %q: %w", nameSplit[1:3], err)
+		dataset, found := datasets[strings.Join(nameSplit[0:2], ".")]
+		if !found || len(nameSplit) != 3 {
+			continue
 		}
 
-		var schemaUpdate []*bigquery.FieldSchema
+		tableEntity := &org.GcpOrgEntity{
+			Type:     "table",
+			Name:     nameSplit[2],
+			Id:       table,
+			FullName: table,
+			Parent:   &dataset,
+			Location: dataset.Location,
+		}
 
-		for _, column := range metadata.Schema {
-			if maskUpdates.ColumnsToAddMask.Contains(column.Name) {
-				if column.PolicyTags == nil {
-					column.PolicyTags = &bigquery.PolicyTagList{Names: []string{policy.PolicyTag.FullName}}
-				}
-			} else if maskUpdates.ColumnsToRemoveMask.Contains(column.Name) {
-				if column.PolicyTags != nil {
-					column.PolicyTags = &bigquery.PolicyTagList{Names: []string{}}
-				}
+		err = r.bigQueryRepo.ListColumns(ctx, nil, tableEntity, func(_ context.Context, entity *org.GcpOrgEntity) error {
+			if entity.DataType != nil {
+				result[entity.FullName] = *entity.DataType
 			}
 
-			schemaUpdate = append(schemaUpdate, column)
-		}
-
-		_, err = bqTable.Update(ctx, bigquery.TableMetadataToUpdate{
-			Schema: schemaUpdate,
-		}, metadata.ETag)
+			return nil
+		})
 
-		if err != nil {
-			return fmt.Errorf("update schema of table %q: %w", table, err)
+		var e *googleapi.Error
+		if ok := errors.As(err, &e); ok && e.Code == 404 {
+			common.Logger.Warn(fmt.Sprintf("Table %q not found. Unable to determine the data types of its columns", table))
+		} else if err != nil {
+			return nil, fmt.Errorf("list columns of table %q: %w", table, err)
 		}
 	}
 
-	return nil
+	return result, nil
 }
 
 func (r *DataCatalogRepository) getDataSets(ctx context.Context) (map[string]org.GcpOrgEntity, error) {
@@ -593,7 +873,8 @@ func (r *DataCatalogRepository) getDataSets(ctx context.Context) (map[string]org
 	return r.datasetCache, nil
 }
 
-func parseWhoToMembers(who *sync_to_target.WhoItem) []string {
+// ParseWhoToMembers converts the users and groups of a who item into IAM members.
+func ParseWhoToMembers(who *sync_to_target.WhoItem) []string {
 	if who == nil {
 		return nil
 	}
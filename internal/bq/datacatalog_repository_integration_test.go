@@ -29,7 +29,7 @@ func TestDataCatalogRepository_CrudPolicyTags(t *testing.T) {
 	var maskingInformation *BQMaskingInformation
 
 	t.Run("CreatePolicyTag", func(t *testing.T) {
-		maskingInformation, err = repo.CreatePolicyTagWithDataPolicy(ctx, "europe-west1", datapoliciespb.DataMaskingPolicy_ALWAYS_NULL, &sync_to_target.AccessProvider{
+		maskingInformation, err = repo.CreatePolicyTagWithDataPolicy(ctx, "europe-west1", BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_ALWAYS_NULL}, &sync_to_target.AccessProvider{
 			Name:       "test_policy_tag",
 			NamingHint: "test_policy_tag",
 		})
@@ -68,7 +68,7 @@ func TestDataCatalogRepository_CrudPolicyTags(t *testing.T) {
 	})
 }
 
-func TestDataCatalogRepository_UpdateWhatOfDataPolicy(t *testing.T) {
+func TestDataCatalogRepository_ApplyColumnPolicyTagUpdates(t *testing.T) {
 	ctx := context.Background()
 
 	repo, _, cleanup, err := createDataCatalogRepository(ctx, t)
@@ -76,7 +76,7 @@ func TestDataCatalogRepository_UpdateWhatOfDataPolicy(t *testing.T) {
 
 	defer cleanup()
 
-	maskingInformation, err := repo.CreatePolicyTagWithDataPolicy(ctx, "eu", datapoliciespb.DataMaskingPolicy_ALWAYS_NULL, &sync_to_target.AccessProvider{
+	maskingInformation, err := repo.CreatePolicyTagWithDataPolicy(ctx, "eu", BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_ALWAYS_NULL}, &sync_to_target.AccessProvider{
 		Name: "update_what_test",
 	})
 
@@ -89,8 +89,9 @@ func TestDataCatalogRepository_UpdateWhatOfDataPolicy(t *testing.T) {
 	columnName := "raito-integration-test.RAITO_TESTING.Person_Password.PasswordSalt"
 
 	t.Run("Add policy tag to column", func(t *testing.T) {
-		err = repo.UpdateWhatOfDataPolicy(ctx, maskingInformation, []string{columnName}, nil)
-		require.NoError(t, err)
+		result := repo.AddColumnPolicyTagUpdate(maskingInformation, []string{columnName}, nil)
+		repo.ApplyColumnPolicyTagUpdates(ctx)
+		require.Empty(t, result.Errors)
 
 		metadata, err := repo.bigQueryClient.Dataset("RAITO_TESTING").Table("Person_Password").Metadata(ctx)
 		require.NoError(t, err)
@@ -124,8 +125,9 @@ func TestDataCatalogRepository_UpdateWhatOfDataPolicy(t *testing.T) {
 	})
 
 	t.Run("Delete policy tag from column", func(t *testing.T) {
-		err = repo.UpdateWhatOfDataPolicy(ctx, maskingInformation, nil, []string{columnName})
-		require.NoError(t, err)
+		result := repo.AddColumnPolicyTagUpdate(maskingInformation, nil, []string{columnName})
+		repo.ApplyColumnPolicyTagUpdates(ctx)
+		require.Empty(t, result.Errors)
 
 		metadata, err := repo.bigQueryClient.Dataset("RAITO_TESTING").Table("Person_Password").Metadata(ctx)
 		require.NoError(t, err)
@@ -181,7 +183,7 @@ func TestDataCatalogRepository_UpdateAccess(t *testing.T) {
 
 	defer cleanup()
 
-	maskingInformation, err := repo.CreatePolicyTagWithDataPolicy(ctx, "eu", datapoliciespb.DataMaskingPolicy_ALWAYS_NULL, &sync_to_target.AccessProvider{
+	maskingInformation, err := repo.CreatePolicyTagWithDataPolicy(ctx, "eu", BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_ALWAYS_NULL}, &sync_to_target.AccessProvider{
 		Name: "update_access_test",
 	})
 
@@ -193,8 +195,9 @@ func TestDataCatalogRepository_UpdateAccess(t *testing.T) {
 
 	columnName := "raito-integration-test.RAITO_TESTING.Production_ProductCategory.Name"
 
-	err = repo.UpdateWhatOfDataPolicy(ctx, maskingInformation, []string{columnName}, nil)
-	require.NoError(t, err)
+	result := repo.AddColumnPolicyTagUpdate(maskingInformation, []string{columnName}, nil)
+	repo.ApplyColumnPolicyTagUpdates(ctx)
+	require.Empty(t, result.Errors)
 
 	whoItem := sync_to_target.WhoItem{
 		Users:  []string{"d_hayden@raito.dev"},
@@ -208,7 +211,7 @@ func TestDataCatalogRepository_UpdateAccess(t *testing.T) {
 		members, err := repo.GetFineGrainedReaderMembers(ctx, maskingInformation.PolicyTag.FullName)
 		require.NoError(t, err)
 
-		assert.ElementsMatch(t, members, []string{"user:d_hayden@raito.dev", "group:sales@raito.dev"})
+		assert.ElementsMatch(t, members.Direct, []string{"user:d_hayden@raito.dev", "group:sales@raito.dev"})
 	})
 
 	t.Run("Delete Access from mask", func(t *testing.T) {
@@ -218,7 +221,7 @@ func TestDataCatalogRepository_UpdateAccess(t *testing.T) {
 		members, err := repo.GetFineGrainedReaderMembers(ctx, maskingInformation.PolicyTag.FullName)
 		require.NoError(t, err)
 
-		assert.Empty(t, members)
+		assert.Empty(t, members.Direct)
 	})
 
 }
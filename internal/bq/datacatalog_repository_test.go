@@ -0,0 +1,62 @@
+package bigquery
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+func TestResourcesPerLocation(t *testing.T) {
+	assert.Equal(t, map[string]string{}, resourcesPerLocation(""))
+	assert.Equal(t, map[string]string{
+		"eu":           "projects/project1/locations/EU/taxonomies/123",
+		"europe-west1": "projects/project1/locations/europe-west1/taxonomies/456/policyTags/789",
+	}, resourcesPerLocation("projects/project1/locations/EU/taxonomies/123, projects/project1/locations/europe-west1/taxonomies/456/policyTags/789,invalid"))
+}
+
+func TestRaitoManagedTagDescription(t *testing.T) {
+	assert.Equal(t, raitoManagedTagMarker, raitoManagedTagDescription(""))
+	assert.Equal(t, "Mask for PII\n"+raitoManagedTagMarker, raitoManagedTagDescription("Mask for PII"))
+
+	assert.True(t, isRaitoManagedTag(raitoManagedTagDescription("Mask for PII")))
+	assert.False(t, isRaitoManagedTag("Curated tag"))
+}
+
+func TestDataCatalogRepository_GetColumnDataTypes(t *testing.T) {
+	bqRepo := newMockDataCatalogBqRepository(t)
+
+	dataset := org.GcpOrgEntity{Type: "dataset", Name: "ds1", Id: "project1.ds1", FullName: "project1.ds1", Location: "EU"}
+
+	bqRepo.EXPECT().ListColumns(mock.Anything, (*bigquery.Table)(nil), mock.MatchedBy(func(table *org.GcpOrgEntity) bool {
+		return table.FullName == "project1.ds1.table1" && table.Name == "table1" && table.Parent.Name == "ds1"
+	}), mock.Anything).RunAndReturn(func(ctx context.Context, _ *bigquery.Table, parent *org.GcpOrgEntity, fn func(context.Context, *org.GcpOrgEntity) error) error {
+		for _, column := range []org.GcpOrgEntity{
+			{Type: "column", Name: "column1", FullName: "project1.ds1.table1.column1", Parent: parent, DataType: ptr.String("STRING")},
+			{Type: "column", Name: "column2", FullName: "project1.ds1.table1.column2", Parent: parent, DataType: ptr.String("INTEGER")},
+		} {
+			err := fn(ctx, &column)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}).Once()
+
+	repo := &DataCatalogRepository{bigQueryRepo: bqRepo, datasetCache: map[string]org.GcpOrgEntity{"project1.ds1": dataset}}
+
+	result, err := repo.GetColumnDataTypes(context.Background(), []string{"project1.ds1.table1.column1", "project1.ds1.table1.column2", "project1.unknown.table1.column1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"project1.ds1.table1.column1": "STRING",
+		"project1.ds1.table1.column2": "INTEGER",
+	}, result)
+}
@@ -3,6 +3,7 @@ package bigquery
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"cloud.google.com/go/bigquery"
 	ds "github.com/raito-io/cli/base/data_source"
@@ -15,18 +16,46 @@ import (
 
 type DataObjectIterator struct {
 	repo      *Repository
+	tagRepo   catalogTagRepository
 	projectId string
+
+	catalogTagsEnabled bool
+	lastTableTags      *entryTags
+	lastTable          string
 }
 
-func NewDataObjectIterator(repo *Repository, configMap *config.ConfigMap) *DataObjectIterator {
+func NewDataObjectIterator(repo *Repository, tagRepo catalogTagRepository, configMap *config.ConfigMap) *DataObjectIterator {
 	return &DataObjectIterator{
 		repo:      repo,
+		tagRepo:   tagRepo,
 		projectId: configMap.GetString(common.GcpProjectId),
+
+		catalogTagsEnabled: configMap.GetBoolWithDefault(common.BqCatalogTagsEnabled, false),
 	}
 }
 
 func (it *DataObjectIterator) DataObjects(ctx context.Context, config *ds.DataSourceSyncConfig, fn func(ctx context.Context, object *org.GcpOrgEntity) error) error {
-	return it.Sync(ctx, config, false, fn)
+	handler := fn
+
+	if it.catalogTagsEnabled {
+		handler = func(ctx context.Context, object *org.GcpOrgEntity) error {
+			err := it.addCatalogTags(ctx, object)
+			if err != nil {
+				return fmt.Errorf("add catalog tags to %q: %w", object.FullName, err)
+			}
+
+			return fn(ctx, object)
+		}
+	}
+
+	err := it.Sync(ctx, config, false, handler)
+	if err != nil {
+		return err
+	}
+
+	it.repo.incremental.completeSync()
+
+	return nil
 }
 
 func (it *DataObjectIterator) Sync(ctx context.Context, config *ds.DataSourceSyncConfig, skipColumns bool, fn func(ctx context.Context, object *org.GcpOrgEntity) error) error {
@@ -103,10 +132,98 @@ func (it *DataObjectIterator) Bindings(ctx context.Context, config *ds.DataSourc
 	})
 }
 
+// GetBindings returns the current bindings of a single data object.
+func (it *DataObjectIterator) GetBindings(ctx context.Context, dataObject *iam.DataObjectReference) ([]iam.IamBinding, error) {
+	return it.repo.GetBindings(ctx, &org.GcpOrgEntity{Id: dataObject.FullName, FullName: dataObject.FullName, Type: dataObject.ObjectType})
+}
+
 func (it *DataObjectIterator) UpdateBindings(ctx context.Context, dataObject *iam.DataObjectReference, addBindings []iam.IamBinding, removeBindings []iam.IamBinding) error {
 	return it.repo.UpdateBindings(ctx, dataObject, addBindings, removeBindings)
 }
 
+func (it *DataObjectIterator) UpdateMetadata(ctx context.Context, update *org.MetadataUpdate) error {
+	return it.repo.UpdateMetadata(ctx, update)
+}
+
 func (it *DataObjectIterator) DataSourceType() string {
 	return "project"
 }
+
+// addCatalogTags adds the Data Catalog tags of datasets, tables and columns to the tags of the object.
+// The tags of the columns are loaded together with the tags of their table.
+func (it *DataObjectIterator) addCatalogTags(ctx context.Context, object *org.GcpOrgEntity) error {
+	var tags map[string]string
+
+	switch {
+	case object.Type == ds.Dataset:
+		objectTags, err := it.loadCatalogTags(ctx, fmt.Sprintf("//bigquery.googleapis.com/projects/%s/datasets/%s", it.projectId, object.Name))
+		if err != nil {
+			return err
+		}
+
+		tags = objectTags.tags
+	case slices.Contains(tableDataObjectTypes, object.Type):
+		objectTags, err := it.tableCatalogTags(ctx, object)
+		if err != nil {
+			return err
+		}
+
+		tags = objectTags.tags
+	case object.Type == ds.Column:
+		if object.Parent == nil || object.Parent.Parent == nil {
+			return nil
+		}
+
+		// The table itself is not necessarily handled, so the tags are loaded through the parent of the column
+		objectTags, err := it.tableCatalogTags(ctx, object.Parent)
+		if err != nil {
+			return err
+		}
+
+		tags = objectTags.columnsTags[object.Name]
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if object.Tags == nil {
+		object.Tags = make(map[string]string, len(tags))
+	}
+
+	for k, v := range tags {
+		object.Tags[k] = v
+	}
+
+	return nil
+}
+
+// tableCatalogTags returns the tags of the table and its columns. The tags of the last table are kept, as its columns are handled right after the table.
+func (it *DataObjectIterator) tableCatalogTags(ctx context.Context, table *org.GcpOrgEntity) (*entryTags, error) {
+	if it.lastTable == table.FullName {
+		return it.lastTableTags, nil
+	}
+
+	objectTags, err := it.loadCatalogTags(ctx, fmt.Sprintf("//bigquery.googleapis.com/projects/%s/datasets/%s/tables/%s", it.projectId, table.Parent.Name, table.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	it.lastTable = table.FullName
+	it.lastTableTags = objectTags
+
+	return objectTags, nil
+}
+
+func (it *DataObjectIterator) loadCatalogTags(ctx context.Context, linkedResource string) (*entryTags, error) {
+	catalogTags, err := it.tagRepo.ListEntryTags(ctx, linkedResource)
+	if isIgnorableCatalogTagError(err) {
+		common.Logger.Warn(fmt.Sprintf("Unable to load Data Catalog tags for %q: %s", linkedResource, err.Error()))
+
+		return newEntryTags(nil), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return newEntryTags(catalogTags), nil
+}
@@ -0,0 +1,173 @@
+package bigquery
+
+import (
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/raito-io/golang-set/set"
+
+	iam2 "github.com/raito-io/cli-plugin-gcp/internal/iam"
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+const (
+	DatasetAccessModeLegacy = "legacy"
+	DatasetAccessModeIam    = "iam"
+)
+
+// datasetAccessPolicyVersion is the IAM policy version used to read and update dataset access.
+// BigQuery exposes the IAM policy of a dataset as the access entries of the dataset (there is no getIamPolicy/setIamPolicy for datasets):
+// each entry is a role binding of a single member and, with version 3, may hold an IAM condition.
+// Version 3 is always used, so conditional access entries are returned as-is and are never dropped by an update, regardless of the access mode of the dataset.
+const datasetAccessPolicyVersion = 3
+
+// useDatasetIamPolicy returns true if the access of a dataset is managed as an IAM policy (any role and IAM conditions)
+// instead of the legacy access list (basic roles only).
+func (c *Repository) useDatasetIamPolicy(dataset string) bool {
+	return c.datasetAccessMode == DatasetAccessModeIam || c.iamModeDatasets.Contains(dataset)
+}
+
+// datasetIamBindings returns all bindings of the dataset access, including the bindings of IAM members, domains and conditional bindings.
+func datasetIamBindings(entity *org.GcpOrgEntity, access []*bigquery.AccessEntry) []iam2.IamBinding {
+	var bindings []iam2.IamBinding
+
+	for _, a := range access {
+		member, ok := accessEntryMember(a)
+		if !ok {
+			continue
+		}
+
+		binding := iam2.IamBinding{
+			Role:         getRoleForBQEntity(a.Role),
+			Member:       member,
+			Resource:     entity.Id,
+			ResourceType: "dataset",
+		}
+
+		if a.Condition != nil {
+			binding.Condition = a.Condition.Expression
+		}
+
+		bindings = append(bindings, binding)
+	}
+
+	return bindings
+}
+
+// mergeIamBindings merges the bindings to add and remove into the access entries of a dataset.
+// Bindings are matched on role, member and condition. Roles are added as-is, so any predefined or custom role can be granted.
+func mergeIamBindings(existingAccess []*bigquery.AccessEntry, bindingsToAdd []iam2.IamBinding, bindingsToRemove []iam2.IamBinding) (*bigquery.DatasetMetadataToUpdate, error) {
+	update := bigquery.DatasetMetadataToUpdate{
+		Access: []*bigquery.AccessEntry{},
+	}
+
+	toRemove := set.NewSet[string]()
+	for i := range bindingsToRemove {
+		toRemove.Add(iamBindingKey(bindingsToRemove[i].Role, bindingsToRemove[i].Member, bindingsToRemove[i].Condition))
+	}
+
+	existing := set.NewSet[string]()
+
+	// Remove old bindings
+	for _, a := range existingAccess {
+		member, ok := accessEntryMember(a)
+		if !ok {
+			update.Access = append(update.Access, a)
+
+			continue
+		}
+
+		condition := ""
+		if a.Condition != nil {
+			condition = a.Condition.Expression
+		}
+
+		key := iamBindingKey(getRoleForBQEntity(a.Role), member, condition)
+
+		if toRemove.Contains(key) {
+			continue
+		}
+
+		existing.Add(key)
+		update.Access = append(update.Access, a)
+	}
+
+	// Add new bindings
+	for i := range bindingsToAdd {
+		b := &bindingsToAdd[i]
+
+		key := iamBindingKey(b.Role, b.Member, b.Condition)
+		if existing.Contains(key) {
+			continue
+		}
+
+		existing.Add(key)
+
+		entityType, entityId, err := parseIamMember(b.Member)
+		if err != nil {
+			return nil, fmt.Errorf("parse member %q: %w", b.Member, err)
+		}
+
+		entry := &bigquery.AccessEntry{
+			Role:       bigquery.AccessRole(b.Role),
+			EntityType: entityType,
+			Entity:     entityId,
+		}
+
+		if b.Condition != "" {
+			entry.Condition = &bigquery.Expr{Expression: b.Condition}
+		}
+
+		update.Access = append(update.Access, entry)
+	}
+
+	return &update, nil
+}
+
+func iamBindingKey(role string, member string, condition string) string {
+	return fmt.Sprintf("%s|%s|%s", getRoleForBQEntity(bigquery.AccessRole(role)), strings.ToLower(member), condition)
+}
+
+// accessEntryMember returns the IAM member of an access entry. Entries that do not grant access to a principal (e.g. authorized views) are ignored.
+func accessEntryMember(a *bigquery.AccessEntry) (string, bool) {
+	switch a.EntityType {
+	case bigquery.UserEmailEntity:
+		if strings.Contains(a.Entity, "gserviceaccount") {
+			return serviceAccountPrefix + a.Entity, true
+		}
+
+		return userPrefix + a.Entity, true
+	case bigquery.GroupEmailEntity:
+		return groupPrefix + a.Entity, true
+	case bigquery.SpecialGroupEntity:
+		return specialGroupPrefix + a.Entity, true
+	case bigquery.DomainEntity:
+		return "domain:" + a.Entity, true
+	case bigquery.IAMMemberEntity:
+		return a.Entity, true
+	default:
+		return "", false
+	}
+}
+
+// parseIamMember returns the access entry entity of an IAM member. Members that have no dedicated entity type are added as IAM member.
+func parseIamMember(m string) (bigquery.EntityType, string, error) {
+	prefix, entity, found := strings.Cut(m, ":")
+	if !found || entity == "" {
+		return bigquery.IAMMemberEntity, "", fmt.Errorf("invalid member format: %s", m)
+	}
+
+	switch prefix {
+	case "user", "serviceAccount":
+		return bigquery.UserEmailEntity, entity, nil
+	case "group":
+		return bigquery.GroupEmailEntity, entity, nil
+	case "special_group":
+		return bigquery.SpecialGroupEntity, entity, nil
+	case "domain":
+		return bigquery.DomainEntity, entity, nil
+	default:
+		return bigquery.IAMMemberEntity, m, nil
+	}
+}
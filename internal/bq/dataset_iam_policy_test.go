@@ -0,0 +1,116 @@
+package bigquery
+
+import (
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/raito-io/golang-set/set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/iam"
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+const testCondition = "request.time < timestamp('2030-01-01T00:00:00Z')"
+
+func TestRepository_useDatasetIamPolicy(t *testing.T) {
+	repo := &Repository{datasetAccessMode: DatasetAccessModeLegacy, iamModeDatasets: set.NewSet("ds2")}
+
+	assert.False(t, repo.useDatasetIamPolicy("ds1"))
+	assert.True(t, repo.useDatasetIamPolicy("ds2"))
+
+	repo.datasetAccessMode = DatasetAccessModeIam
+
+	assert.True(t, repo.useDatasetIamPolicy("ds1"))
+}
+
+func TestDatasetIamBindings(t *testing.T) {
+	entity := &org.GcpOrgEntity{Id: "project1.ds1"}
+
+	bindings := datasetIamBindings(entity, []*bigquery.AccessEntry{
+		{Role: bigquery.ReaderRole, EntityType: bigquery.UserEmailEntity, Entity: "user@raito.io"},
+		{Role: bigquery.WriterRole, EntityType: bigquery.UserEmailEntity, Entity: "sa@project1.iam.gserviceaccount.com"},
+		{Role: "roles/bigquery.metadataViewer", EntityType: bigquery.GroupEmailEntity, Entity: "group@raito.io", Condition: &bigquery.Expr{Expression: testCondition}},
+		{Role: bigquery.ReaderRole, EntityType: bigquery.DomainEntity, Entity: "raito.io"},
+		{Role: bigquery.ReaderRole, EntityType: bigquery.IAMMemberEntity, Entity: "principalSet://iam.googleapis.com/locations/global/workforcePools/pool/*"},
+		{EntityType: bigquery.ViewEntity, View: &bigquery.Table{ProjectID: "project1", DatasetID: "ds2", TableID: "view"}},
+	})
+
+	assert.Equal(t, []iam.IamBinding{
+		{Role: "roles/bigquery.dataViewer", Member: "user:user@raito.io", Resource: "project1.ds1", ResourceType: "dataset"},
+		{Role: "roles/bigquery.dataEditor", Member: "serviceAccount:sa@project1.iam.gserviceaccount.com", Resource: "project1.ds1", ResourceType: "dataset"},
+		{Role: "roles/bigquery.metadataViewer", Member: "group:group@raito.io", Resource: "project1.ds1", ResourceType: "dataset", Condition: testCondition},
+		{Role: "roles/bigquery.dataViewer", Member: "domain:raito.io", Resource: "project1.ds1", ResourceType: "dataset"},
+		{Role: "roles/bigquery.dataViewer", Member: "principalSet://iam.googleapis.com/locations/global/workforcePools/pool/*", Resource: "project1.ds1", ResourceType: "dataset"},
+	}, bindings)
+}
+
+func TestMergeIamBindings(t *testing.T) {
+	view := &bigquery.AccessEntry{EntityType: bigquery.ViewEntity, View: &bigquery.Table{ProjectID: "project1", DatasetID: "ds2", TableID: "view"}}
+
+	existing := []*bigquery.AccessEntry{
+		{Role: bigquery.ReaderRole, EntityType: bigquery.UserEmailEntity, Entity: "user@raito.io"},
+		{Role: bigquery.ReaderRole, EntityType: bigquery.UserEmailEntity, Entity: "user2@raito.io", Condition: &bigquery.Expr{Expression: testCondition, Title: "expires", Description: "Temporary access"}},
+		{Role: bigquery.WriterRole, EntityType: bigquery.GroupEmailEntity, Entity: "group@raito.io"},
+		view,
+	}
+
+	update, err := mergeIamBindings(existing, []iam.IamBinding{
+		{Role: "roles/bigquery.dataViewer", Member: "user:user@raito.io"},
+		{Role: "roles/bigquery.metadataViewer", Member: "group:group2@raito.io", Condition: testCondition},
+		{Role: "roles/bigquery.dataViewer", Member: "principal://iam.googleapis.com/locations/global/workforcePools/pool/subject/user3"},
+	}, []iam.IamBinding{
+		{Role: "roles/bigquery.dataViewer", Member: "user:user2@raito.io"},
+		{Role: "roles/bigquery.dataEditor", Member: "group:group@raito.io"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []*bigquery.AccessEntry{
+		existing[0],
+		existing[1],
+		view,
+		{Role: "roles/bigquery.metadataViewer", EntityType: bigquery.GroupEmailEntity, Entity: "group2@raito.io", Condition: &bigquery.Expr{Expression: testCondition}},
+		{Role: "roles/bigquery.dataViewer", EntityType: bigquery.IAMMemberEntity, Entity: "principal://iam.googleapis.com/locations/global/workforcePools/pool/subject/user3"},
+	}, update.Access)
+
+	// Untouched conditional entries are kept as-is, including the title and description of the condition
+	assert.Same(t, existing[1], update.Access[1])
+
+	// Conditional bindings are only removed if the condition matches
+	update, err = mergeIamBindings(existing, nil, []iam.IamBinding{
+		{Role: "roles/bigquery.dataViewer", Member: "user:user2@raito.io", Condition: testCondition},
+	})
+
+	require.NoError(t, err)
+	assert.NotContains(t, update.Access, existing[1])
+	assert.Len(t, update.Access, 3)
+}
+
+func TestParseIamMember(t *testing.T) {
+	tests := []struct {
+		member         string
+		wantEntityType bigquery.EntityType
+		wantEntity     string
+		wantErr        assert.ErrorAssertionFunc
+	}{
+		{member: "user:user@raito.io", wantEntityType: bigquery.UserEmailEntity, wantEntity: "user@raito.io", wantErr: assert.NoError},
+		{member: "serviceAccount:sa@project1.iam.gserviceaccount.com", wantEntityType: bigquery.UserEmailEntity, wantEntity: "sa@project1.iam.gserviceaccount.com", wantErr: assert.NoError},
+		{member: "group:group@raito.io", wantEntityType: bigquery.GroupEmailEntity, wantEntity: "group@raito.io", wantErr: assert.NoError},
+		{member: "special_group:projectReaders", wantEntityType: bigquery.SpecialGroupEntity, wantEntity: "projectReaders", wantErr: assert.NoError},
+		{member: "domain:raito.io", wantEntityType: bigquery.DomainEntity, wantEntity: "raito.io", wantErr: assert.NoError},
+		{member: "principal://iam.googleapis.com/subject/user", wantEntityType: bigquery.IAMMemberEntity, wantEntity: "principal://iam.googleapis.com/subject/user", wantErr: assert.NoError},
+		{member: "invalid", wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.member, func(t *testing.T) {
+			entityType, entity, err := parseIamMember(tt.member)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.wantEntityType, entityType)
+			assert.Equal(t, tt.wantEntity, entity)
+		})
+	}
+}
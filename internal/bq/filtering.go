@@ -2,6 +2,8 @@ package bigquery
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -23,6 +25,10 @@ import (
 	"github.com/raito-io/cli-plugin-gcp/internal/org"
 )
 
+// raitoFilterPrefix is the prefix of the names of row access policies created by Raito.
+// The names also end with a checksum of the prefixed name, so row access policies of customers that happen to start with the prefix are not mistaken for Raito filters.
+const raitoFilterPrefix = "raito_"
+
 //go:generate go run github.com/vektra/mockery/v2 --name=filteringRepository --with-expecter --inpackage
 type filteringRepository interface {
 	ListFilters(ctx context.Context, table *org.GcpOrgEntity, fn func(ctx context.Context, rap *bigquery.RowAccessPolicy, users []string, groups []string, internalizable bool) error) error
@@ -49,14 +55,14 @@ func NewBqFilteringService(filteringRepository filteringRepository, dataObjectIt
 
 func (s *BqFilteringService) ImportFilters(ctx context.Context, config *ds.DataSourceSyncConfig, accessProviderHandler wrappers.AccessProviderHandler, raitoFilters set.Set[string]) error {
 	err := s.dataObjectIterator.Sync(ctx, config, true, func(ctx context.Context, object *org.GcpOrgEntity) error {
-		if object.Type != ds.Table {
+		if !isFilterableDataObjectType(object.Type) {
 			return nil
 		}
 
 		err := s.filteringRepository.ListFilters(ctx, object, func(ctx context.Context, rap *bigquery.RowAccessPolicy, users []string, groups []string, internalizable bool) error {
 			externalId := fmt.Sprintf("%s.%s.%s.%s", rap.RowAccessPolicyReference.ProjectId, rap.RowAccessPolicyReference.DatasetId, rap.RowAccessPolicyReference.TableId, rap.RowAccessPolicyReference.PolicyId)
 
-			if raitoFilters.Contains(externalId) {
+			if raitoFilters.Contains(externalId) || isRaitoFilter(rap.RowAccessPolicyReference.PolicyId) {
 				return nil
 			}
 
@@ -71,7 +77,7 @@ func (s *BqFilteringService) ImportFilters(ctx context.Context, config *ds.DataS
 					{
 						DataObject: &ds.DataObjectReference{
 							FullName: fmt.Sprintf("%s.%s.%s", rap.RowAccessPolicyReference.ProjectId, rap.RowAccessPolicyReference.DatasetId, rap.RowAccessPolicyReference.TableId),
-							Type:     ds.Table,
+							Type:     object.Type,
 						},
 					},
 				},
@@ -123,7 +129,7 @@ func (s *BqFilteringService) ExportFilter(ctx context.Context, accessProvider *s
 		}
 
 		return nil, nil
-	} else if accessProvider.What[0].DataObject.Type != ds.Table {
+	} else if !isFilterableDataObjectType(accessProvider.What[0].DataObject.Type) {
 		err := accessProviderFeedbackHandler.AddAccessProviderFeedback(sync_to_target.AccessProviderSyncFeedback{
 			AccessProvider: accessProvider.Id,
 			Errors:         []string{fmt.Sprintf("filter what type (%s) is not supported", accessProvider.What[0].DataObject.Type)},
@@ -205,12 +211,27 @@ func (s *BqFilteringService) createOrUpdateFilter(ctx context.Context, table BQR
 		return nil, nil, fmt.Errorf("access provider policy rule or filter criteria is required")
 	}
 
-	var filterName string
+	var filterName, previousFilterName string
+
+	// The table of the previous row access policy of the access provider, which is replaced if it is renamed or if the filter moved to another table
+	var previousTable *BQReferencedTable
 
 	if ap.ExternalId != nil {
-		filterName = strings.SplitN(*ap.ExternalId, ".", 4)[3]
+		externalIdSplit := strings.SplitN(*ap.ExternalId, ".", 4)
+		previousFilterName = externalIdSplit[3]
+		filterName = previousFilterName
+
+		// Filters created by earlier versions, or imported filters now managed in Raito, are renamed so they are recognised as Raito filters in later imports
+		if !isRaitoFilter(filterName) {
+			filterName = raitoFilterName(filterName)
+		}
+
+		previousTableReference := BQReferencedTable{Project: externalIdSplit[0], Dataset: externalIdSplit[1], Table: externalIdSplit[2]}
+		if previousFilterName != filterName || previousTableReference != table {
+			previousTable = &previousTableReference
+		}
 	} else {
-		filterName = validSqlName(ap.NamingHint)
+		filterName = raitoFilterName(ap.NamingHint)
 	}
 
 	externalId := fmt.Sprintf("%s.%s.%s.%s", table.Project, table.Dataset, table.Table, filterName)
@@ -230,9 +251,48 @@ func (s *BqFilteringService) createOrUpdateFilter(ctx context.Context, table BQR
 		return nil, nil, fmt.Errorf("create or update filter: %w", err)
 	}
 
+	// The old row access policy is only dropped once its replacement exists, so the grantees never lose access to their rows
+	if previousTable != nil {
+		common.Logger.Info(fmt.Sprintf("Replaced filter %q by %q", *ap.ExternalId, externalId))
+
+		err = s.filteringRepository.DeleteFilter(ctx, previousTable, previousFilterName)
+		if err != nil {
+			return &filterName, &externalId, fmt.Errorf("delete replaced filter %q: %w", *ap.ExternalId, err)
+		}
+	}
+
 	return &filterName, &externalId, nil
 }
 
+// raitoFilterName returns the name of a new row access policy, prefixed and suffixed with a checksum to recognise it as created by Raito in later syncs.
+func raitoFilterName(namingHint string) string {
+	name := validSqlName(namingHint)
+	if isRaitoFilter(name) {
+		return name
+	}
+
+	if !strings.HasPrefix(name, raitoFilterPrefix) {
+		name = raitoFilterPrefix + name
+	}
+
+	return name + "_" + raitoFilterChecksum(name)
+}
+
+func isRaitoFilter(policyId string) bool {
+	idx := strings.LastIndex(policyId, "_")
+	if idx < 0 || !strings.HasPrefix(policyId, raitoFilterPrefix) {
+		return false
+	}
+
+	return policyId[idx+1:] == raitoFilterChecksum(policyId[:idx])
+}
+
+func raitoFilterChecksum(name string) string {
+	hash := sha256.Sum256([]byte(name))
+
+	return hex.EncodeToString(hash[:])[:8]
+}
+
 func createFilterExpression(ctx context.Context, filterCriteria *bexpression.DataComparisonExpression) (string, error) {
 	filterVisitor := NewFilterExpressionVisitor()
 
@@ -201,15 +201,24 @@ func TestBqFilteringService_ImportFilters(t *testing.T) {
 							return err
 						}
 
+						err = f(ctx, &org.GcpOrgEntity{
+							Name: "snapshot1",
+							Id:   "snapshot1",
+							Type: TypeSnapshot,
+						})
+						if err != nil {
+							return err
+						}
+
 						return f(ctx, &org.GcpOrgEntity{
 							Name: "table2",
 							Id:   "table2",
-							Type: ds.Table,
+							Type: TypeClone,
 						})
 					}).Once()
 
 					repositoryMock.EXPECT().ListFilters(mock.Anything, &org.GcpOrgEntity{Name: "table1", Id: "table1", Type: ds.Table}, mock.Anything).Return(nil).Once()
-					repositoryMock.EXPECT().ListFilters(mock.Anything, &org.GcpOrgEntity{Name: "table2", Id: "table2", Type: ds.Table}, mock.Anything).Return(nil).Once()
+					repositoryMock.EXPECT().ListFilters(mock.Anything, &org.GcpOrgEntity{Name: "table2", Id: "table2", Type: TypeClone}, mock.Anything).Return(nil).Once()
 				},
 			},
 			args: args{
@@ -254,7 +263,7 @@ func TestBqFilteringService_ImportFilters(t *testing.T) {
 						err := f(ctx, &bigquery.RowAccessPolicy{
 							FilterPredicate: "column1 = \"value1\"",
 							RowAccessPolicyReference: &bigquery.RowAccessPolicyReference{
-								PolicyId:  "policyId1",
+								PolicyId:  "raito_policyId1",
 								TableId:   "table1",
 								DatasetId: "dataset1",
 								ProjectId: "projectId1",
@@ -279,7 +288,17 @@ func TestBqFilteringService_ImportFilters(t *testing.T) {
 							NullFields:      nil,
 						}, []string{"ruben@raito.io"}, []string{"sales@raito.io"}, true)
 					}).Once()
-					repositoryMock.EXPECT().ListFilters(mock.Anything, &org.GcpOrgEntity{Name: "table2", Id: "table2", Type: ds.Table}, mock.Anything).Return(nil).Once()
+					repositoryMock.EXPECT().ListFilters(mock.Anything, &org.GcpOrgEntity{Name: "table2", Id: "table2", Type: ds.Table}, mock.Anything).RunAndReturn(func(ctx context.Context, entity *org.GcpOrgEntity, f func(context.Context, *bigquery.RowAccessPolicy, []string, []string, bool) error) error {
+						return f(ctx, &bigquery.RowAccessPolicy{
+							FilterPredicate: "column3 = \"value3\"",
+							RowAccessPolicyReference: &bigquery.RowAccessPolicyReference{
+								PolicyId:  raitoFilterName("filter2"),
+								TableId:   "table2",
+								DatasetId: "dataset1",
+								ProjectId: "projectId1",
+							},
+						}, []string{"ruben@raito.io"}, nil, true)
+					}).Once()
 				},
 			},
 			args: args{
@@ -289,9 +308,9 @@ func TestBqFilteringService_ImportFilters(t *testing.T) {
 			},
 			want: []sync_from_target.AccessProvider{
 				{
-					ExternalId: "projectId1.dataset1.table1.policyId1",
-					Name:       "policyId1",
-					NamingHint: "policyId1",
+					ExternalId: "projectId1.dataset1.table1.raito_policyId1",
+					Name:       "raito_policyId1",
+					NamingHint: "raito_policyId1",
 					Action:     types.Filtered,
 					Policy:     "column1 = \"value1\"",
 					Who: &sync_from_target.WhoItem{
@@ -299,7 +318,7 @@ func TestBqFilteringService_ImportFilters(t *testing.T) {
 						Groups: []string{"sales@raito.io"},
 					},
 					NotInternalizable: false,
-					ActualName:        "policyId1",
+					ActualName:        "raito_policyId1",
 					What: []sync_from_target.WhatItem{
 						{
 							DataObject: &ds.DataObjectReference{
@@ -399,6 +418,9 @@ func TestBqFilteringService_ImportFilters(t *testing.T) {
 }
 
 func TestBqFilteringService_ExportFilter(t *testing.T) {
+	filter1 := raitoFilterName("filter1")
+	filter3 := raitoFilterName("filter3")
+
 	type fields struct {
 		setup func(repositoryMock *mockFilteringRepository, doIteratorMock *mockFilteringDataObjectIterator)
 	}
@@ -419,7 +441,7 @@ func TestBqFilteringService_ExportFilter(t *testing.T) {
 			fields: fields{
 				setup: func(repositoryMock *mockFilteringRepository, _ *mockFilteringDataObjectIterator) {
 					repositoryMock.EXPECT().CreateOrUpdateFilter(mock.Anything, &BQFilter{
-						FilterName: "filter1",
+						FilterName: filter1,
 						Table: BQReferencedTable{
 							Project: "project1",
 							Dataset: "dataset1",
@@ -457,11 +479,11 @@ func TestBqFilteringService_ExportFilter(t *testing.T) {
 					DeleteWhat: nil,
 				},
 			},
-			want: ptr.String("project1.dataset1.table1.filter1"),
+			want: ptr.String("project1.dataset1.table1." + filter1),
 			wantFeedback: &sync_to_target.AccessProviderSyncFeedback{
 				AccessProvider: "apId1",
-				ActualName:     "filter1",
-				ExternalId:     ptr.String("project1.dataset1.table1.filter1"),
+				ActualName:     filter1,
+				ExternalId:     ptr.String("project1.dataset1.table1." + filter1),
 				State: &sync_to_target.AccessProviderFeedbackState{
 					Who: sync_to_target.AccessProviderWhoFeedbackState{
 						Users:  []string{"ruben@raito.io"},
@@ -472,11 +494,11 @@ func TestBqFilteringService_ExportFilter(t *testing.T) {
 			wantErr: require.NoError,
 		},
 		{
-			name: "Update existing filter with filter criteria",
+			name: "Replace existing filter without Raito name with filter criteria",
 			fields: fields{
 				setup: func(repositoryMock *mockFilteringRepository, _ *mockFilteringDataObjectIterator) {
 					repositoryMock.EXPECT().CreateOrUpdateFilter(mock.Anything, &BQFilter{
-						FilterName: "filter3",
+						FilterName: filter3,
 						Table: BQReferencedTable{
 							Project: "project1",
 							Dataset: "dataset1",
@@ -486,6 +508,7 @@ func TestBqFilteringService_ExportFilter(t *testing.T) {
 						Groups:           []string{"sales@raito.io"},
 						FilterExpression: "column1 = \"value2\"",
 					}).Return(nil).Once()
+					repositoryMock.EXPECT().DeleteFilter(mock.Anything, &BQReferencedTable{Project: "project1", Dataset: "dataset1", Table: "table1"}, "filter3").Return(nil).Once()
 				},
 			},
 			args: args{
@@ -530,11 +553,11 @@ func TestBqFilteringService_ExportFilter(t *testing.T) {
 					DeleteWhat: nil,
 				},
 			},
-			want: ptr.String("project1.dataset1.table1.filter3"),
+			want: ptr.String("project1.dataset1.table1." + filter3),
 			wantFeedback: &sync_to_target.AccessProviderSyncFeedback{
 				AccessProvider: "apId1",
-				ActualName:     "filter3",
-				ExternalId:     ptr.String("project1.dataset1.table1.filter3"),
+				ActualName:     filter3,
+				ExternalId:     ptr.String("project1.dataset1.table1." + filter3),
 				State: &sync_to_target.AccessProviderFeedbackState{
 					Who: sync_to_target.AccessProviderWhoFeedbackState{
 						Users:  []string{"ruben@raito.io"},
@@ -544,6 +567,109 @@ func TestBqFilteringService_ExportFilter(t *testing.T) {
 			},
 			wantErr: require.NoError,
 		},
+		{
+			name: "Update existing Raito filter in place",
+			fields: fields{
+				setup: func(repositoryMock *mockFilteringRepository, _ *mockFilteringDataObjectIterator) {
+					repositoryMock.EXPECT().CreateOrUpdateFilter(mock.Anything, &BQFilter{
+						FilterName: filter1,
+						Table: BQReferencedTable{
+							Project: "project1",
+							Dataset: "dataset1",
+							Table:   "table1",
+						},
+						Users:            []string{"ruben@raito.io"},
+						FilterExpression: "column1 = \"value1\"",
+					}).Return(nil).Once()
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				accessProvider: &sync_to_target.AccessProvider{
+					Id:         "apId1",
+					Name:       "filter1-name",
+					NamingHint: "filter1",
+					ExternalId: ptr.String("project1.dataset1.table1." + filter1),
+					Action:     types.Filtered,
+					Who: sync_to_target.WhoItem{
+						Users: []string{"ruben@raito.io"},
+					},
+					PolicyRule: ptr.String("column1 = \"value1\""),
+					What: []sync_to_target.WhatItem{
+						{
+							DataObject: &ds.DataObjectReference{
+								FullName: "project1.dataset1.table1",
+								Type:     ds.Table,
+							},
+						},
+					},
+				},
+			},
+			want: ptr.String("project1.dataset1.table1." + filter1),
+			wantFeedback: &sync_to_target.AccessProviderSyncFeedback{
+				AccessProvider: "apId1",
+				ActualName:     filter1,
+				ExternalId:     ptr.String("project1.dataset1.table1." + filter1),
+				State: &sync_to_target.AccessProviderFeedbackState{
+					Who: sync_to_target.AccessProviderWhoFeedbackState{
+						Users: []string{"ruben@raito.io"},
+					},
+				},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name: "Move existing Raito filter to another table",
+			fields: fields{
+				setup: func(repositoryMock *mockFilteringRepository, _ *mockFilteringDataObjectIterator) {
+					repositoryMock.EXPECT().CreateOrUpdateFilter(mock.Anything, &BQFilter{
+						FilterName: filter1,
+						Table: BQReferencedTable{
+							Project: "project1",
+							Dataset: "dataset1",
+							Table:   "table1",
+						},
+						Users:            []string{"ruben@raito.io"},
+						FilterExpression: "column1 = \"value1\"",
+					}).Return(nil).Once()
+					repositoryMock.EXPECT().DeleteFilter(mock.Anything, &BQReferencedTable{Project: "project1", Dataset: "dataset2", Table: "table1"}, filter1).Return(nil).Once()
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				accessProvider: &sync_to_target.AccessProvider{
+					Id:         "apId1",
+					Name:       "filter1-name",
+					NamingHint: "filter1",
+					ExternalId: ptr.String("project1.dataset2.table1." + filter1),
+					Action:     types.Filtered,
+					Who: sync_to_target.WhoItem{
+						Users: []string{"ruben@raito.io"},
+					},
+					PolicyRule: ptr.String("column1 = \"value1\""),
+					What: []sync_to_target.WhatItem{
+						{
+							DataObject: &ds.DataObjectReference{
+								FullName: "project1.dataset1.table1",
+								Type:     ds.Table,
+							},
+						},
+					},
+				},
+			},
+			want: ptr.String("project1.dataset1.table1." + filter1),
+			wantFeedback: &sync_to_target.AccessProviderSyncFeedback{
+				AccessProvider: "apId1",
+				ActualName:     filter1,
+				ExternalId:     ptr.String("project1.dataset1.table1." + filter1),
+				State: &sync_to_target.AccessProviderFeedbackState{
+					Who: sync_to_target.AccessProviderWhoFeedbackState{
+						Users: []string{"ruben@raito.io"},
+					},
+				},
+			},
+			wantErr: require.NoError,
+		},
 		{
 			name: "Delete existing filter",
 			fields: fields{
@@ -642,3 +768,14 @@ func createFilteringService(t *testing.T) (*BqFilteringService, *mockFilteringRe
 
 	return NewBqFilteringService(repoMock, doIteratorMock), repoMock, doIteratorMock
 }
+
+func Test_isRaitoFilter(t *testing.T) {
+	name := raitoFilterName("my filter")
+
+	assert.True(t, isRaitoFilter(name))
+	assert.Equal(t, name, raitoFilterName(name))
+	assert.Equal(t, name, raitoFilterName("raito_my_filter"))
+	assert.False(t, isRaitoFilter("raito_my_filter"))
+	assert.False(t, isRaitoFilter("raito_my_filter_12345678"))
+	assert.False(t, isRaitoFilter("my_filter"))
+}
@@ -0,0 +1,128 @@
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	datacatalog "cloud.google.com/go/datacatalog/apiv1"
+	"cloud.google.com/go/datacatalog/apiv1/datacatalogpb"
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"github.com/raito-io/cli/base/tag"
+	"github.com/raito-io/golang-set/set"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+)
+
+// InheritedFineGrainedReadersTagKey is the key of the read-only tag that lists the fine-grained readers inherited from parent policy tags.
+const InheritedFineGrainedReadersTagKey = "gcp.inherited_fine_grained_readers"
+
+// FineGrainedReaders are the fine-grained readers of a policy tag.
+// Readers of a parent policy tag can also read the columns of the tag, but they are managed on the parent tag.
+type FineGrainedReaders struct {
+	Direct    []string
+	Inherited []string
+}
+
+// PolicyTagReaders looks up the fine-grained readers of policy tags in any project, including the readers inherited from their parent tags.
+// It is shared by the BigQuery and the GCP data source.
+type PolicyTagReaders struct {
+	policyTagClient *datacatalog.PolicyTagManagerClient
+
+	// Cache
+	parents map[string]string
+}
+
+func NewPolicyTagReaders(tagClient *datacatalog.PolicyTagManagerClient) *PolicyTagReaders {
+	return &PolicyTagReaders{
+		policyTagClient: tagClient,
+
+		parents: make(map[string]string),
+	}
+}
+
+// SetParent caches the parent of a policy tag that is already known, e.g. because the policy tags of its taxonomy are listed.
+func (r *PolicyTagReaders) SetParent(tagId string, parentTagId string) {
+	r.parents[tagId] = parentTagId
+}
+
+// FineGrainedReaders returns the fine-grained readers of the policy tag and the readers inherited from its parent tags.
+func (r *PolicyTagReaders) FineGrainedReaders(ctx context.Context, tagId string) (*FineGrainedReaders, error) {
+	return loadFineGrainedReaders(ctx, tagId, r.readersOfTag, r.parentOfTag)
+}
+
+func (r *PolicyTagReaders) readersOfTag(ctx context.Context, tagId string) ([]string, error) {
+	common.Logger.Debug(fmt.Sprintf("Getting iam policy for policy tag %s", tagId))
+
+	iamPolicy, err := r.policyTagClient.GetIamPolicy(ctx, &iampb.GetIamPolicyRequest{Resource: tagId})
+	if err != nil {
+		return nil, fmt.Errorf("get iam policy for policy tag %q: %w", tagId, err)
+	}
+
+	for _, binding := range iamPolicy.Bindings {
+		if binding.Role == fineGrainedReaderRole {
+			return binding.Members, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *PolicyTagReaders) parentOfTag(ctx context.Context, tagId string) (string, error) {
+	if parent, found := r.parents[tagId]; found {
+		return parent, nil
+	}
+
+	policyTag, err := r.policyTagClient.GetPolicyTag(ctx, &datacatalogpb.GetPolicyTagRequest{Name: tagId})
+	if err != nil {
+		return "", fmt.Errorf("get policy tag %q: %w", tagId, err)
+	}
+
+	r.parents[tagId] = policyTag.ParentPolicyTag
+
+	return policyTag.ParentPolicyTag, nil
+}
+
+// loadFineGrainedReaders returns the readers of the policy tag as direct readers and the readers of its parent tags, which are not direct readers, as inherited readers.
+func loadFineGrainedReaders(ctx context.Context, tagId string, readersOfTag func(ctx context.Context, tagId string) ([]string, error), parentOfTag func(ctx context.Context, tagId string) (string, error)) (*FineGrainedReaders, error) {
+	direct, err := readersOfTag(ctx, tagId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FineGrainedReaders{Direct: direct}
+	members := set.NewSet(direct...)
+
+	for parent := tagId; ; {
+		parent, err = parentOfTag(ctx, parent)
+		if err != nil {
+			return nil, err
+		}
+
+		if parent == "" {
+			return result, nil
+		}
+
+		parentMembers, err := readersOfTag(ctx, parent)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range parentMembers {
+			if !members.Contains(member) {
+				members.Add(member)
+				result.Inherited = append(result.Inherited, member)
+			}
+		}
+	}
+}
+
+// InheritedReadersTags returns the read-only tag that reports the inherited readers on the imported access provider.
+// The inherited readers are not imported as who items, as they can only be changed on the parent policy tags.
+func (r *FineGrainedReaders) InheritedReadersTags() []*tag.Tag {
+	if len(r.Inherited) == 0 {
+		return nil
+	}
+
+	return []*tag.Tag{{Key: InheritedFineGrainedReadersTagKey, Value: strings.Join(r.Inherited, ","), Source: common.TagSource}}
+}
@@ -0,0 +1,51 @@
+package bigquery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/raito-io/cli/base/tag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loadFineGrainedReaders(t *testing.T) {
+	readers := map[string][]string{
+		"child":  {"user:user1@raito.io", "group:sales@raito.io"},
+		"parent": {"group:sales@raito.io", "group:finance@raito.io"},
+		"root":   {"user:user2@raito.io"},
+	}
+	parents := map[string]string{"child": "parent", "parent": "root"}
+
+	readersOfTag := func(_ context.Context, tagId string) ([]string, error) { return readers[tagId], nil }
+	parentOfTag := func(_ context.Context, tagId string) (string, error) { return parents[tagId], nil }
+
+	result, err := loadFineGrainedReaders(context.Background(), "child", readersOfTag, parentOfTag)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:user1@raito.io", "group:sales@raito.io"}, result.Direct)
+	assert.Equal(t, []string{"group:finance@raito.io", "user:user2@raito.io"}, result.Inherited)
+	assert.Equal(t, []*tag.Tag{{Key: InheritedFineGrainedReadersTagKey, Value: "group:finance@raito.io,user:user2@raito.io", Source: "gcp"}}, result.InheritedReadersTags())
+}
+
+func Test_loadFineGrainedReaders_RootTag(t *testing.T) {
+	readersOfTag := func(_ context.Context, _ string) ([]string, error) { return []string{"user:user1@raito.io"}, nil }
+	parentOfTag := func(_ context.Context, _ string) (string, error) { return "", nil }
+
+	result, err := loadFineGrainedReaders(context.Background(), "root", readersOfTag, parentOfTag)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:user1@raito.io"}, result.Direct)
+	assert.Empty(t, result.Inherited)
+	assert.Nil(t, result.InheritedReadersTags())
+}
+
+func Test_loadFineGrainedReaders_Error(t *testing.T) {
+	readersOfTag := func(_ context.Context, _ string) ([]string, error) { return nil, nil }
+	parentOfTag := func(_ context.Context, _ string) (string, error) { return "", errors.New("boom") }
+
+	_, err := loadFineGrainedReaders(context.Background(), "child", readersOfTag, parentOfTag)
+
+	require.Error(t, err)
+}
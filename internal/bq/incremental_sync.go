@@ -0,0 +1,299 @@
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/raito-io/cli/base/util/config"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+const defaultFullSyncIntervalHours = 7 * 24
+
+// incrementalTableState is the state of a table at the time it was last crawled.
+type incrementalTableState struct {
+	LastModifiedTime time.Time           `json:"lastModifiedTime"`
+	Table            *org.GcpOrgEntity   `json:"table"`
+	ColumnsLoaded    bool                `json:"columnsLoaded"`
+	Columns          []*org.GcpOrgEntity `json:"columns,omitempty"`
+}
+
+// incrementalDatasetState holds the watermark of each table of a dataset.
+type incrementalDatasetState struct {
+	Tables map[string]*incrementalTableState `json:"tables"`
+}
+
+// incrementalSync keeps track of the last-modified timestamps of the tables of each dataset, so unchanged tables are not crawled again.
+// The entities of unchanged tables and their columns are served from the state of the previous sync.
+type incrementalSync struct {
+	mutex sync.Mutex
+
+	projectId string
+	states    *common.Cache[*incrementalDatasetState]
+	fullSyncs *common.Cache[time.Time]
+	fullSync  bool
+	startedAt time.Time
+
+	// The states of the datasets that are being crawled, keyed by dataset full name.
+	pending map[string]*incrementalDatasetState
+}
+
+type tableLastModifiedEntity struct {
+	TableId          string    `bigquery:"table_id"`
+	LastModifiedTime time.Time `bigquery:"last_modified_time"`
+}
+
+// newIncrementalSync returns nil if the incremental sync is disabled.
+func newIncrementalSync(configMap *config.ConfigMap) *incrementalSync {
+	if !configMap.GetBoolWithDefault(common.BqIncrementalSync, false) {
+		return nil
+	}
+
+	dir := configMap.GetString(common.BqIncrementalSyncDir)
+	if dir == "" {
+		common.Logger.Warn(fmt.Sprintf("Incremental sync requires %s to be set. Falling back to a full sync.", common.BqIncrementalSyncDir))
+
+		return nil
+	}
+
+	projectId := configMap.GetString(common.GcpProjectId)
+
+	result := &incrementalSync{
+		projectId: projectId,
+		startedAt: time.Now(),
+		states:    common.NewCache[*incrementalDatasetState](0, filepath.Join(dir, projectId, "datasets")),
+		fullSyncs: common.NewCache[time.Time](0, filepath.Join(dir, projectId)),
+		pending:   make(map[string]*incrementalDatasetState),
+	}
+
+	interval := time.Duration(configMap.GetIntWithDefault(common.BqFullSyncInterval, defaultFullSyncIntervalHours)) * time.Hour
+	lastFullSync, found := result.fullSyncs.Get(projectId)
+
+	switch {
+	case configMap.GetBoolWithDefault(common.BqForceFullSync, false):
+		common.Logger.Info("Full sync is forced")
+	case !found:
+		common.Logger.Info("No previous full sync found. Executing a full sync")
+	case interval > 0 && time.Since(lastFullSync) > interval:
+		common.Logger.Info(fmt.Sprintf("Last full sync was executed at %s. Executing a full sync", lastFullSync.Format(time.RFC3339)))
+	default:
+		return result
+	}
+
+	// The full sync is only recorded once the data source sync completes, see completeSync
+	result.fullSync = true
+
+	return result
+}
+
+// completeSync records a successful full sync, so the next syncs are incremental until the full sync interval has passed.
+// Only the data source sync crawls all data objects, so the other syncs never record a full sync.
+func (s *incrementalSync) completeSync() {
+	if s == nil || !s.fullSync {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.fullSyncs.Set(s.projectId, s.startedAt)
+}
+
+// listTablesIncremental lists the tables of a dataset. Only tables that were modified since the previous sync are crawled.
+// Tables that no longer exist are not listed anymore and are removed from the state, so deletions are still detected.
+func (c *Repository) listTablesIncremental(ctx context.Context, ds *bigquery.Dataset, parent *org.GcpOrgEntity, fn func(ctx context.Context, entity *org.GcpOrgEntity, tab *bigquery.Table) error) (bool, error) {
+	lastModifiedTimes, err := c.tableLastModifiedTimes(ctx, ds)
+	if err != nil {
+		common.Logger.Warn(fmt.Sprintf("Unable to load the last-modified timestamps of the tables in dataset %q. Crawling all tables: %s", parent.FullName, err.Error()))
+
+		return false, nil
+	}
+
+	previous := c.incremental.start(parent.FullName)
+	defer c.incremental.finish(parent.FullName)
+
+	tableIds := make([]string, 0, len(lastModifiedTimes))
+	for tableId := range lastModifiedTimes {
+		tableIds = append(tableIds, tableId)
+	}
+
+	sort.Strings(tableIds)
+
+	var dataObjects []*org.GcpOrgEntity
+
+	unchanged := 0
+
+	for _, tableId := range tableIds {
+		var entity *org.GcpOrgEntity
+
+		var tab *bigquery.Table
+
+		if state, found := previous.Tables[tableId]; found && state.LastModifiedTime.Equal(lastModifiedTimes[tableId]) {
+			entityCopy := *state.Table
+			entityCopy.Parent = parent
+			entity = &entityCopy
+
+			c.incremental.setTable(parent.FullName, tableId, state)
+
+			unchanged++
+		} else {
+			tab = ds.Table(tableId)
+
+			entity, err = c.tableEntity(ctx, tab, parent)
+			if err != nil {
+				return true, err
+			} else if entity == nil {
+				continue
+			}
+
+			tableCopy := *entity
+			tableCopy.Parent = nil
+
+			c.incremental.setTable(parent.FullName, tableId, &incrementalTableState{LastModifiedTime: lastModifiedTimes[tableId], Table: &tableCopy})
+		}
+
+		err = fn(ctx, entity, tab)
+		if err != nil {
+			return true, err
+		}
+
+		dataObjects = append(dataObjects, entity)
+	}
+
+	common.Logger.Info(fmt.Sprintf("Incremental sync of dataset %q: %d unchanged and %d new or modified tables", parent.FullName, unchanged, len(dataObjects)-unchanged))
+
+	c.cacheDataObjects(parent, dataObjects)
+
+	return true, nil
+}
+
+// tableLastModifiedTimes returns the last-modified timestamp of all tables, views and other table types in a dataset.
+func (c *Repository) tableLastModifiedTimes(ctx context.Context, ds *bigquery.Dataset) (map[string]time.Time, error) {
+	query := fmt.Sprintf("SELECT table_id, TIMESTAMP_MILLIS(last_modified_time) AS last_modified_time FROM `%s.%s.__TABLES__`", c.projectId, ds.DatasetID)
+
+	result := make(map[string]time.Time)
+
+	err := readInformationSchemaQuery(ctx, c.client, query, func(row *tableLastModifiedEntity) {
+		result[row.TableId] = row.LastModifiedTime
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// loadColumnsFromIncrementalState lists the columns of an unchanged table from the state of the previous sync.
+func (c *Repository) loadColumnsFromIncrementalState(ctx context.Context, parent *org.GcpOrgEntity, fn func(ctx context.Context, entity *org.GcpOrgEntity) error) (error, bool) {
+	columns, found := c.incremental.columns(parent)
+	if !found {
+		return nil, false
+	}
+
+	for _, column := range columns {
+		columnCopy := *column
+		columnCopy.Parent = parent
+
+		err := fn(ctx, &columnCopy)
+		if err != nil {
+			return err, true
+		}
+	}
+
+	return nil, true
+}
+
+// start returns the state of the previous sync of a dataset and starts tracking the new state. During a full sync, the previous state is empty.
+func (s *incrementalSync) start(dataset string) *incrementalDatasetState {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.pending[dataset] = &incrementalDatasetState{Tables: make(map[string]*incrementalTableState)}
+
+	if !s.fullSync {
+		if previous, found := s.states.Get(dataset); found && previous != nil && previous.Tables != nil {
+			return previous
+		}
+	}
+
+	return &incrementalDatasetState{Tables: make(map[string]*incrementalTableState)}
+}
+
+// finish persists the new state of a dataset.
+func (s *incrementalSync) finish(dataset string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if state, found := s.pending[dataset]; found {
+		s.states.Set(dataset, state)
+		delete(s.pending, dataset)
+	}
+}
+
+func (s *incrementalSync) setTable(dataset string, tableId string, state *incrementalTableState) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if pending, found := s.pending[dataset]; found {
+		pending.Tables[tableId] = state
+	}
+}
+
+// columns returns the columns of a table that was not modified since the previous sync.
+func (s *incrementalSync) columns(table *org.GcpOrgEntity) ([]*org.GcpOrgEntity, bool) {
+	if s == nil || table.Parent == nil {
+		return nil, false
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pending, found := s.pending[table.Parent.FullName]
+	if !found {
+		return nil, false
+	}
+
+	state, found := pending.Tables[table.Name]
+	if !found || !state.ColumnsLoaded {
+		return nil, false
+	}
+
+	return state.Columns, true
+}
+
+// recordColumns stores the crawled columns of a table in the new state of its dataset.
+func (s *incrementalSync) recordColumns(table *org.GcpOrgEntity, columns []*org.GcpOrgEntity) {
+	if s == nil || table.Parent == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pending, found := s.pending[table.Parent.FullName]
+	if !found {
+		return
+	}
+
+	state, found := pending.Tables[table.Name]
+	if !found {
+		return
+	}
+
+	state.ColumnsLoaded = true
+	state.Columns = make([]*org.GcpOrgEntity, 0, len(columns))
+
+	for _, column := range columns {
+		columnCopy := *column
+		columnCopy.Parent = nil
+
+		state.Columns = append(state.Columns, &columnCopy)
+	}
+}
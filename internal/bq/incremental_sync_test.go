@@ -0,0 +1,109 @@
+package bigquery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+func TestNewIncrementalSync(t *testing.T) {
+	dir := t.TempDir()
+
+	parameters := map[string]string{
+		common.GcpProjectId:         "project1",
+		common.BqIncrementalSync:    "true",
+		common.BqIncrementalSyncDir: dir,
+	}
+
+	assert.Nil(t, newIncrementalSync(&config.ConfigMap{Parameters: map[string]string{common.GcpProjectId: "project1"}}))
+	assert.Nil(t, newIncrementalSync(&config.ConfigMap{Parameters: map[string]string{common.GcpProjectId: "project1", common.BqIncrementalSync: "true"}}))
+
+	// First sync is a full sync
+	incremental := newIncrementalSync(&config.ConfigMap{Parameters: parameters})
+	require.NotNil(t, incremental)
+	assert.True(t, incremental.fullSync)
+
+	// Until the full sync completes, the next sync is a full sync as well
+	incremental = newIncrementalSync(&config.ConfigMap{Parameters: parameters})
+	require.NotNil(t, incremental)
+	assert.True(t, incremental.fullSync)
+
+	incremental.completeSync()
+
+	// Next sync is incremental
+	incremental = newIncrementalSync(&config.ConfigMap{Parameters: parameters})
+	require.NotNil(t, incremental)
+	assert.False(t, incremental.fullSync)
+
+	// Unless a full sync is forced
+	parameters[common.BqForceFullSync] = "true"
+	incremental = newIncrementalSync(&config.ConfigMap{Parameters: parameters})
+	require.NotNil(t, incremental)
+	assert.True(t, incremental.fullSync)
+
+	// Or the full sync interval has passed
+	delete(parameters, common.BqForceFullSync)
+	incremental.fullSyncs.Set("project1", time.Now().Add(-8*24*time.Hour))
+
+	incremental = newIncrementalSync(&config.ConfigMap{Parameters: parameters})
+	require.NotNil(t, incremental)
+	assert.True(t, incremental.fullSync)
+}
+
+func TestIncrementalSync_State(t *testing.T) {
+	dataset := &org.GcpOrgEntity{Id: "project1.ds", Name: "ds", FullName: "project1.ds", Type: data_source.Dataset}
+	table := &org.GcpOrgEntity{Id: "project1.ds.table", Name: "table", FullName: "project1.ds.table", Type: data_source.Table, Parent: dataset}
+	column := &org.GcpOrgEntity{Id: "project1.ds.table.col", Name: "col", FullName: "project1.ds.table.col", Type: data_source.Column, Parent: table}
+	lastModified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	incremental := &incrementalSync{
+		states:    common.NewCache[*incrementalDatasetState](0, t.TempDir()),
+		fullSyncs: common.NewCache[time.Time](0, ""),
+		pending:   make(map[string]*incrementalDatasetState),
+	}
+
+	// First sync: no previous state
+	previous := incremental.start(dataset.FullName)
+	assert.Empty(t, previous.Tables)
+
+	incremental.setTable(dataset.FullName, table.Name, &incrementalTableState{LastModifiedTime: lastModified, Table: &org.GcpOrgEntity{Name: "table"}})
+
+	_, found := incremental.columns(table)
+	assert.False(t, found)
+
+	incremental.recordColumns(table, []*org.GcpOrgEntity{column})
+	incremental.finish(dataset.FullName)
+
+	assert.Same(t, table, column.Parent)
+
+	// Second sync: previous state contains the table and its columns
+	previous = incremental.start(dataset.FullName)
+	require.Contains(t, previous.Tables, table.Name)
+	assert.Equal(t, lastModified, previous.Tables[table.Name].LastModifiedTime)
+
+	incremental.setTable(dataset.FullName, table.Name, previous.Tables[table.Name])
+
+	columns, found := incremental.columns(table)
+	assert.True(t, found)
+	require.Len(t, columns, 1)
+	assert.Equal(t, "project1.ds.table.col", columns[0].FullName)
+	assert.Nil(t, columns[0].Parent)
+
+	incremental.finish(dataset.FullName)
+
+	// Third sync: the table was deleted, so it is removed from the state
+	previous = incremental.start(dataset.FullName)
+	assert.Contains(t, previous.Tables, table.Name)
+	incremental.finish(dataset.FullName)
+
+	previous = incremental.start(dataset.FullName)
+	assert.Empty(t, previous.Tables)
+	incremental.finish(dataset.FullName)
+}
@@ -0,0 +1,678 @@
+package bigquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/golang-set/set"
+	bigquery2 "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/iterator"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=BigQueryDatasetsService --with-expecter --inpackage
+type BigQueryDatasetsService interface {
+	List(projectId string) *bigquery2.DatasetsListCall
+}
+
+// informationSchemaSnapshot contains all metadata of a project that could be loaded from the regional INFORMATION_SCHEMA views.
+// Datasets that are not part of the snapshot should be loaded through the BigQuery API.
+type informationSchemaSnapshot struct {
+	datasetOrder []string
+	datasets     map[string]*isDataset
+}
+
+type isDataset struct {
+	Name        string
+	Location    string
+	Description string
+	Labels      map[string]string
+
+	// Loaded indicates that the dataset could be loaded from the INFORMATION_SCHEMA of its region
+	Loaded bool
+
+	tableOrder []string
+	tables     map[string]*isTable
+}
+
+type isTable struct {
+	Name        string
+	TableType   string
+	Description string
+	Labels      map[string]string
+	Columns     []*isColumn
+
+	ViewQuery         string
+	PartitioningField string
+	ClusteringFields  []string
+	ExpirationTime    string
+	KmsKeyName        string
+	Storage           *isTableStorageRow
+}
+
+type isColumn struct {
+	Name        string
+	DataType    string
+	Description string
+}
+
+type isSchemaRow struct {
+	Dataset  string `bigquery:"dataset_id"`
+	Location string `bigquery:"location"`
+}
+
+type isOptionRow struct {
+	Dataset     string              `bigquery:"dataset_id"`
+	Table       bigquery.NullString `bigquery:"table_id"`
+	OptionName  string              `bigquery:"option_name"`
+	OptionValue string              `bigquery:"option_value"`
+}
+
+type isTableRow struct {
+	Dataset   string              `bigquery:"dataset_id"`
+	Table     string              `bigquery:"table_id"`
+	TableType string              `bigquery:"table_type"`
+	Ddl       bigquery.NullString `bigquery:"ddl"`
+}
+
+type isViewRow struct {
+	Dataset        string `bigquery:"dataset_id"`
+	Table          string `bigquery:"table_id"`
+	ViewDefinition string `bigquery:"view_definition"`
+}
+
+type isColumnRow struct {
+	Dataset            string              `bigquery:"dataset_id"`
+	Table              string              `bigquery:"table_id"`
+	Column             string              `bigquery:"column_name"`
+	DataType           string              `bigquery:"data_type"`
+	Description        bigquery.NullString `bigquery:"description"`
+	PartitioningColumn string              `bigquery:"is_partitioning_column"`
+	ClusteringPosition bigquery.NullInt64  `bigquery:"clustering_ordinal_position"`
+}
+
+// isTableStorageRow is a row of TABLE_STORAGE. The values are NULL for tables without managed storage, e.g. external tables.
+type isTableStorageRow struct {
+	Dataset          string                 `bigquery:"dataset_id"`
+	Table            string                 `bigquery:"table_id"`
+	TotalRows        bigquery.NullInt64     `bigquery:"total_rows"`
+	LogicalBytes     bigquery.NullInt64     `bigquery:"total_logical_bytes"`
+	LastModifiedTime bigquery.NullTimestamp `bigquery:"storage_last_modified_time"`
+}
+
+func newInformationSchemaSnapshot() *informationSchemaSnapshot {
+	return &informationSchemaSnapshot{
+		datasets: make(map[string]*isDataset),
+	}
+}
+
+func (s *informationSchemaSnapshot) addDataset(name string, location string, labels map[string]string) {
+	if _, found := s.datasets[name]; found {
+		return
+	}
+
+	s.datasetOrder = append(s.datasetOrder, name)
+	s.datasets[name] = &isDataset{
+		Name:     name,
+		Location: location,
+		Labels:   labels,
+		tables:   make(map[string]*isTable),
+	}
+}
+
+func (s *informationSchemaSnapshot) regions() set.Set[string] {
+	regions := set.NewSet[string]()
+
+	for _, ds := range s.datasets {
+		if ds.Location != "" {
+			regions.Add(strings.ToLower(ds.Location))
+		}
+	}
+
+	return regions
+}
+
+func (s *informationSchemaSnapshot) addSchemaRow(row *isSchemaRow) {
+	if ds, found := s.datasets[row.Dataset]; found {
+		ds.Loaded = true
+	}
+}
+
+func (s *informationSchemaSnapshot) addSchemaOptionRow(row *isOptionRow) {
+	ds, found := s.datasets[row.Dataset]
+	if !found {
+		return
+	}
+
+	switch row.OptionName {
+	case "description":
+		ds.Description = parseOptionString(row.OptionValue)
+	case "labels":
+		ds.Labels = parseOptionLabels(row.OptionValue)
+	}
+}
+
+func (s *informationSchemaSnapshot) addTableRow(row *isTableRow) {
+	ds, found := s.datasets[row.Dataset]
+	if !found {
+		return
+	}
+
+	if _, found = ds.tables[row.Table]; found {
+		return
+	}
+
+	ds.tableOrder = append(ds.tableOrder, row.Table)
+	ds.tables[row.Table] = &isTable{
+		Name:      row.Table,
+		TableType: row.TableType,
+		ViewQuery: row.Ddl.StringVal,
+	}
+}
+
+func (s *informationSchemaSnapshot) addViewRow(row *isViewRow) {
+	table := s.table(row.Dataset, row.Table)
+	if table == nil {
+		return
+	}
+
+	table.ViewQuery = row.ViewDefinition
+}
+
+func (s *informationSchemaSnapshot) addTableOptionRow(row *isOptionRow) {
+	table := s.table(row.Dataset, row.Table.StringVal)
+	if table == nil {
+		return
+	}
+
+	switch row.OptionName {
+	case "description":
+		table.Description = parseOptionString(row.OptionValue)
+	case "labels":
+		table.Labels = parseOptionLabels(row.OptionValue)
+	case "expiration_timestamp":
+		table.ExpirationTime = parseOptionTimestamp(row.OptionValue)
+	case "kms_key_name":
+		table.KmsKeyName = parseOptionString(row.OptionValue)
+	}
+}
+
+func (s *informationSchemaSnapshot) addTableStorageRow(row *isTableStorageRow) {
+	table := s.table(row.Dataset, row.Table)
+	if table == nil {
+		return
+	}
+
+	table.Storage = row
+}
+
+func (s *informationSchemaSnapshot) addColumnRow(row *isColumnRow) {
+	table := s.table(row.Dataset, row.Table)
+	if table == nil {
+		return
+	}
+
+	table.Columns = append(table.Columns, &isColumn{
+		Name:        row.Column,
+		DataType:    normalizeInformationSchemaDataType(row.DataType),
+		Description: row.Description.StringVal,
+	})
+
+	if row.PartitioningColumn == "YES" {
+		table.PartitioningField = row.Column
+	}
+
+	if row.ClusteringPosition.Valid {
+		position := int(row.ClusteringPosition.Int64)
+
+		for len(table.ClusteringFields) < position {
+			table.ClusteringFields = append(table.ClusteringFields, "")
+		}
+
+		table.ClusteringFields[position-1] = row.Column
+	}
+}
+
+func (s *informationSchemaSnapshot) table(dataset string, table string) *isTable {
+	ds, found := s.datasets[dataset]
+	if !found {
+		return nil
+	}
+
+	return ds.tables[table]
+}
+
+// loadedDataset returns the dataset if it could be loaded from the INFORMATION_SCHEMA
+func (s *informationSchemaSnapshot) loadedDataset(name string) *isDataset {
+	if s == nil {
+		return nil
+	}
+
+	ds, found := s.datasets[name]
+	if !found || !ds.Loaded {
+		return nil
+	}
+
+	return ds
+}
+
+func (d *isDataset) toEntity(parent *org.GcpOrgEntity) *org.GcpOrgEntity {
+	id := fmt.Sprintf("%s.%s", parent.Id, d.Name)
+
+	return &org.GcpOrgEntity{
+		Type:        data_source.Dataset,
+		Name:        d.Name,
+		Id:          id,
+		FullName:    id,
+		Description: d.Description,
+		Parent:      parent,
+		Location:    d.Location,
+		Tags:        d.Labels,
+	}
+}
+
+func (d *isDataset) listTables() []*isTable {
+	tables := make([]*isTable, 0, len(d.tableOrder))
+
+	for _, t := range d.tableOrder {
+		tables = append(tables, d.tables[t])
+	}
+
+	return tables
+}
+
+func (t *isTable) toEntity(parent *org.GcpOrgEntity) *org.GcpOrgEntity {
+	id := fmt.Sprintf("%s.%s", parent.Id, t.Name)
+
+	return &org.GcpOrgEntity{
+		Type:        dataObjectTypeForTableType(tableTypeFromInformationSchema(t.TableType)),
+		Name:        t.Name,
+		Id:          id,
+		FullName:    id,
+		Description: t.Description,
+		Parent:      parent,
+		Location:    parent.Location,
+		Tags:        t.tags(parent.Parent),
+	}
+}
+
+// tags merges the labels of a table with the metadata tags that are available in the INFORMATION_SCHEMA.
+// The partitioning type is not available in the INFORMATION_SCHEMA and the definition of materialized views is their DDL statement.
+func (t *isTable) tags(project *org.GcpOrgEntity) map[string]string {
+	tags := make(map[string]string, len(t.Labels)+8)
+
+	for k, v := range t.Labels {
+		tags[k] = v
+	}
+
+	tags[TagTableType] = tableTypeFromInformationSchema(t.TableType)
+
+	if t.PartitioningField != "" {
+		tags[TagPartitioningField] = t.PartitioningField
+	}
+
+	if len(t.ClusteringFields) > 0 {
+		tags[TagClusteringFields] = strings.Join(t.ClusteringFields, ",")
+	}
+
+	if t.ExpirationTime != "" {
+		tags[TagExpirationTime] = t.ExpirationTime
+	}
+
+	if t.KmsKeyName != "" {
+		tags[TagKmsKeyName] = t.KmsKeyName
+	}
+
+	if t.Storage != nil {
+		if t.Storage.TotalRows.Valid {
+			tags[TagNumRows] = strconv.FormatInt(t.Storage.TotalRows.Int64, 10)
+		}
+
+		if t.Storage.LogicalBytes.Valid {
+			tags[TagLogicalBytes] = strconv.FormatInt(t.Storage.LogicalBytes.Int64, 10)
+		}
+
+		if t.Storage.LastModifiedTime.Valid && !t.Storage.LastModifiedTime.Timestamp.IsZero() {
+			tags[TagLastModifiedTime] = formatTagTime(t.Storage.LastModifiedTime.Timestamp)
+		}
+	}
+
+	if t.ViewQuery != "" && project != nil {
+		addViewDefinitionTags(tags, t.ViewQuery, project.Id)
+	}
+
+	return tags
+}
+
+func (c *isColumn) toEntity(parent *org.GcpOrgEntity) *org.GcpOrgEntity {
+	id := fmt.Sprintf("%s.%s", parent.Id, c.Name)
+
+	return &org.GcpOrgEntity{
+		Type:        data_source.Column,
+		Name:        c.Name,
+		Id:          id,
+		FullName:    id,
+		Parent:      parent,
+		Description: c.Description,
+		Location:    parent.Location,
+		DataType:    ptr.String(c.DataType),
+	}
+}
+
+// informationSchema returns the metadata snapshot of the project if the INFORMATION_SCHEMA crawl is enabled.
+// If the snapshot could not be loaded, the crawl is disabled and nil is returned so the BigQuery API is used instead.
+func (c *Repository) informationSchema(ctx context.Context) *informationSchemaSnapshot {
+	if !c.informationSchemaCrawl {
+		return nil
+	}
+
+	snapshot, err := c.loadInformationSchemaSnapshot(ctx)
+	if err != nil {
+		common.Logger.Warn(fmt.Sprintf("Unable to load metadata from INFORMATION_SCHEMA. Falling back to the BigQuery API: %s", err.Error()))
+
+		c.informationSchemaCrawl = false
+
+		return nil
+	}
+
+	return snapshot
+}
+
+// informationSchemaDataset returns the dataset if it could be loaded from the INFORMATION_SCHEMA
+func (c *Repository) informationSchemaDataset(ctx context.Context, name string) *isDataset {
+	return c.informationSchema(ctx).loadedDataset(name)
+}
+
+func (c *Repository) listDataSetsFromInformationSchema(ctx context.Context, snapshot *informationSchemaSnapshot, parent *org.GcpOrgEntity, fn func(ctx context.Context, entity *org.GcpOrgEntity, dataset *bigquery.Dataset) error) error {
+	dataObjects := make([]*org.GcpOrgEntity, 0, len(snapshot.datasetOrder))
+
+	for _, name := range snapshot.datasetOrder {
+		isDs := snapshot.datasets[name]
+		ds := c.client.Dataset(name)
+
+		var entity *org.GcpOrgEntity
+
+		if isDs.Loaded {
+			entity = isDs.toEntity(parent)
+		} else {
+			meta, err := ds.Metadata(ctx)
+			if common.IsGoogle400Error(err) {
+				common.Logger.Warn(fmt.Sprintf("Encountered 4xx error while fetching metadata for dataset %q: %s", name, err))
+
+				continue
+			} else if err != nil {
+				return fmt.Errorf("getting metadata for dataset %s: %w", name, err)
+			}
+
+			id := fmt.Sprintf("%s.%s", parent.Id, name)
+
+			entity = &org.GcpOrgEntity{
+				Type:        data_source.Dataset,
+				Name:        name,
+				Id:          id,
+				FullName:    id,
+				Description: meta.Description,
+				Parent:      parent,
+				Location:    meta.Location,
+				Tags:        meta.Labels,
+			}
+		}
+
+		err := fn(ctx, entity, ds)
+		if err != nil {
+			return err
+		}
+
+		dataObjects = append(dataObjects, entity)
+	}
+
+	c.cacheDataObjects(parent, dataObjects)
+
+	return nil
+}
+
+func (c *Repository) listTablesFromInformationSchema(ctx context.Context, isDs *isDataset, parent *org.GcpOrgEntity, fn func(ctx context.Context, entity *org.GcpOrgEntity, tab *bigquery.Table) error) error {
+	ds := c.client.Dataset(isDs.Name)
+	dataObjects := make([]*org.GcpOrgEntity, 0, len(isDs.tableOrder))
+
+	for _, isTab := range isDs.listTables() {
+		entity := isTab.toEntity(parent)
+
+		err := fn(ctx, entity, ds.Table(isTab.Name))
+		if err != nil {
+			return err
+		}
+
+		dataObjects = append(dataObjects, entity)
+	}
+
+	c.cacheDataObjects(parent, dataObjects)
+
+	return nil
+}
+
+func (c *Repository) listColumnsFromInformationSchema(ctx context.Context, isTab *isTable, parent *org.GcpOrgEntity, fn func(ctx context.Context, entity *org.GcpOrgEntity) error) error {
+	dataObjects := make([]*org.GcpOrgEntity, 0, len(isTab.Columns))
+
+	for _, col := range isTab.Columns {
+		entity := col.toEntity(parent)
+
+		err := fn(ctx, entity)
+		if err != nil {
+			return err
+		}
+
+		dataObjects = append(dataObjects, entity)
+	}
+
+	c.cacheDataObjects(parent, dataObjects)
+
+	return nil
+}
+
+// loadInformationSchemaSnapshot lists all datasets of the project and loads their metadata with a few queries per region.
+// If querying a region fails, the datasets of that region will not be marked as loaded.
+func (c *Repository) loadInformationSchemaSnapshot(ctx context.Context) (*informationSchemaSnapshot, error) {
+	if c.isSnapshot != nil {
+		return c.isSnapshot, nil
+	}
+
+	snapshot := newInformationSchemaSnapshot()
+
+	err := c.datasetsClient.List(c.projectId).All(c.listHidden).Pages(ctx, func(list *bigquery2.DatasetList) error {
+		for _, ds := range list.Datasets {
+			if ds.DatasetReference == nil {
+				continue
+			}
+
+			snapshot.addDataset(ds.DatasetReference.DatasetId, ds.Location, ds.Labels)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list datasets: %w", err)
+	}
+
+	for region := range snapshot.regions() {
+		common.Logger.Info(fmt.Sprintf("Loading metadata from INFORMATION_SCHEMA in BigQuery region %s", region))
+
+		err = c.loadInformationSchemaRegion(ctx, region, snapshot)
+		if err != nil {
+			common.Logger.Warn(fmt.Sprintf("Unable to load metadata from INFORMATION_SCHEMA in region %s. Falling back to the BigQuery API for the datasets in this region: %s", region, err.Error()))
+
+			for _, ds := range snapshot.datasets {
+				if strings.EqualFold(ds.Location, region) {
+					ds.Loaded = false
+					ds.tableOrder = nil
+					ds.tables = make(map[string]*isTable)
+				}
+			}
+		}
+	}
+
+	c.isSnapshot = snapshot
+
+	return snapshot, nil
+}
+
+func (c *Repository) loadInformationSchemaRegion(ctx context.Context, region string, snapshot *informationSchemaSnapshot) error {
+	schema := fmt.Sprintf("`%s`.`region-%s`.INFORMATION_SCHEMA", c.projectId, region)
+
+	err := readInformationSchemaQuery(ctx, c.client, fmt.Sprintf(`SELECT schema_name AS dataset_id, location FROM %s.SCHEMATA`, schema), snapshot.addSchemaRow)
+	if err != nil {
+		return fmt.Errorf("schemata: %w", err)
+	}
+
+	err = readInformationSchemaQuery(ctx, c.client, fmt.Sprintf(`SELECT schema_name AS dataset_id, CAST(NULL AS STRING) AS table_id, option_name, option_value FROM %s.SCHEMATA_OPTIONS WHERE option_name IN ("description", "labels")`, schema), snapshot.addSchemaOptionRow)
+	if err != nil {
+		return fmt.Errorf("schemata options: %w", err)
+	}
+
+	err = readInformationSchemaQuery(ctx, c.client, fmt.Sprintf(`SELECT table_schema AS dataset_id, table_name AS table_id, table_type, IF(table_type = "MATERIALIZED VIEW", ddl, NULL) AS ddl FROM %s.TABLES ORDER BY table_schema, table_name`, schema), snapshot.addTableRow)
+	if err != nil {
+		return fmt.Errorf("tables: %w", err)
+	}
+
+	err = readInformationSchemaQuery(ctx, c.client, fmt.Sprintf(`SELECT table_schema AS dataset_id, table_name AS table_id, view_definition FROM %s.VIEWS`, schema), snapshot.addViewRow)
+	if err != nil {
+		return fmt.Errorf("views: %w", err)
+	}
+
+	err = readInformationSchemaQuery(ctx, c.client, fmt.Sprintf(`SELECT table_schema AS dataset_id, table_name AS table_id, option_name, option_value FROM %s.TABLE_OPTIONS WHERE option_name IN ("description", "labels", "expiration_timestamp", "kms_key_name")`, schema), snapshot.addTableOptionRow)
+	if err != nil {
+		return fmt.Errorf("table options: %w", err)
+	}
+
+	err = readInformationSchemaQuery(ctx, c.client, fmt.Sprintf(`
+		SELECT f.table_schema AS dataset_id, f.table_name AS table_id, f.column_name, f.data_type, f.description, c.is_partitioning_column, c.clustering_ordinal_position
+		FROM %[1]s.COLUMN_FIELD_PATHS AS f
+		JOIN %[1]s.COLUMNS AS c USING (table_schema, table_name, column_name)
+		WHERE f.field_path = f.column_name
+		ORDER BY f.table_schema, f.table_name, c.ordinal_position`, schema), snapshot.addColumnRow)
+	if err != nil {
+		return fmt.Errorf("column field paths: %w", err)
+	}
+
+	// Storage information is optional as it requires additional permissions
+	err = readInformationSchemaQuery(ctx, c.client, fmt.Sprintf(`SELECT table_schema AS dataset_id, table_name AS table_id, total_rows, total_logical_bytes, storage_last_modified_time FROM %s.TABLE_STORAGE WHERE NOT deleted`, schema), snapshot.addTableStorageRow)
+	if err != nil {
+		common.Logger.Warn(fmt.Sprintf("Unable to load table storage information from INFORMATION_SCHEMA in region %s: %s", region, err.Error()))
+	}
+
+	return nil
+}
+
+func readInformationSchemaQuery[T any](ctx context.Context, client *bigquery.Client, query string, fn func(row *T)) error {
+	common.Logger.Debug(fmt.Sprintf("Executing query: %s", query))
+
+	rows, err := client.Query(query).Read(ctx)
+	if err != nil {
+		return fmt.Errorf("execute query: %w", err)
+	}
+
+	for {
+		var row T
+
+		err = rows.Next(&row)
+		if errors.Is(err, iterator.Done) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("read row: %w", err)
+		}
+
+		fn(&row)
+	}
+
+	return nil
+}
+
+var optionTimestampRegex = regexp.MustCompile(`^TIMESTAMP\s+("(?:[^"\\]|\\.)*")$`)
+
+// parseOptionTimestamp parses a timestamp literal as returned in the option_value column of the INFORMATION_SCHEMA options views.
+// For example: TIMESTAMP "2025-01-01T00:00:00.000Z"
+func parseOptionTimestamp(value string) string {
+	value = strings.TrimSpace(value)
+
+	if match := optionTimestampRegex.FindStringSubmatch(value); match != nil {
+		value = parseOptionString(match[1])
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999-07", "2006-01-02 15:04:05.999999999 MST"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return formatTagTime(t)
+		}
+	}
+
+	return value
+}
+
+var optionLabelRegex = regexp.MustCompile(`STRUCT\(\s*("(?:[^"\\]|\\.)*")\s*,\s*("(?:[^"\\]|\\.)*")\s*\)`)
+
+// parseOptionString parses a string literal as returned in the option_value column of the INFORMATION_SCHEMA options views.
+func parseOptionString(value string) string {
+	value = strings.TrimSpace(value)
+
+	unquoted, err := strconv.Unquote(value)
+	if err == nil {
+		return unquoted
+	}
+
+	return strings.Trim(value, `"`)
+}
+
+// parseOptionLabels parses the labels option as returned in the option_value column of the INFORMATION_SCHEMA options views.
+// For example: [STRUCT("env", "prod"), STRUCT("team", "data")]
+func parseOptionLabels(value string) map[string]string {
+	matches := optionLabelRegex.FindAllStringSubmatch(value, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	labels := make(map[string]string, len(matches))
+
+	for _, match := range matches {
+		labels[parseOptionString(match[1])] = parseOptionString(match[2])
+	}
+
+	return labels
+}
+
+var informationSchemaDataTypes = map[string]string{
+	"INT64":   string(bigquery.IntegerFieldType),
+	"FLOAT64": string(bigquery.FloatFieldType),
+	"BOOL":    string(bigquery.BooleanFieldType),
+	"STRUCT":  string(bigquery.RecordFieldType),
+}
+
+// normalizeInformationSchemaDataType converts the standard SQL data type of the INFORMATION_SCHEMA to the field type as returned by the BigQuery API.
+func normalizeInformationSchemaDataType(dataType string) string {
+	dataType = strings.TrimSpace(dataType)
+
+	// Repeated fields are returned as the type of their elements
+	if strings.HasPrefix(dataType, "ARRAY<") && strings.HasSuffix(dataType, ">") {
+		dataType = dataType[len("ARRAY<") : len(dataType)-1]
+	}
+
+	if idx := strings.IndexAny(dataType, "<("); idx >= 0 {
+		dataType = dataType[:idx]
+	}
+
+	dataType = strings.ToUpper(strings.TrimSpace(dataType))
+
+	if fieldType, found := informationSchemaDataTypes[dataType]; found {
+		return fieldType
+	}
+
+	return dataType
+}
@@ -0,0 +1,194 @@
+package bigquery
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/raito-io/cli/base/data_source"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+func Test_parseOptionString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "quoted string",
+			value: `"my description"`,
+			want:  "my description",
+		},
+		{
+			name:  "escaped characters",
+			value: `"a \"quoted\" word\nnew line"`,
+			want:  "a \"quoted\" word\nnew line",
+		},
+		{
+			name:  "unquoted string",
+			value: `plain`,
+			want:  "plain",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseOptionString(tt.value))
+		})
+	}
+}
+
+func Test_parseOptionLabels(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  map[string]string
+	}{
+		{
+			name:  "multiple labels",
+			value: `[STRUCT("env", "prod"), STRUCT("team", "data")]`,
+			want:  map[string]string{"env": "prod", "team": "data"},
+		},
+		{
+			name:  "empty label value",
+			value: `[STRUCT("pii", "")]`,
+			want:  map[string]string{"pii": ""},
+		},
+		{
+			name:  "no labels",
+			value: `[]`,
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseOptionLabels(tt.value))
+		})
+	}
+}
+
+func Test_normalizeInformationSchemaDataType(t *testing.T) {
+	tests := []struct {
+		dataType string
+		want     string
+	}{
+		{dataType: "STRING", want: "STRING"},
+		{dataType: "STRING(10)", want: "STRING"},
+		{dataType: "INT64", want: "INTEGER"},
+		{dataType: "FLOAT64", want: "FLOAT"},
+		{dataType: "BOOL", want: "BOOLEAN"},
+		{dataType: "NUMERIC(10, 2)", want: "NUMERIC"},
+		{dataType: "STRUCT<a INT64, b STRING>", want: "RECORD"},
+		{dataType: "ARRAY<INT64>", want: "INTEGER"},
+		{dataType: "ARRAY<STRUCT<a STRING>>", want: "RECORD"},
+		{dataType: "TIMESTAMP", want: "TIMESTAMP"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dataType, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeInformationSchemaDataType(tt.dataType))
+		})
+	}
+}
+
+func Test_informationSchemaSnapshot(t *testing.T) {
+	snapshot := newInformationSchemaSnapshot()
+	snapshot.addDataset("ds1", "EU", map[string]string{"from": "api"})
+	snapshot.addDataset("ds2", "us-east1", nil)
+	snapshot.addDataset("ds3", "EU", nil)
+
+	assert.ElementsMatch(t, []string{"eu", "us-east1"}, snapshot.regions().Slice())
+
+	snapshot.addSchemaRow(&isSchemaRow{Dataset: "ds1", Location: "EU"})
+	snapshot.addSchemaRow(&isSchemaRow{Dataset: "ds2", Location: "us-east1"})
+	snapshot.addSchemaRow(&isSchemaRow{Dataset: "unknown", Location: "EU"})
+
+	snapshot.addSchemaOptionRow(&isOptionRow{Dataset: "ds1", OptionName: "description", OptionValue: `"dataset description"`})
+	snapshot.addSchemaOptionRow(&isOptionRow{Dataset: "ds1", OptionName: "labels", OptionValue: `[STRUCT("env", "prod")]`})
+
+	snapshot.addTableRow(&isTableRow{Dataset: "ds1", Table: "table1", TableType: "BASE TABLE"})
+	snapshot.addTableRow(&isTableRow{Dataset: "ds1", Table: "view1", TableType: "VIEW"})
+	snapshot.addTableRow(&isTableRow{Dataset: "ds1", Table: "mv1", TableType: "MATERIALIZED VIEW", Ddl: bigquery.NullString{StringVal: "CREATE MATERIALIZED VIEW `project1.ds1.mv1` AS SELECT * FROM `project1.ds1.table1`", Valid: true}})
+	snapshot.addTableRow(&isTableRow{Dataset: "unknown", Table: "table1", TableType: "BASE TABLE"})
+
+	snapshot.addViewRow(&isViewRow{Dataset: "ds1", Table: "view1", ViewDefinition: "SELECT id FROM ds1.table1"})
+
+	snapshot.addTableOptionRow(&isOptionRow{Dataset: "ds1", Table: bigquery.NullString{StringVal: "table1", Valid: true}, OptionName: "description", OptionValue: `"table description"`})
+
+	snapshot.addTableOptionRow(&isOptionRow{Dataset: "ds1", Table: bigquery.NullString{StringVal: "table1", Valid: true}, OptionName: "expiration_timestamp", OptionValue: `TIMESTAMP "2025-01-01T00:00:00.000Z"`})
+	snapshot.addTableOptionRow(&isOptionRow{Dataset: "ds1", Table: bigquery.NullString{StringVal: "table1", Valid: true}, OptionName: "kms_key_name", OptionValue: `"key"`})
+
+	snapshot.addColumnRow(&isColumnRow{Dataset: "ds1", Table: "table1", Column: "id", DataType: "INT64", PartitioningColumn: "NO", ClusteringPosition: bigquery.NullInt64{Int64: 2, Valid: true}})
+	snapshot.addColumnRow(&isColumnRow{Dataset: "ds1", Table: "table1", Column: "name", DataType: "STRING", Description: bigquery.NullString{StringVal: "column description", Valid: true}, PartitioningColumn: "NO", ClusteringPosition: bigquery.NullInt64{Int64: 1, Valid: true}})
+	snapshot.addColumnRow(&isColumnRow{Dataset: "ds1", Table: "table1", Column: "created_at", DataType: "TIMESTAMP", PartitioningColumn: "YES"})
+
+	snapshot.addTableStorageRow(&isTableStorageRow{Dataset: "ds1", Table: "table1", TotalRows: bigquery.NullInt64{Int64: 10, Valid: true}, LogicalBytes: bigquery.NullInt64{Int64: 2048, Valid: true}, LastModifiedTime: bigquery.NullTimestamp{Timestamp: time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC), Valid: true}})
+	snapshot.addTableStorageRow(&isTableStorageRow{Dataset: "ds1", Table: "view1"})
+
+	assert.Nil(t, snapshot.loadedDataset("ds3"))
+	assert.Nil(t, snapshot.loadedDataset("unknown"))
+	assert.NotNil(t, snapshot.loadedDataset("ds2"))
+
+	project := &org.GcpOrgEntity{Id: "project1", FullName: "project1", Type: data_source.Datasource}
+
+	isDs := snapshot.loadedDataset("ds1")
+	dsEntity := isDs.toEntity(project)
+
+	assert.Equal(t, &org.GcpOrgEntity{
+		Type:        data_source.Dataset,
+		Name:        "ds1",
+		Id:          "project1.ds1",
+		FullName:    "project1.ds1",
+		Description: "dataset description",
+		Parent:      project,
+		Location:    "EU",
+		Tags:        map[string]string{"env": "prod"},
+	}, dsEntity)
+
+	tables := isDs.listTables()
+	assert.Len(t, tables, 3)
+
+	tableEntity := tables[0].toEntity(dsEntity)
+	assert.Equal(t, data_source.Table, tableEntity.Type)
+	assert.Equal(t, "project1.ds1.table1", tableEntity.FullName)
+	assert.Equal(t, "table description", tableEntity.Description)
+	assert.Equal(t, "EU", tableEntity.Location)
+	assert.Equal(t, map[string]string{
+		TagTableType:         TableTypeTable,
+		TagPartitioningField: "created_at",
+		TagClusteringFields:  "name,id",
+		TagExpirationTime:    "2025-01-01T00:00:00Z",
+		TagKmsKeyName:        "key",
+		TagNumRows:           "10",
+		TagLogicalBytes:      "2048",
+		TagLastModifiedTime:  "2024-03-01T12:30:00Z",
+	}, tableEntity.Tags)
+
+	viewEntity := tables[1].toEntity(dsEntity)
+	assert.Equal(t, data_source.View, viewEntity.Type)
+	assert.Equal(t, "project1.ds1.view1", viewEntity.FullName)
+	assert.Equal(t, map[string]string{
+		TagTableType:        TableTypeView,
+		TagViewQuery:        "SELECT id FROM ds1.table1",
+		TagReferencedTables: "project1.ds1.table1",
+	}, viewEntity.Tags)
+
+	mvEntity := tables[2].toEntity(dsEntity)
+	assert.Equal(t, TypeMaterializedView, mvEntity.Type)
+	assert.Equal(t, "project1.ds1.table1", mvEntity.Tags[TagReferencedTables])
+
+	assert.Len(t, tables[0].Columns, 3)
+	assert.Equal(t, &org.GcpOrgEntity{
+		Type:        data_source.Column,
+		Name:        "name",
+		Id:          "project1.ds1.table1.name",
+		FullName:    "project1.ds1.table1.name",
+		Parent:      tableEntity,
+		Description: "column description",
+		Location:    "EU",
+		DataType:    ptr.String("STRING"),
+	}, tables[0].Columns[1].toEntity(tableEntity))
+	assert.Equal(t, ptr.String("INTEGER"), tables[0].Columns[0].toEntity(tableEntity).DataType)
+}
@@ -0,0 +1,201 @@
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/raito-io/golang-set/set"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+// ViewLineage is the dependency graph of views and materialized views on the tables and views they reference.
+// All nodes are identified by their full name (project.dataset.table).
+type ViewLineage struct {
+	upstream map[string]set.Set[string]
+}
+
+func NewViewLineage() *ViewLineage {
+	return &ViewLineage{
+		upstream: make(map[string]set.Set[string]),
+	}
+}
+
+// SetUpstream sets the tables and views that are directly referenced by a view, replacing any previously known references.
+func (l *ViewLineage) SetUpstream(view string, referencedTables ...string) {
+	l.upstream[view] = set.NewSet(referencedTables...)
+}
+
+func (l *ViewLineage) IsView(fullName string) bool {
+	_, found := l.upstream[fullName]
+
+	return found
+}
+
+// Upstream returns the tables and views that are directly referenced by a view.
+func (l *ViewLineage) Upstream(view string) []string {
+	result := l.upstream[view].Slice()
+	sort.Strings(result)
+
+	return result
+}
+
+// BaseTables returns all tables on which a view transitively depends. Referenced views are resolved to their own base tables.
+func (l *ViewLineage) BaseTables(view string) []string {
+	baseTables := set.NewSet[string]()
+	visited := set.NewSet[string]()
+
+	var resolve func(node string)
+	resolve = func(node string) {
+		if visited.Contains(node) {
+			return
+		}
+
+		visited.Add(node)
+
+		for upstream := range l.upstream[node] {
+			if l.IsView(upstream) {
+				resolve(upstream)
+			} else {
+				baseTables.Add(upstream)
+			}
+		}
+	}
+
+	resolve(view)
+
+	result := baseTables.Slice()
+	sort.Strings(result)
+
+	return result
+}
+
+// AttributeUsage attributes the usage of a query to the views that are referenced in the query.
+// INFORMATION_SCHEMA.JOBS only references the base tables of a view, so the queried views are added and the base tables
+// that were only accessed through those views are removed from the referenced tables.
+func (l *ViewLineage) AttributeUsage(row *BQInformationSchemaEntity, defaultProject string) {
+	queried := referencedTablesFromQuery(row.Query, defaultProject)
+
+	queriedSet := set.NewSet(queried...)
+	accessedThroughViews := set.NewSet[string]()
+
+	var views []string
+
+	for _, fullName := range queried {
+		if !l.IsView(fullName) {
+			continue
+		}
+
+		views = append(views, fullName)
+
+		for _, baseTable := range l.BaseTables(fullName) {
+			if !queriedSet.Contains(baseTable) {
+				accessedThroughViews.Add(baseTable)
+			}
+		}
+	}
+
+	if len(views) == 0 {
+		return
+	}
+
+	tables := make([]BQInformationSchemaReferencedTable, 0, len(row.Tables)+len(views))
+	knownTables := set.NewSet[string]()
+
+	for _, table := range row.Tables {
+		fullName := fmt.Sprintf("%s.%s.%s", table.Project.StringVal, table.Dataset.StringVal, table.Table.StringVal)
+
+		if accessedThroughViews.Contains(fullName) {
+			continue
+		}
+
+		knownTables.Add(fullName)
+		tables = append(tables, table)
+	}
+
+	for _, view := range views {
+		if knownTables.Contains(view) {
+			continue
+		}
+
+		parts := strings.Split(view, ".")
+
+		tables = append(tables, BQInformationSchemaReferencedTable{
+			Project: bigquery.NullString{StringVal: parts[0], Valid: true},
+			Dataset: bigquery.NullString{StringVal: parts[1], Valid: true},
+			Table:   bigquery.NullString{StringVal: parts[2], Valid: true},
+		})
+
+		common.Logger.Debug(fmt.Sprintf("Query %q references view %q, adding a reference to it for usage", row.Query, view))
+	}
+
+	row.Tables = tables
+}
+
+// GetViewLineage builds the lineage of all views in the project from their SQL definition.
+// References that BigQuery resolved when creating the views (INFORMATION_SCHEMA.JOBS) take precedence over the parsed SQL definition.
+func (c *Repository) GetViewLineage(ctx context.Context, regions set.Set[string]) (*ViewLineage, error) {
+	lineage := NewViewLineage()
+
+	err := c.ListDataSets(ctx, c.Project(), func(ctx context.Context, entity *org.GcpOrgEntity, dataset *bigquery.Dataset) error {
+		return c.ListViews(ctx, dataset, entity, func(ctx context.Context, entity *org.GcpOrgEntity) error {
+			var referencedTables []string
+			if references := entity.Tags[TagReferencedTables]; references != "" {
+				referencedTables = strings.Split(references, ",")
+			}
+
+			lineage.SetUpstream(entity.FullName, referencedTables...)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for region := range regions {
+		err = c.loadViewLineageFromJobs(ctx, strings.ToLower(region), lineage)
+		if err != nil {
+			common.Logger.Warn(fmt.Sprintf("Unable to load view lineage from INFORMATION_SCHEMA.JOBS in region %s. Using the view definitions instead: %s", region, err.Error()))
+		}
+	}
+
+	return lineage, nil
+}
+
+func (c *Repository) loadViewLineageFromJobs(ctx context.Context, region string, lineage *ViewLineage) error {
+	query := fmt.Sprintf(`
+		SELECT
+			destination_table.project_id AS project_id,
+			destination_table.dataset_id AS dataset_id,
+			destination_table.table_id AS table_id,
+			referenced_tables
+		FROM
+			%s.INFORMATION_SCHEMA.JOBS
+		WHERE
+			state = "DONE"
+			AND error_result IS NULL
+			AND statement_type IN ("CREATE_VIEW", "CREATE_MATERIALIZED_VIEW")
+			AND destination_table.project_id = "%s"
+		QUALIFY ROW_NUMBER() OVER (PARTITION BY destination_table.dataset_id, destination_table.table_id ORDER BY creation_time DESC) = 1`, fmt.Sprintf("`region-%s`", region), c.projectId)
+
+	return readInformationSchemaQuery(ctx, c.client, query, func(row *BQViewLineageEntity) {
+		view := fmt.Sprintf("%s.%s.%s", row.Project, row.Dataset, row.Table)
+
+		// Only update views that still exist
+		if !lineage.IsView(view) || len(row.Tables) == 0 {
+			return
+		}
+
+		referencedTables := make([]string, 0, len(row.Tables))
+		for _, table := range row.Tables {
+			referencedTables = append(referencedTables, fmt.Sprintf("%s.%s.%s", table.Project.StringVal, table.Dataset.StringVal, table.Table.StringVal))
+		}
+
+		lineage.SetUpstream(view, referencedTables...)
+	})
+}
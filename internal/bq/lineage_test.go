@@ -0,0 +1,85 @@
+package bigquery
+
+import (
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/stretchr/testify/assert"
+)
+
+func referencedTable(project, dataset, table string) BQInformationSchemaReferencedTable {
+	return BQInformationSchemaReferencedTable{
+		Project: bigquery.NullString{StringVal: project, Valid: true},
+		Dataset: bigquery.NullString{StringVal: dataset, Valid: true},
+		Table:   bigquery.NullString{StringVal: table, Valid: true},
+	}
+}
+
+func testViewLineage() *ViewLineage {
+	lineage := NewViewLineage()
+	lineage.SetUpstream("project1.ds1.view1", "project1.ds1.table1", "project1.ds1.table2")
+	lineage.SetUpstream("project1.ds1.view2", "project1.ds1.view1", "project1.ds2.table3")
+	lineage.SetUpstream("project1.ds1.cycle1", "project1.ds1.cycle2")
+	lineage.SetUpstream("project1.ds1.cycle2", "project1.ds1.cycle1", "project1.ds1.table1")
+
+	return lineage
+}
+
+func TestViewLineage_BaseTables(t *testing.T) {
+	lineage := testViewLineage()
+
+	assert.Equal(t, []string{"project1.ds1.table1", "project1.ds1.table2"}, lineage.BaseTables("project1.ds1.view1"))
+	assert.Equal(t, []string{"project1.ds1.table1", "project1.ds1.table2", "project1.ds2.table3"}, lineage.BaseTables("project1.ds1.view2"))
+	assert.Equal(t, []string{"project1.ds1.table1"}, lineage.BaseTables("project1.ds1.cycle1"))
+	assert.Equal(t, []string{"project1.ds1.view1", "project1.ds2.table3"}, lineage.Upstream("project1.ds1.view2"))
+	assert.Empty(t, lineage.BaseTables("project1.ds1.table1"))
+}
+
+func TestViewLineage_AttributeUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		row  BQInformationSchemaEntity
+		want []BQInformationSchemaReferencedTable
+	}{
+		{
+			name: "no views referenced",
+			row: BQInformationSchemaEntity{
+				Query:  "SELECT * FROM ds1.table1",
+				Tables: []BQInformationSchemaReferencedTable{referencedTable("project1", "ds1", "table1")},
+			},
+			want: []BQInformationSchemaReferencedTable{referencedTable("project1", "ds1", "table1")},
+		},
+		{
+			name: "view replaces its base tables",
+			row: BQInformationSchemaEntity{
+				Query:  "SELECT * FROM `project1.ds1.view1`",
+				Tables: []BQInformationSchemaReferencedTable{referencedTable("project1", "ds1", "table1"), referencedTable("project1", "ds1", "table2")},
+			},
+			want: []BQInformationSchemaReferencedTable{referencedTable("project1", "ds1", "view1")},
+		},
+		{
+			name: "directly queried base tables are kept",
+			row: BQInformationSchemaEntity{
+				Query:  "SELECT * FROM ds1.view2 v JOIN ds1.table1 t ON v.id = t.id",
+				Tables: []BQInformationSchemaReferencedTable{referencedTable("project1", "ds1", "table1"), referencedTable("project1", "ds1", "table2"), referencedTable("project1", "ds2", "table3")},
+			},
+			want: []BQInformationSchemaReferencedTable{referencedTable("project1", "ds1", "table1"), referencedTable("project1", "ds1", "view2")},
+		},
+		{
+			name: "view name as substring of another name is not matched",
+			row: BQInformationSchemaEntity{
+				Query:  "SELECT * FROM ds1.view10",
+				Tables: []BQInformationSchemaReferencedTable{referencedTable("project1", "ds1", "view10")},
+			},
+			want: []BQInformationSchemaReferencedTable{referencedTable("project1", "ds1", "view10")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := tt.row
+			testViewLineage().AttributeUsage(&row, "project1")
+
+			assert.Equal(t, tt.want, row.Tables)
+		})
+	}
+}
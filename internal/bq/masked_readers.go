@@ -0,0 +1,143 @@
+package bigquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"github.com/raito-io/golang-set/set"
+	"google.golang.org/api/googleapi"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/common/roles"
+	"github.com/raito-io/cli-plugin-gcp/internal/iam"
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+// DataPoliciesForDataObject returns the data policies that mask columns of the data object.
+// Tables and views are covered by the data policies of the policy tags on their columns.
+// Datasets are covered by all data policies in their location, and the project by all its data policies.
+func (r *DataCatalogRepository) DataPoliciesForDataObject(ctx context.Context, dataObject *iam.DataObjectReference) ([]string, error) {
+	dataPolicies, err := r.ListDataPolicies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list data policies: %w", err)
+	}
+
+	result := set.NewSet[string]()
+	nameSplit := strings.Split(dataObject.FullName, ".")
+
+	switch len(nameSplit) {
+	case 1:
+		for _, mask := range dataPolicies {
+			result.Add(mask.DataPolicy.FullName)
+		}
+	case 2:
+		datasets, err2 := r.getDataSets(ctx)
+		if err2 != nil {
+			return nil, err2
+		}
+
+		dataset, found := datasets[dataObject.FullName]
+		if !found {
+			return nil, fmt.Errorf("dataset %q not found", dataObject.FullName)
+		}
+
+		for _, mask := range dataPolicies {
+			if dataPolicyLocation(mask.DataPolicy.FullName) == strings.ToLower(dataset.Location) {
+				result.Add(mask.DataPolicy.FullName)
+			}
+		}
+	default:
+		policyTags, err2 := r.tablePolicyTags(ctx, strings.Join(nameSplit[0:3], "."))
+		if err2 != nil {
+			return nil, err2
+		}
+
+		for _, policyTag := range policyTags {
+			if mask, found := dataPolicies[policyTag]; found {
+				result.Add(mask.DataPolicy.FullName)
+			}
+		}
+	}
+
+	dataPolicyIds := result.Slice()
+	sort.Strings(dataPolicyIds)
+
+	return dataPolicyIds, nil
+}
+
+// tablePolicyTags returns the policy tags attached to the columns of the table.
+func (r *DataCatalogRepository) tablePolicyTags(ctx context.Context, table string) ([]string, error) {
+	datasets, err := r.getDataSets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nameSplit := strings.Split(table, ".")
+
+	dataset, found := datasets[strings.Join(nameSplit[0:2], ".")]
+	if !found {
+		return nil, fmt.Errorf("dataset of %q not found", table)
+	}
+
+	tableEntity := &org.GcpOrgEntity{
+		Type:     "table",
+		Name:     nameSplit[2],
+		Id:       table,
+		FullName: table,
+		Parent:   &dataset,
+		Location: dataset.Location,
+	}
+
+	var policyTags []string
+
+	err = r.bigQueryRepo.ListColumns(ctx, nil, tableEntity, func(_ context.Context, entity *org.GcpOrgEntity) error {
+		for _, policyTag := range entity.PolicyTags {
+			policyTags = append(policyTags, projectNumberRegex.ReplaceAllString(policyTag, fmt.Sprintf("projects/%s/", r.projectId)))
+		}
+
+		return nil
+	})
+
+	var e *googleapi.Error
+	if ok := errors.As(err, &e); ok && e.Code == 404 {
+		common.Logger.Warn(fmt.Sprintf("Table %q not found. Unable to determine the policy tags of its columns", table))
+
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("list columns of table %q: %w", table, err)
+	}
+
+	return policyTags, nil
+}
+
+// UpdateMaskedReaders adds and removes the members as masked reader of the data policy.
+func (r *DataCatalogRepository) UpdateMaskedReaders(ctx context.Context, dataPolicyId string, membersToAdd []string, membersToRemove []string) error {
+	policy, err := r.dataPolicyClient.GetIamPolicy(ctx, &iampb.GetIamPolicyRequest{Resource: dataPolicyId})
+	if err != nil {
+		return fmt.Errorf("get iam policy of data policy %q: %w", dataPolicyId, err)
+	}
+
+	_, err = r.dataPolicyClient.SetIamPolicy(ctx, &iampb.SetIamPolicyRequest{
+		Resource: dataPolicyId,
+		Policy:   iam.UpdateRoleMembers(policy, roles.RolesBigQueryMaskedReader.Name, membersToAdd, membersToRemove),
+	})
+	if err != nil {
+		return fmt.Errorf("set iam policy of data policy %q: %w", dataPolicyId, err)
+	}
+
+	return nil
+}
+
+// dataPolicyLocation returns the location of a data policy (projects/{project}/locations/{location}/dataPolicies/{id}).
+func dataPolicyLocation(dataPolicyId string) string {
+	parts := strings.Split(dataPolicyId, "/")
+	if len(parts) < 4 {
+		return ""
+	}
+
+	return strings.ToLower(parts[3])
+}
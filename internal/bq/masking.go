@@ -3,11 +3,13 @@ package bigquery
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
 	"cloud.google.com/go/bigquery/datapolicies/apiv1/datapoliciespb"
 	"github.com/aws/smithy-go/ptr"
+	"github.com/hashicorp/go-multierror"
 	"github.com/raito-io/cli/base/access_provider/sync_from_target"
 	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
 	"github.com/raito-io/cli/base/access_provider/types"
@@ -23,26 +25,57 @@ import (
 //go:generate go run github.com/vektra/mockery/v2 --name=maskingDataCatalogRepository --with-expecter --inpackage
 type maskingDataCatalogRepository interface {
 	ListDataPolicies(ctx context.Context) (map[string]BQMaskingInformation, error)
-	GetFineGrainedReaderMembers(ctx context.Context, tagId string) ([]string, error)
+	DataPoliciesForDataObject(ctx context.Context, dataObject *iam.DataObjectReference) ([]string, error)
+	UpdateMaskedReaders(ctx context.Context, dataPolicyId string, membersToAdd []string, membersToRemove []string) error
+	GetFineGrainedReaderMembers(ctx context.Context, tagId string) (*FineGrainedReaders, error)
 	DeletePolicyAndTag(ctx context.Context, policyTagId string) error
 	UpdateAccess(ctx context.Context, maskingInformation *BQMaskingInformation, who *importer.WhoItem, deletedWho *importer.WhoItem) error
-	UpdateWhatOfDataPolicy(ctx context.Context, policy *BQMaskingInformation, dataObjects []string, deletedDataObjects []string) error
-	UpdatePolicyTag(ctx context.Context, location string, maskingType datapoliciespb.DataMaskingPolicy_PredefinedExpression, ap *importer.AccessProvider, dataPolicyId string) (*BQMaskingInformation, error)
-	CreatePolicyTagWithDataPolicy(ctx context.Context, location string, maskingType datapoliciespb.DataMaskingPolicy_PredefinedExpression, ap *importer.AccessProvider) (_ *BQMaskingInformation, err error)
+	AddColumnPolicyTagUpdate(policy *BQMaskingInformation, dataObjects []string, deletedDataObjects []string) *ColumnPolicyTagResult
+	ApplyColumnPolicyTagUpdates(ctx context.Context)
+	UpdatePolicyTag(ctx context.Context, location string, maskingType BQMaskingType, ap *importer.AccessProvider, dataPolicyId string) (*BQMaskingInformation, error)
+	CreatePolicyTagWithDataPolicy(ctx context.Context, location string, maskingType BQMaskingType, ap *importer.AccessProvider) (*BQMaskingInformation, error)
+	CreateFallbackPolicyTagWithDataPolicy(ctx context.Context, location string, ap *importer.AccessProvider) (*BQMaskingInformation, error)
+	GetColumnDataTypes(ctx context.Context, columns []string) (map[string]string, error)
 	GetLocationsForDataObjects(ctx context.Context, ap *importer.AccessProvider) (map[string]string, map[string]string, error)
+	GetPolicyTag(ctx context.Context, tagId string) (*BQPolicyTag, error)
+	CreateColumnAccessPolicyTag(ctx context.Context, location string, ap *importer.AccessProvider) (*BQPolicyTag, error)
+	UpdateColumnAccessPolicyTag(ctx context.Context, policyTag *BQPolicyTag, ap *importer.AccessProvider) (*BQPolicyTag, error)
+	DeleteColumnAccessPolicyTag(ctx context.Context, policyTag *BQPolicyTag) (bool, error)
 }
 
 type BqMaskingService struct {
 	datacatalogRepo maskingDataCatalogRepository
+	catalogTagRepo  catalogTagRepository
 	projectId       string
 	maskingEnabled  bool
+
+	// Mask columns of which the data type is not supported by the mask type with the default masking value, instead of rejecting them
+	defaultValueFallback bool
+
+	// Delete data policies of which the policy tag is not attached to any column, instead of importing them
+	deleteOrphaned bool
+
+	// Feedback of masks and column accesses that is completed once the column policy tags are applied
+	pendingFeedback []*pendingColumnPolicyTagFeedback
+}
+
+type pendingColumnPolicyTagFeedback struct {
+	feedback *importer.AccessProviderSyncFeedback
+	results  []*ColumnPolicyTagResult
+
+	// handler is nil if the feedback is sent by the caller
+	handler wrappers.AccessProviderFeedbackHandler
 }
 
-func NewBqMaskingService(dataCatalogRepository maskingDataCatalogRepository, configMap *config.ConfigMap) *BqMaskingService {
+func NewBqMaskingService(dataCatalogRepository maskingDataCatalogRepository, catalogTagRepository catalogTagRepository, configMap *config.ConfigMap) *BqMaskingService {
 	return &BqMaskingService{
 		datacatalogRepo: dataCatalogRepository,
+		catalogTagRepo:  catalogTagRepository,
 		projectId:       configMap.GetString(common.GcpProjectId),
 		maskingEnabled:  configMap.GetBoolWithDefault(common.BqCatalogEnabled, false),
+
+		defaultValueFallback: configMap.GetBoolWithDefault(common.BqMaskDefaultValueFallback, false),
+		deleteOrphaned:       configMap.GetBoolWithDefault(common.BqMaskDeleteOrphaned, false),
 	}
 }
 
@@ -62,64 +95,132 @@ func (m *BqMaskingService) ImportMasks(ctx context.Context, accessProviderHandle
 	for maskTag, columns := range maskingTags {
 		mask, found := masks[maskTag]
 		if !found {
-			common.Logger.Warn(fmt.Sprintf("Data policy for tag %s not found", maskTag))
+			// Policy tags without data policy are only used for column-level access control
+			err = m.importColumnAccess(ctx, accessProviderHandler, maskTag, columns)
+			if err != nil {
+				return err
+			}
 
 			continue
-		} else if raitoMasks.Contains(mask.DataPolicy.FullName) {
+		} else if raitoMasks.Contains(mask.DataPolicy.FullName) || mask.PolicyTag.RaitoManaged {
 			common.Logger.Debug(fmt.Sprintf("Ingore raito created mask %q", maskTag))
 
 			continue
 		}
 
-		whatItems := make([]sync_from_target.WhatItem, 0, len(columns))
+		err = m.importMask(ctx, accessProviderHandler, &mask, columns)
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.importOrphanedMasks(ctx, accessProviderHandler, masks, maskingTags, raitoMasks)
+}
+
+// importOrphanedMasks imports the data policies of which the policy tag is not attached to any synced column as masks without what items.
+// If deleteOrphaned is set, those data policies are deleted instead, but only if a Data Catalog search confirms that no column uses their policy tag,
+// as hidden and excluded datasets are not synced. Data policies managed by Raito are never deleted.
+func (m *BqMaskingService) importOrphanedMasks(ctx context.Context, accessProviderHandler wrappers.AccessProviderHandler, masks map[string]BQMaskingInformation, maskingTags map[string][]string, raitoMasks set.Set[string]) error {
+	orphanedTags := make([]string, 0, len(masks))
 
-		for _, column := range columns {
-			whatItems = append(whatItems, sync_from_target.WhatItem{
-				DataObject: &ds.DataObjectReference{
-					FullName: column,
-					Type:     "column",
-				},
-				Permissions: []string{},
-			})
+	for maskTag, mask := range masks {
+		if _, found := maskingTags[maskTag]; found || raitoMasks.Contains(maskTag) || raitoMasks.Contains(mask.DataPolicy.FullName) {
+			continue
 		}
 
-		whoItem := sync_from_target.WhoItem{}
+		orphanedTags = append(orphanedTags, maskTag)
+	}
 
-		members, err := m.datacatalogRepo.GetFineGrainedReaderMembers(ctx, mask.PolicyTag.FullName)
-		if err != nil {
-			return fmt.Errorf("gine grained reader members for %q: %w", mask.PolicyTag.FullName, err)
+	sort.Strings(orphanedTags)
+
+	for _, maskTag := range orphanedTags {
+		mask := masks[maskTag]
+
+		if mask.PolicyTag.RaitoManaged {
+			common.Logger.Debug(fmt.Sprintf("Ignore raito created mask %q that is not attached to any column", maskTag))
+
+			continue
 		}
 
-		for _, member := range members {
-			if strings.HasPrefix(member, "user:") {
-				whoItem.Users = append(whoItem.Users, member[5:])
-			} else if strings.HasPrefix(member, "serviceAccount:") {
-				whoItem.Users = append(whoItem.Users, member[10:])
-			} else if strings.HasPrefix(member, "group:") {
-				whoItem.Groups = append(whoItem.Groups, member[6:])
+		if m.deleteOrphaned && m.orphanedPolicyTagUnused(ctx, &mask) {
+			common.Logger.Info(fmt.Sprintf("Delete data policy %q as its policy tag %q is not attached to any column", mask.DataPolicy.FullName, maskTag))
+
+			err := m.datacatalogRepo.DeletePolicyAndTag(ctx, mask.DataPolicy.FullName)
+			if err != nil {
+				return fmt.Errorf("delete orphaned data policy %q: %w", mask.DataPolicy.FullName, err)
 			}
+
+			continue
 		}
 
-		err = accessProviderHandler.AddAccessProviders(
-			&sync_from_target.AccessProvider{
-				Name:       mask.PolicyTag.Name,
-				Type:       ptr.String(mask.DataPolicy.PolicyType.String()),
-				What:       whatItems,
-				Action:     types.Mask,
-				ExternalId: mask.DataPolicy.FullName,
-				Who:        &whoItem,
-				ActualName: mask.PolicyTag.Name,
-			},
-		)
+		common.Logger.Debug(fmt.Sprintf("Policy tag %q of data policy %q is not attached to any synced column", maskTag, mask.DataPolicy.FullName))
 
+		err := m.importMask(ctx, accessProviderHandler, &mask, nil)
 		if err != nil {
-			return fmt.Errorf("add mask to ap handler: %w", err)
+			return err
 		}
 	}
 
 	return nil
 }
 
+// orphanedPolicyTagUnused returns true if a Data Catalog search finds no column with the policy tag of the mask. Otherwise the data policy is kept and reported.
+func (m *BqMaskingService) orphanedPolicyTagUnused(ctx context.Context, mask *BQMaskingInformation) bool {
+	inUse, err := m.catalogTagRepo.PolicyTagInUse(ctx, &mask.PolicyTag)
+	if err != nil {
+		common.Logger.Warn(fmt.Sprintf("Orphaned data policy %q is not deleted, as the usage of its policy tag cannot be verified: %s", mask.DataPolicy.FullName, err.Error()))
+
+		return false
+	} else if inUse {
+		common.Logger.Warn(fmt.Sprintf("Orphaned data policy %q is not deleted, as its policy tag %q is attached to columns that are not synced", mask.DataPolicy.FullName, mask.PolicyTag.FullName))
+
+		return false
+	}
+
+	return true
+}
+
+// importMask imports the data policy as mask on the columns.
+func (m *BqMaskingService) importMask(ctx context.Context, accessProviderHandler wrappers.AccessProviderHandler, mask *BQMaskingInformation, columns []string) error {
+	whatItems := make([]sync_from_target.WhatItem, 0, len(columns))
+
+	for _, column := range columns {
+		whatItems = append(whatItems, sync_from_target.WhatItem{
+			DataObject: &ds.DataObjectReference{
+				FullName: column,
+				Type:     "column",
+			},
+			Permissions: []string{},
+		})
+	}
+
+	readers, err := m.datacatalogRepo.GetFineGrainedReaderMembers(ctx, mask.PolicyTag.FullName)
+	if err != nil {
+		return fmt.Errorf("fine grained reader members for %q: %w", mask.PolicyTag.FullName, err)
+	}
+
+	whoItem := MembersToWhoItem(readers.Direct)
+
+	err = accessProviderHandler.AddAccessProviders(
+		&sync_from_target.AccessProvider{
+			Name:       mask.PolicyTag.Name,
+			Type:       ptr.String(mask.DataPolicy.MaskingType().String()),
+			What:       whatItems,
+			Action:     types.Mask,
+			ExternalId: mask.DataPolicy.FullName,
+			Who:        &whoItem,
+			ActualName: mask.PolicyTag.Name,
+			Tags:       readers.InheritedReadersTags(),
+		},
+	)
+
+	if err != nil {
+		return fmt.Errorf("add mask to ap handler: %w", err)
+	}
+
+	return nil
+}
+
 func (m *BqMaskingService) ExportMasks(ctx context.Context, accessProvider *importer.AccessProvider, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler) ([]string, error) {
 	if !m.maskingEnabled {
 		err := accessProviderFeedbackHandler.AddAccessProviderFeedback(importer.AccessProviderSyncFeedback{
@@ -133,16 +234,19 @@ func (m *BqMaskingService) ExportMasks(ctx context.Context, accessProvider *impo
 		return nil, nil
 	}
 
-	var actualName, externalId, errors []string
+	var actualName, externalId []string
 	var maskType *string
 	var err error
 
+	pending := &pendingColumnPolicyTagFeedback{handler: accessProviderFeedbackHandler}
+
 	if accessProvider.Delete {
 		actualName, maskType, externalId, err = m.deleteMask(ctx, accessProvider)
 	} else {
-		actualName, maskType, externalId, err = m.exportMasks(ctx, accessProvider)
+		actualName, maskType, externalId, err = m.exportMasks(ctx, accessProvider, pending)
 	}
 
+	var errors []string
 	if err != nil {
 		errors = append(errors, err.Error())
 	}
@@ -150,7 +254,7 @@ func (m *BqMaskingService) ExportMasks(ctx context.Context, accessProvider *impo
 	sort.Strings(actualName)
 	sort.Strings(externalId)
 
-	err = accessProviderFeedbackHandler.AddAccessProviderFeedback(importer.AccessProviderSyncFeedback{
+	pending.feedback = &importer.AccessProviderSyncFeedback{
 		AccessProvider: accessProvider.Id,
 		ActualName:     strings.Join(actualName, ","),
 		Type:           maskType,
@@ -162,28 +266,57 @@ func (m *BqMaskingService) ExportMasks(ctx context.Context, accessProvider *impo
 			},
 		},
 		Errors: errors,
-	})
-
-	if err != nil {
-		return nil, fmt.Errorf("add ap feedback to handler: %w", err)
 	}
 
+	// The feedback is sent once the column policy tags are applied
+	m.pendingFeedback = append(m.pendingFeedback, pending)
+
 	return externalId, nil
 }
 
-func (m *BqMaskingService) MaskedBinding(_ context.Context, members []string) ([]iam.IamBinding, error) {
-	bindings := make([]iam.IamBinding, 0, len(members))
+// ApplyColumnPolicyTags applies the column policy tag changes of all exported masks and column accesses, with a single schema update per table.
+// Afterwards, the feedback of the masks is sent, including the errors and warnings for their columns.
+func (m *BqMaskingService) ApplyColumnPolicyTags(ctx context.Context) error {
+	if len(m.pendingFeedback) == 0 {
+		return nil
+	}
+
+	m.datacatalogRepo.ApplyColumnPolicyTagUpdates(ctx)
 
-	for _, member := range members {
-		bindings = append(bindings, iam.IamBinding{
-			Member:       member,
-			Role:         "roles/bigquerydatapolicy.maskedReader",
-			Resource:     m.projectId,
-			ResourceType: "project",
-		})
+	var merr error
+
+	for _, pending := range m.pendingFeedback {
+		for _, result := range pending.results {
+			pending.feedback.Errors = append(pending.feedback.Errors, result.Errors...)
+			pending.feedback.Warnings = append(pending.feedback.Warnings, result.Warnings...)
+		}
+
+		if pending.handler != nil {
+			err := pending.handler.AddAccessProviderFeedback(*pending.feedback)
+			if err != nil {
+				merr = multierror.Append(merr, fmt.Errorf("add ap feedback to handler: %w", err))
+			}
+		}
 	}
 
-	return bindings, nil
+	m.pendingFeedback = nil
+
+	return merr
+}
+
+// MaskedReaderDataPolicies returns the data policies that mask columns of the data object.
+func (m *BqMaskingService) MaskedReaderDataPolicies(ctx context.Context, dataObject *iam.DataObjectReference) ([]string, error) {
+	return m.datacatalogRepo.DataPoliciesForDataObject(ctx, dataObject)
+}
+
+// UpdateMaskedReaders adds and removes the members as masked reader of the data policy.
+func (m *BqMaskingService) UpdateMaskedReaders(ctx context.Context, dataPolicyId string, membersToAdd []string, membersToRemove []string) error {
+	return m.datacatalogRepo.UpdateMaskedReaders(ctx, dataPolicyId, membersToAdd, membersToRemove)
+}
+
+// LegacyMaskedReaderResource returns the project on which earlier versions granted the masked reader role.
+func (m *BqMaskingService) LegacyMaskedReaderResource() *iam.DataObjectReference {
+	return &iam.DataObjectReference{FullName: m.projectId, ObjectType: "project"}
 }
 
 func (m *BqMaskingService) deleteMask(ctx context.Context, ap *importer.AccessProvider) (actualNames []string, apType *string, externalIds []string, err error) {
@@ -213,7 +346,7 @@ func (m *BqMaskingService) deleteMask(ctx context.Context, ap *importer.AccessPr
 	return actualNames, ap.Type, externalIds, nil
 }
 
-func (m *BqMaskingService) exportMasks(ctx context.Context, accessProvider *importer.AccessProvider) (actualName []string, apType *string, externalId []string, err error) {
+func (m *BqMaskingService) exportMasks(ctx context.Context, accessProvider *importer.AccessProvider, pending *pendingColumnPolicyTagFeedback) (actualName []string, apType *string, externalId []string, err error) {
 	common.Logger.Info(fmt.Sprintf("Update mask %s", accessProvider.Name))
 
 	defer func() {
@@ -228,26 +361,74 @@ func (m *BqMaskingService) exportMasks(ctx context.Context, accessProvider *impo
 		return nil, nil, nil, err
 	}
 
+	fallbackPolicyLocations := fallbackDataPolicyLocations(accessProvider.ExternalId)
+
+	maskingType := ParseMaskingType(accessProvider.Type)
+	apType = ptr.String(maskingType.String())
+
+	originalDeletedDoLocations := copyLocations(deletedDoLocations)
+
+	// Columns of which the data type is not supported by the mask type are detached from the mask
+	fallbackDoLocations, err := m.exportRaitoMaskFilterDataTypes(ctx, maskingType, doLocations, deletedDoLocations, pending)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	// First remove old data policies
 	err = m.exportRaitoMaskRemoveOldPolicies(ctx, accessProvider, deletedDoLocations, doLocations, dataPolicyLocations)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	err = m.exportRaitoMaskRemoveOldFallbackPolicies(ctx, accessProvider, fallbackDoLocations, fallbackPolicyLocations)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	// Create or update all data policies
-	apType, dataPolicyMap, err := m.exportRaitoMaskCreateAndUpdateDataPolicies(ctx, accessProvider, doLocations, dataPolicyLocations)
+	dataPolicyMap, err := m.exportRaitoMaskCreateAndUpdateDataPolicies(ctx, accessProvider, maskingType, doLocations, dataPolicyLocations, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	fallbackDataPolicyMap, err := m.exportRaitoMaskCreateAndUpdateDataPolicies(ctx, accessProvider, BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_DEFAULT_MASKING_VALUE}, fallbackDoLocations, fallbackPolicyLocations, true)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	externalId = make([]string, 0, len(dataPolicyMap))
-	actualName = make([]string, 0, len(dataPolicyMap))
+	externalId = make([]string, 0, len(dataPolicyMap)+len(fallbackDataPolicyMap))
+	actualName = make([]string, 0, len(dataPolicyMap)+len(fallbackDataPolicyMap))
 
 	for _, maskingInformation := range dataPolicyMap {
 		externalId = append(externalId, maskingInformation.DataPolicy.FullName)
 		actualName = append(actualName, maskingInformation.PolicyTag.Name)
 	}
 
+	for _, maskingInformation := range fallbackDataPolicyMap {
+		externalId = append(externalId, maskingInformation.DataPolicy.FullName)
+		actualName = append(actualName, maskingInformation.PolicyTag.Name)
+	}
+
+	err = m.exportRaitoMaskUpdateColumns(ctx, accessProvider, dataPolicyMap, doLocations, deletedDoLocations, pending)
+	if err != nil {
+		return actualName, apType, externalId, err
+	}
+
+	// Columns that are supported by the mask type again are detached from the fallback data policy
+	fallbackDeletedDoLocations := originalDeletedDoLocations
+	for location, dos := range doLocations {
+		fallbackDeletedDoLocations[location] = append(fallbackDeletedDoLocations[location], dos...)
+	}
+
+	err = m.exportRaitoMaskUpdateColumns(ctx, accessProvider, fallbackDataPolicyMap, fallbackDoLocations, fallbackDeletedDoLocations, pending)
+	if err != nil {
+		return actualName, apType, externalId, err
+	}
+
+	return actualName, apType, externalId, nil
+}
+
+func (m *BqMaskingService) exportRaitoMaskUpdateColumns(ctx context.Context, accessProvider *importer.AccessProvider, dataPolicyMap map[string]BQMaskingInformation, doLocations map[string][]string, deletedDoLocations map[string][]string, pending *pendingColumnPolicyTagFeedback) error {
 	for location := range dataPolicyMap {
 		dataObjectsToAdd := doLocations[location]
 		dataObjectsToRemove := deletedDoLocations[location]
@@ -258,42 +439,107 @@ func (m *BqMaskingService) exportMasks(ctx context.Context, accessProvider *impo
 			// Update WHO of policy tag and data policy
 			common.Logger.Debug(fmt.Sprintf("Update who for policy tag %q", maskingPolicy.PolicyTag.FullName))
 
-			err = m.datacatalogRepo.UpdateAccess(ctx, &maskingPolicy, &accessProvider.Who, accessProvider.DeletedWho)
+			err := m.datacatalogRepo.UpdateAccess(ctx, &maskingPolicy, &accessProvider.Who, accessProvider.DeletedWho)
 			if err != nil {
-				return actualName, apType, externalId, fmt.Errorf("update datapolicy access on %q: %w", maskingPolicy.PolicyTag.FullName, err)
+				return fmt.Errorf("update datapolicy access on %q: %w", maskingPolicy.PolicyTag.FullName, err)
 			}
 
 			// Update What of policy tag
 			common.Logger.Debug(fmt.Sprintf("Update what for policy tag %q", maskingPolicy.PolicyTag.FullName))
 
-			err = m.datacatalogRepo.UpdateWhatOfDataPolicy(ctx, &maskingPolicy, dataObjectsToAdd, dataObjectsToRemove)
-			if err != nil {
-				return actualName, apType, externalId, fmt.Errorf("update what of data policy %q: %w", maskingPolicy.PolicyTag.FullName, err)
-			}
+			pending.results = append(pending.results, m.datacatalogRepo.AddColumnPolicyTagUpdate(&maskingPolicy, dataObjectsToAdd, dataObjectsToRemove))
 		} else {
-			err = m.datacatalogRepo.DeletePolicyAndTag(ctx, dataPolicyMap[location].DataPolicy.FullName)
+			err := m.datacatalogRepo.DeletePolicyAndTag(ctx, dataPolicyMap[location].DataPolicy.FullName)
 			if err != nil {
-				return actualName, apType, externalId, fmt.Errorf("delete policy and tag %q: %w", dataPolicyMap[location].DataPolicy.FullName, err)
+				return fmt.Errorf("delete policy and tag %q: %w", dataPolicyMap[location].DataPolicy.FullName, err)
 			}
 		}
 	}
 
-	return actualName, apType, externalId, nil
+	return nil
 }
 
-func (m *BqMaskingService) exportRaitoMaskCreateAndUpdateDataPolicies(ctx context.Context, accessProvider *importer.AccessProvider, doLocations map[string][]string, dataPolicyLocations map[string]string) (*string, map[string]BQMaskingInformation, error) {
-	common.Logger.Debug(fmt.Sprintf("Create or update data policies for mask %s", accessProvider.Name))
+// exportRaitoMaskFilterDataTypes removes the columns of which the data type is not supported by the masking type from the columns to mask, and adds them to the deleted columns.
+// These columns are rejected, or returned per location to be masked with the default masking value if the fallback is enabled.
+func (m *BqMaskingService) exportRaitoMaskFilterDataTypes(ctx context.Context, maskingType BQMaskingType, doLocations map[string][]string, deletedDoLocations map[string][]string, pending *pendingColumnPolicyTagFeedback) (map[string][]string, error) {
+	fallbackDoLocations := map[string][]string{}
+
+	dataTypes := maskingType.DataTypes()
+	if len(dataTypes) == 0 {
+		return fallbackDoLocations, nil
+	}
+
+	var columns []string
+	for _, dos := range doLocations {
+		columns = append(columns, dos...)
+	}
+
+	columnDataTypes, err := m.datacatalogRepo.GetColumnDataTypes(ctx, columns)
+	if err != nil {
+		return nil, fmt.Errorf("get data types of columns: %w", err)
+	}
+
+	result := &ColumnPolicyTagResult{}
+
+	for location, dos := range doLocations {
+		supportedDos := make([]string, 0, len(dos))
+
+		for _, do := range dos {
+			// Columns of which the data type is unknown are not rejected
+			dataType, found := columnDataTypes[do]
+			if !found || slices.Contains(dataTypes, dataType) {
+				supportedDos = append(supportedDos, do)
+
+				continue
+			}
+
+			deletedDoLocations[location] = append(deletedDoLocations[location], do)
+
+			if m.defaultValueFallback {
+				fallbackDoLocations[location] = append(fallbackDoLocations[location], do)
+				result.Warnings = append(result.Warnings, fmt.Sprintf("column %q has data type %s, which is not supported by mask type %s, so the default masking value is used", do, dataType, maskingType.String()))
+			} else {
+				result.Errors = append(result.Errors, fmt.Sprintf("column %q has data type %s, which is not supported by mask type %s", do, dataType, maskingType.String()))
+			}
+		}
+
+		if len(supportedDos) > 0 {
+			doLocations[location] = supportedDos
+		} else {
+			delete(doLocations, location)
+		}
+	}
+
+	sort.Strings(result.Errors)
+	sort.Strings(result.Warnings)
+
+	pending.results = append(pending.results, result)
+
+	return fallbackDoLocations, nil
+}
 
-	maskingTypeInt := int32(datapoliciespb.DataMaskingPolicy_ALWAYS_NULL)
+// exportRaitoMaskRemoveOldFallbackPolicies deletes the fallback data policies of locations without columns that require the default masking value.
+func (m *BqMaskingService) exportRaitoMaskRemoveOldFallbackPolicies(ctx context.Context, accessProvider *importer.AccessProvider, fallbackDoLocations map[string][]string, fallbackPolicyLocations map[string]string) error {
+	for location, dataPolicyId := range fallbackPolicyLocations {
+		if _, found := fallbackDoLocations[location]; found {
+			continue
+		}
 
-	if accessProvider.Type != nil {
-		if accessProviderMaskType, found := datapoliciespb.DataMaskingPolicy_PredefinedExpression_value[*accessProvider.Type]; found {
-			maskingTypeInt = accessProviderMaskType
+		common.Logger.Info(fmt.Sprintf("Delete fallback data policy and policy tag %s in location %s", accessProvider.Name, location))
+
+		err := m.datacatalogRepo.DeletePolicyAndTag(ctx, dataPolicyId)
+		if err != nil {
+			return fmt.Errorf("delete fallback data policy %q: %w", dataPolicyId, err)
 		}
+
+		delete(fallbackPolicyLocations, location)
 	}
 
-	maskingType := datapoliciespb.DataMaskingPolicy_PredefinedExpression(maskingTypeInt)
-	apType := ptr.String(maskingType.String())
+	return nil
+}
+
+func (m *BqMaskingService) exportRaitoMaskCreateAndUpdateDataPolicies(ctx context.Context, accessProvider *importer.AccessProvider, maskingType BQMaskingType, doLocations map[string][]string, dataPolicyLocations map[string]string, fallback bool) (map[string]BQMaskingInformation, error) {
+	common.Logger.Debug(fmt.Sprintf("Create or update data policies for mask %s", accessProvider.Name))
 
 	dataPolicyMap := make(map[string]BQMaskingInformation)
 
@@ -302,7 +548,7 @@ func (m *BqMaskingService) exportRaitoMaskCreateAndUpdateDataPolicies(ctx contex
 			// Get MaskingInformation for existing policy
 			maskingInformation, err := m.datacatalogRepo.UpdatePolicyTag(ctx, doLocation, maskingType, accessProvider, dataPolicyId)
 			if err != nil {
-				return nil, nil, fmt.Errorf("update mask %q: %w", dataPolicyId, err)
+				return nil, fmt.Errorf("update mask %q: %w", dataPolicyId, err)
 			}
 
 			dataPolicyMap[doLocation] = *maskingInformation
@@ -310,16 +556,24 @@ func (m *BqMaskingService) exportRaitoMaskCreateAndUpdateDataPolicies(ctx contex
 			// Create new data policy
 			common.Logger.Info(fmt.Sprintf("Create new data policy and policy tag %s in location %s", accessProvider.Name, doLocation))
 
-			maskingInformation, err := m.datacatalogRepo.CreatePolicyTagWithDataPolicy(ctx, doLocation, maskingType, accessProvider)
+			var maskingInformation *BQMaskingInformation
+			var err error
+
+			if fallback {
+				maskingInformation, err = m.datacatalogRepo.CreateFallbackPolicyTagWithDataPolicy(ctx, doLocation, accessProvider)
+			} else {
+				maskingInformation, err = m.datacatalogRepo.CreatePolicyTagWithDataPolicy(ctx, doLocation, maskingType, accessProvider)
+			}
+
 			if err != nil {
-				return nil, nil, fmt.Errorf("data policy creation: %w", err)
+				return nil, fmt.Errorf("data policy creation: %w", err)
 			}
 
 			dataPolicyMap[doLocation] = *maskingInformation
 		}
 	}
 
-	return apType, dataPolicyMap, nil
+	return dataPolicyMap, nil
 }
 
 func (m *BqMaskingService) exportRaitoMaskRemoveOldPolicies(ctx context.Context, accessProvider *importer.AccessProvider, deletedDoLocations map[string][]string, doLocations map[string][]string, dataPolicyLocations map[string]string) error {
@@ -360,6 +614,11 @@ func (m *BqMaskingService) exportRaitoMaskListAllDoLocations(ctx context.Context
 	dataPolicyLocations := map[string]string{}
 
 	for _, dataPolicy := range dataPolicies {
+		// Fallback data policies are handled separately, as they share the location with the data policy of the mask
+		if IsFallbackDataPolicy(dataPolicy) {
+			continue
+		}
+
 		dpNameSplit := strings.Split(dataPolicy, "/")
 		dataPolicyLocations[dpNameSplit[3]] = dataPolicy
 	}
@@ -382,3 +641,30 @@ func (m *BqMaskingService) exportRaitoMaskListAllDoLocations(ctx context.Context
 
 	return dataPolicyLocations, doLocations, deletedDoLocations, nil
 }
+
+// fallbackDataPolicyLocations maps the fallback data policies in the external id of a mask on their location.
+func fallbackDataPolicyLocations(externalId *string) map[string]string {
+	result := map[string]string{}
+
+	if externalId == nil || *externalId == "" {
+		return result
+	}
+
+	for _, dataPolicy := range strings.Split(*externalId, ",") {
+		if IsFallbackDataPolicy(dataPolicy) {
+			result[strings.Split(dataPolicy, "/")[3]] = dataPolicy
+		}
+	}
+
+	return result
+}
+
+func copyLocations(locations map[string][]string) map[string][]string {
+	result := make(map[string][]string, len(locations))
+
+	for location, dos := range locations {
+		result[location] = slices.Clone(dos)
+	}
+
+	return result
+}
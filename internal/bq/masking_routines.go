@@ -0,0 +1,107 @@
+package bigquery
+
+import (
+	"context"
+	"fmt"
+
+	bigquery2 "google.golang.org/api/bigquery/v2"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+)
+
+const dataMaskingGovernanceType = "DATA_MASKING"
+
+// routineReadMask requests the fields of the routines that are needed to detect custom masking routines, so no metadata call per routine is needed.
+const routineReadMask = "routineReference,dataGovernanceType,description,returnType,arguments"
+
+//go:generate go run github.com/vektra/mockery/v2 --name=BigQueryRoutinesService --with-expecter --inpackage
+type BigQueryRoutinesService interface {
+	List(projectId string, datasetId string) *bigquery2.RoutinesListCall
+}
+
+// ListMaskingRoutines returns all user defined functions in the project that are registered as custom masking routine. Excluded datasets are skipped.
+// The routines are listed with a single call per dataset and are cached with the other metadata of the project.
+func (c *Repository) ListMaskingRoutines(ctx context.Context) ([]BQMaskingRoutine, error) {
+	if c.cache != nil {
+		if routines, found := c.cache.maskingRoutines.Get(c.projectId); found {
+			return routines, nil
+		}
+	}
+
+	var datasets []string
+
+	err := c.datasetsClient.List(c.projectId).All(true).Pages(ctx, func(list *bigquery2.DatasetList) error {
+		for _, ds := range list.Datasets {
+			if ds.DatasetReference != nil && !c.excludedDatasets.Contains(ds.DatasetReference.DatasetId) {
+				datasets = append(datasets, ds.DatasetReference.DatasetId)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list datasets: %w", err)
+	}
+
+	result := []BQMaskingRoutine{}
+
+	for _, dataset := range datasets {
+		routines, err := c.listMaskingRoutinesInDataset(ctx, dataset)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, routines...)
+	}
+
+	if c.cache != nil {
+		c.cache.maskingRoutines.Set(c.projectId, result)
+	}
+
+	return result, nil
+}
+
+func (c *Repository) listMaskingRoutinesInDataset(ctx context.Context, dataset string) ([]BQMaskingRoutine, error) {
+	var result []BQMaskingRoutine
+
+	err := c.routinesClient.List(c.projectId, dataset).ReadMask(routineReadMask).Pages(ctx, func(list *bigquery2.ListRoutinesResponse) error {
+		for _, routine := range list.Routines {
+			if maskingRoutine, ok := maskingRoutineFromRoutine(routine); ok {
+				result = append(result, maskingRoutine)
+			}
+		}
+
+		return nil
+	})
+	if common.IsGoogle400Error(err) || common.IsGoogle403Error(err) {
+		common.Logger.Warn(fmt.Sprintf("Unable to list routines of dataset %q: %s", dataset, err.Error()))
+
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("list routines of dataset %q: %w", dataset, err)
+	}
+
+	return result, nil
+}
+
+// maskingRoutineFromRoutine returns the masking routine of a routine that is registered as custom masking routine.
+func maskingRoutineFromRoutine(routine *bigquery2.Routine) (BQMaskingRoutine, bool) {
+	if routine.DataGovernanceType != dataMaskingGovernanceType || routine.RoutineReference == nil {
+		return BQMaskingRoutine{}, false
+	}
+
+	maskingRoutine := BQMaskingRoutine{
+		Project:     routine.RoutineReference.ProjectId,
+		Dataset:     routine.RoutineReference.DatasetId,
+		Routine:     routine.RoutineReference.RoutineId,
+		Description: routine.Description,
+	}
+
+	if routine.ReturnType != nil {
+		maskingRoutine.DataType = routine.ReturnType.TypeKind
+	} else if len(routine.Arguments) == 1 && routine.Arguments[0].DataType != nil {
+		maskingRoutine.DataType = routine.Arguments[0].DataType.TypeKind
+	}
+
+	return maskingRoutine, true
+}
@@ -0,0 +1,63 @@
+package bigquery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bigquery2 "google.golang.org/api/bigquery/v2"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+)
+
+func TestMaskingRoutineFromRoutine(t *testing.T) {
+	reference := &bigquery2.RoutineReference{ProjectId: "project1", DatasetId: "masking", RoutineId: "mask_iban"}
+
+	t.Run("masking routine", func(t *testing.T) {
+		routine, ok := maskingRoutineFromRoutine(&bigquery2.Routine{
+			RoutineReference:   reference,
+			DataGovernanceType: dataMaskingGovernanceType,
+			Description:        "Show the country code of an IBAN",
+			ReturnType:         &bigquery2.StandardSqlDataType{TypeKind: "STRING"},
+		})
+
+		assert.True(t, ok)
+		assert.Equal(t, BQMaskingRoutine{Project: "project1", Dataset: "masking", Routine: "mask_iban", Description: "Show the country code of an IBAN", DataType: "STRING"}, routine)
+	})
+
+	t.Run("data type of the argument", func(t *testing.T) {
+		routine, ok := maskingRoutineFromRoutine(&bigquery2.Routine{
+			RoutineReference:   reference,
+			DataGovernanceType: dataMaskingGovernanceType,
+			Arguments:          []*bigquery2.Argument{{DataType: &bigquery2.StandardSqlDataType{TypeKind: "INT64"}}},
+		})
+
+		assert.True(t, ok)
+		assert.Equal(t, "INT64", routine.DataType)
+	})
+
+	t.Run("other routine", func(t *testing.T) {
+		_, ok := maskingRoutineFromRoutine(&bigquery2.Routine{RoutineReference: reference})
+
+		assert.False(t, ok)
+	})
+}
+
+func TestRepository_ListMaskingRoutines_Cached(t *testing.T) {
+	configMap := &config.ConfigMap{Parameters: map[string]string{
+		common.GcpProjectId: "routines-cache-project",
+	}}
+
+	routines := []BQMaskingRoutine{{Project: "routines-cache-project", Dataset: "masking", Routine: "mask_iban"}}
+
+	// The datasets and routines clients are not set, so they may not be called
+	repo := &Repository{projectId: "routines-cache-project", cache: getRepositoryCache(configMap)}
+	repo.cache.maskingRoutines.Set("routines-cache-project", routines)
+
+	result, err := repo.ListMaskingRoutines(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, routines, result)
+}
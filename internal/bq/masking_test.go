@@ -2,14 +2,17 @@ package bigquery
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"cloud.google.com/go/bigquery/datapolicies/apiv1/datapoliciespb"
 	"github.com/aws/smithy-go/ptr"
+	"github.com/raito-io/cli/base/access_provider"
 	"github.com/raito-io/cli/base/access_provider/sync_from_target"
 	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
 	"github.com/raito-io/cli/base/access_provider/types"
 	"github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/tag"
 	"github.com/raito-io/cli/base/util/config"
 	"github.com/raito-io/cli/base/wrappers/mocks"
 	"github.com/raito-io/golang-set/set"
@@ -25,6 +28,8 @@ func TestBqMaskingService_ImportMasks(t *testing.T) {
 		setup          func(repository *mockMaskingDataCatalogRepository)
 		projectId      string
 		maskingEnabled bool
+		deleteOrphaned bool
+		setupTags      func(tagRepository *mockCatalogTagRepository)
 	}
 	type args struct {
 		ctx         context.Context
@@ -91,7 +96,7 @@ func TestBqMaskingService_ImportMasks(t *testing.T) {
 						},
 					}, nil)
 
-					repository.EXPECT().GetFineGrainedReaderMembers(mock.Anything, "maskTag1").Return([]string{"user:user1@raito.io", "group:sales@raito.io"}, nil)
+					repository.EXPECT().GetFineGrainedReaderMembers(mock.Anything, "maskTag1").Return(&FineGrainedReaders{Direct: []string{"user:user1@raito.io", "group:sales@raito.io"}, Inherited: []string{"group:finance@raito.io"}}, nil)
 				},
 				projectId:      "test-project",
 				maskingEnabled: true,
@@ -114,6 +119,7 @@ func TestBqMaskingService_ImportMasks(t *testing.T) {
 						Groups: []string{"sales@raito.io"},
 					},
 					ActualName: "maskNameTag1",
+					Tags:       []*tag.Tag{{Key: InheritedFineGrainedReadersTagKey, Value: "group:finance@raito.io", Source: "gcp"}},
 					What: []sync_from_target.WhatItem{
 						{
 							DataObject: &data_source.DataObjectReference{
@@ -134,11 +140,53 @@ func TestBqMaskingService_ImportMasks(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Import policy tag without data policy as column access",
+			fields: fields{
+				setup: func(repository *mockMaskingDataCatalogRepository) {
+					repository.EXPECT().ListDataPolicies(mock.Anything).Return(map[string]BQMaskingInformation{}, nil)
+					repository.EXPECT().GetPolicyTag(mock.Anything, "accessTag1").Return(&BQPolicyTag{FullName: "accessTag1", Name: "accessNameTag1"}, nil)
+					repository.EXPECT().GetFineGrainedReaderMembers(mock.Anything, "accessTag1").Return(&FineGrainedReaders{Direct: []string{"user:user1@raito.io", "serviceAccount:sa@project1.iam.gserviceaccount.com", "group:sales@raito.io"}}, nil)
+				},
+				projectId:      "test-project",
+				maskingEnabled: true,
+			},
+			args: args{
+				ctx:         context.Background(),
+				locations:   set.NewSet("europe-west1"),
+				raitoMasks:  set.NewSet("existing-mask"),
+				maskingTags: map[string][]string{"accessTag1": {"column1"}},
+			},
+			wantErr: require.NoError,
+			wantFeedback: []sync_from_target.AccessProvider{
+				{
+					ExternalId: "accessTag1",
+					Name:       "accessNameTag1",
+					Type:       ptr.String(access_provider.AclSet),
+					Action:     types.Grant,
+					Who: &sync_from_target.WhoItem{
+						Users:  []string{"user1@raito.io", "sa@project1.iam.gserviceaccount.com"},
+						Groups: []string{"sales@raito.io"},
+					},
+					ActualName: "accessNameTag1",
+					What: []sync_from_target.WhatItem{
+						{
+							DataObject: &data_source.DataObjectReference{
+								FullName: "column1",
+								Type:     "column",
+							},
+							Permissions: []string{"roles/datacatalog.categoryFineGrainedReader"},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Ignore unknown policy tag",
 			fields: fields{
 				setup: func(repository *mockMaskingDataCatalogRepository) {
 					repository.EXPECT().ListDataPolicies(mock.Anything).Return(map[string]BQMaskingInformation{}, nil)
+					repository.EXPECT().GetPolicyTag(mock.Anything, "maskTag1").Return(nil, nil)
 				},
 				projectId:      "test-project",
 				maskingEnabled: true,
@@ -179,12 +227,217 @@ func TestBqMaskingService_ImportMasks(t *testing.T) {
 			wantErr:      require.NoError,
 			wantFeedback: []sync_from_target.AccessProvider{},
 		},
+		{
+			name: "Ignore raito managed policy tags",
+			fields: fields{
+				setup: func(repository *mockMaskingDataCatalogRepository) {
+					repository.EXPECT().ListDataPolicies(mock.Anything).Return(map[string]BQMaskingInformation{
+						"maskTag1": {
+							DataPolicy: BQDataPolicy{FullName: "DataPolicy1", PolicyType: datapoliciespb.DataMaskingPolicy_SHA256},
+							PolicyTag:  BQPolicyTag{FullName: "maskTag1", Name: "maskNameTag1", RaitoManaged: true},
+						},
+						"maskTag2": {
+							DataPolicy: BQDataPolicy{FullName: "DataPolicy2", PolicyType: datapoliciespb.DataMaskingPolicy_SHA256},
+							PolicyTag:  BQPolicyTag{FullName: "maskTag2", Name: "maskNameTag2", RaitoManaged: true},
+						},
+					}, nil)
+					repository.EXPECT().GetPolicyTag(mock.Anything, "accessTag1").Return(&BQPolicyTag{FullName: "accessTag1", Name: "accessNameTag1", RaitoManaged: true}, nil)
+				},
+				projectId:      "test-project",
+				maskingEnabled: true,
+			},
+			args: args{
+				ctx:         context.Background(),
+				locations:   set.NewSet("europe-west1"),
+				raitoMasks:  set.NewSet[string](),
+				maskingTags: map[string][]string{"maskTag1": {"column1"}, "accessTag1": {"column2"}},
+			},
+			wantErr:      require.NoError,
+			wantFeedback: []sync_from_target.AccessProvider{},
+		},
+		{
+			name: "Import orphaned mask without what items",
+			fields: fields{
+				setup: func(repository *mockMaskingDataCatalogRepository) {
+					repository.EXPECT().ListDataPolicies(mock.Anything).Return(map[string]BQMaskingInformation{
+						"maskTag1": {
+							DataPolicy: BQDataPolicy{FullName: "DataPolicy1", PolicyType: datapoliciespb.DataMaskingPolicy_SHA256},
+							PolicyTag:  BQPolicyTag{FullName: "maskTag1", Name: "maskNameTag1"},
+						},
+						"maskTag2": {
+							DataPolicy: BQDataPolicy{FullName: "existing-mask", PolicyType: datapoliciespb.DataMaskingPolicy_SHA256},
+							PolicyTag:  BQPolicyTag{FullName: "maskTag2", Name: "maskNameTag2"},
+						},
+					}, nil)
+
+					repository.EXPECT().GetFineGrainedReaderMembers(mock.Anything, "maskTag1").Return(&FineGrainedReaders{Direct: []string{"user:user1@raito.io"}}, nil)
+				},
+				projectId:      "test-project",
+				maskingEnabled: true,
+			},
+			args: args{
+				ctx:         context.Background(),
+				locations:   set.NewSet[string](),
+				raitoMasks:  set.NewSet("existing-mask"),
+				maskingTags: nil,
+			},
+			wantErr: require.NoError,
+			wantFeedback: []sync_from_target.AccessProvider{
+				{
+					ExternalId: "DataPolicy1",
+					Name:       "maskNameTag1",
+					Type:       ptr.String(datapoliciespb.DataMaskingPolicy_SHA256.String()),
+					Action:     types.Mask,
+					Who: &sync_from_target.WhoItem{
+						Users: []string{"user1@raito.io"},
+					},
+					ActualName: "maskNameTag1",
+					What:       []sync_from_target.WhatItem{},
+				},
+			},
+		},
+		{
+			name: "Delete orphaned mask",
+			fields: fields{
+				setup: func(repository *mockMaskingDataCatalogRepository) {
+					repository.EXPECT().ListDataPolicies(mock.Anything).Return(map[string]BQMaskingInformation{
+						"maskTag1": {
+							DataPolicy: BQDataPolicy{FullName: "DataPolicy1", PolicyType: datapoliciespb.DataMaskingPolicy_SHA256},
+							PolicyTag:  BQPolicyTag{FullName: "maskTag1", Name: "maskNameTag1"},
+						},
+						"maskTag2": {
+							DataPolicy: BQDataPolicy{FullName: "DataPolicy2", PolicyType: datapoliciespb.DataMaskingPolicy_SHA256},
+							PolicyTag:  BQPolicyTag{FullName: "maskTag2", Name: "maskNameTag2"},
+						},
+						"maskTag3": {
+							DataPolicy: BQDataPolicy{FullName: "DataPolicy3", PolicyType: datapoliciespb.DataMaskingPolicy_SHA256},
+							PolicyTag:  BQPolicyTag{FullName: "maskTag3", Name: "maskNameTag3", RaitoManaged: true},
+						},
+					}, nil)
+
+					repository.EXPECT().GetFineGrainedReaderMembers(mock.Anything, "maskTag2").Return(&FineGrainedReaders{}, nil)
+					repository.EXPECT().DeletePolicyAndTag(mock.Anything, "DataPolicy1").Return(nil).Once()
+				},
+				projectId:      "test-project",
+				maskingEnabled: true,
+				deleteOrphaned: true,
+				setupTags: func(tagRepository *mockCatalogTagRepository) {
+					tagRepository.EXPECT().PolicyTagInUse(mock.Anything, &BQPolicyTag{FullName: "maskTag1", Name: "maskNameTag1"}).Return(false, nil).Once()
+				},
+			},
+			args: args{
+				ctx:         context.Background(),
+				locations:   set.NewSet("europe-west1"),
+				raitoMasks:  set.NewSet[string](),
+				maskingTags: map[string][]string{"maskTag2": {"column1"}},
+			},
+			wantErr: require.NoError,
+			wantFeedback: []sync_from_target.AccessProvider{
+				{
+					ExternalId: "DataPolicy2",
+					Name:       "maskNameTag2",
+					Type:       ptr.String(datapoliciespb.DataMaskingPolicy_SHA256.String()),
+					Action:     types.Mask,
+					Who:        &sync_from_target.WhoItem{},
+					ActualName: "maskNameTag2",
+					What: []sync_from_target.WhatItem{
+						{
+							DataObject:  &data_source.DataObjectReference{FullName: "column1", Type: "column"},
+							Permissions: []string{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Import orphaned mask instead of deleting it if its policy tag is attached to a column that is not synced",
+			fields: fields{
+				setup: func(repository *mockMaskingDataCatalogRepository) {
+					repository.EXPECT().ListDataPolicies(mock.Anything).Return(map[string]BQMaskingInformation{
+						"maskTag1": {
+							DataPolicy: BQDataPolicy{FullName: "DataPolicy1", PolicyType: datapoliciespb.DataMaskingPolicy_SHA256},
+							PolicyTag:  BQPolicyTag{FullName: "maskTag1", Name: "maskNameTag1"},
+						},
+					}, nil)
+
+					repository.EXPECT().GetFineGrainedReaderMembers(mock.Anything, "maskTag1").Return(&FineGrainedReaders{}, nil)
+				},
+				projectId:      "test-project",
+				maskingEnabled: true,
+				deleteOrphaned: true,
+				setupTags: func(tagRepository *mockCatalogTagRepository) {
+					tagRepository.EXPECT().PolicyTagInUse(mock.Anything, &BQPolicyTag{FullName: "maskTag1", Name: "maskNameTag1"}).Return(true, nil).Once()
+				},
+			},
+			args: args{
+				ctx:         context.Background(),
+				locations:   set.NewSet("europe-west1"),
+				raitoMasks:  set.NewSet[string](),
+				maskingTags: nil,
+			},
+			wantErr: require.NoError,
+			wantFeedback: []sync_from_target.AccessProvider{
+				{
+					ExternalId: "DataPolicy1",
+					Name:       "maskNameTag1",
+					Type:       ptr.String(datapoliciespb.DataMaskingPolicy_SHA256.String()),
+					Action:     types.Mask,
+					Who:        &sync_from_target.WhoItem{},
+					ActualName: "maskNameTag1",
+					What:       []sync_from_target.WhatItem{},
+				},
+			},
+		},
+		{
+			name: "Import orphaned mask instead of deleting it if the usage of its policy tag cannot be verified",
+			fields: fields{
+				setup: func(repository *mockMaskingDataCatalogRepository) {
+					repository.EXPECT().ListDataPolicies(mock.Anything).Return(map[string]BQMaskingInformation{
+						"maskTag1": {
+							DataPolicy: BQDataPolicy{FullName: "DataPolicy1", PolicyType: datapoliciespb.DataMaskingPolicy_SHA256},
+							PolicyTag:  BQPolicyTag{FullName: "maskTag1", Name: "maskNameTag1"},
+						},
+					}, nil)
+
+					repository.EXPECT().GetFineGrainedReaderMembers(mock.Anything, "maskTag1").Return(&FineGrainedReaders{}, nil)
+				},
+				projectId:      "test-project",
+				maskingEnabled: true,
+				deleteOrphaned: true,
+				setupTags: func(tagRepository *mockCatalogTagRepository) {
+					tagRepository.EXPECT().PolicyTagInUse(mock.Anything, &BQPolicyTag{FullName: "maskTag1", Name: "maskNameTag1"}).Return(false, errors.New("permission denied")).Once()
+				},
+			},
+			args: args{
+				ctx:         context.Background(),
+				locations:   set.NewSet("europe-west1"),
+				raitoMasks:  set.NewSet[string](),
+				maskingTags: nil,
+			},
+			wantErr: require.NoError,
+			wantFeedback: []sync_from_target.AccessProvider{
+				{
+					ExternalId: "DataPolicy1",
+					Name:       "maskNameTag1",
+					Type:       ptr.String(datapoliciespb.DataMaskingPolicy_SHA256.String()),
+					Action:     types.Mask,
+					Who:        &sync_from_target.WhoItem{},
+					ActualName: "maskNameTag1",
+					What:       []sync_from_target.WhatItem{},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			maskingService, repo := createMaskingService(t, tt.fields.projectId, tt.fields.maskingEnabled)
+			maskingService.deleteOrphaned = tt.fields.deleteOrphaned
 			tt.fields.setup(repo)
 
+			if tt.fields.setupTags != nil {
+				tt.fields.setupTags(maskingService.catalogTagRepo.(*mockCatalogTagRepository))
+			}
+
 			apHandler := mocks.NewSimpleAccessProviderHandler(t, 1)
 
 			err := maskingService.ImportMasks(tt.args.ctx, apHandler, tt.args.locations, tt.args.maskingTags, tt.args.raitoMasks)
@@ -270,6 +523,17 @@ func TestBqMaskingService_ExportMasks(t *testing.T) {
 		},
 	}
 
+	fallbackMaskInfo := BQMaskingInformation{
+		DataPolicy: BQDataPolicy{
+			FullName:   "projects/test-project/locations/europe-west2/dataPolicies/DataPolicy3_fallback",
+			PolicyType: datapoliciespb.DataMaskingPolicy_DEFAULT_MASKING_VALUE,
+		},
+		PolicyTag: BQPolicyTag{
+			FullName: "maskTag3",
+			Name:     "maskNameTag3",
+		},
+	}
+
 	maskInfo2 := BQMaskingInformation{
 		DataPolicy: BQDataPolicy{
 			FullName:   "DataPolicy2",
@@ -282,9 +546,10 @@ func TestBqMaskingService_ExportMasks(t *testing.T) {
 	}
 
 	type fields struct {
-		setup          func(repository *mockMaskingDataCatalogRepository)
-		projectId      string
-		maskingEnabled bool
+		setup                func(repository *mockMaskingDataCatalogRepository)
+		projectId            string
+		maskingEnabled       bool
+		defaultValueFallback bool
 	}
 	type args struct {
 		ctx            context.Context
@@ -325,13 +590,55 @@ func TestBqMaskingService_ExportMasks(t *testing.T) {
 			fields: fields{
 				setup: func(repository *mockMaskingDataCatalogRepository) {
 					repository.EXPECT().GetLocationsForDataObjects(mock.Anything, &newMask).Return(map[string]string{"column1": "europe-west1", "column2": "europe-west2"}, map[string]string{"column3": "europe-west1"}, nil)
-					repository.EXPECT().CreatePolicyTagWithDataPolicy(mock.Anything, "europe-west1", datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS, &newMask).Return(&maskInfo, nil)
-					repository.EXPECT().CreatePolicyTagWithDataPolicy(mock.Anything, "europe-west2", datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS, &newMask).Return(&maskInfo2, nil)
+					repository.EXPECT().GetColumnDataTypes(mock.Anything, mock.Anything).Return(map[string]string{"column1": "STRING", "column2": "STRING"}, nil)
+					repository.EXPECT().CreatePolicyTagWithDataPolicy(mock.Anything, "europe-west1", BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS}, &newMask).Return(&maskInfo, nil)
+					repository.EXPECT().CreatePolicyTagWithDataPolicy(mock.Anything, "europe-west2", BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS}, &newMask).Return(&maskInfo2, nil)
+					repository.EXPECT().UpdateAccess(mock.Anything, &maskInfo, &newMask.Who, newMask.DeletedWho).Return(nil)
+					repository.EXPECT().UpdateAccess(mock.Anything, &maskInfo2, &newMask.Who, newMask.DeletedWho).Return(nil)
+					repository.EXPECT().AddColumnPolicyTagUpdate(&maskInfo2, []string{"column2"}, []string(nil)).Return(&ColumnPolicyTagResult{})
+
+					repository.EXPECT().AddColumnPolicyTagUpdate(&maskInfo, []string{"column1"}, []string{"column3"}).Return(&ColumnPolicyTagResult{})
+				},
+				projectId:      "test-project",
+				maskingEnabled: true,
+			},
+			args: args{
+				ctx:            context.Background(),
+				accessProvider: &newMask,
+			},
+			wantErr: require.NoError,
+			wantFeedback: []importer.AccessProviderSyncFeedback{
+				{
+					AccessProvider: newMask.Id,
+					ActualName:     "maskNameTag1,maskNameTag2",
+					ExternalId:     ptr.String("DataPolicy1,DataPolicy2"),
+					Type:           ptr.String(datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS.String()),
+					State: &importer.AccessProviderFeedbackState{
+						Who: importer.AccessProviderWhoFeedbackState{
+							Users:  []string{"user1@raito.io"},
+							Groups: []string{"sales@raito.io"},
+						},
+					},
+				},
+			},
+			want: []string{
+				"DataPolicy1",
+				"DataPolicy2",
+			},
+		},
+		{
+			name: "Report policy tag conflicts per column",
+			fields: fields{
+				setup: func(repository *mockMaskingDataCatalogRepository) {
+					repository.EXPECT().GetLocationsForDataObjects(mock.Anything, &newMask).Return(map[string]string{"column1": "europe-west1", "column2": "europe-west2"}, map[string]string{"column3": "europe-west1"}, nil)
+					repository.EXPECT().GetColumnDataTypes(mock.Anything, mock.Anything).Return(map[string]string{"column1": "STRING", "column2": "STRING"}, nil)
+					repository.EXPECT().CreatePolicyTagWithDataPolicy(mock.Anything, "europe-west1", BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS}, &newMask).Return(&maskInfo, nil)
+					repository.EXPECT().CreatePolicyTagWithDataPolicy(mock.Anything, "europe-west2", BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS}, &newMask).Return(&maskInfo2, nil)
 					repository.EXPECT().UpdateAccess(mock.Anything, &maskInfo, &newMask.Who, newMask.DeletedWho).Return(nil)
 					repository.EXPECT().UpdateAccess(mock.Anything, &maskInfo2, &newMask.Who, newMask.DeletedWho).Return(nil)
-					repository.EXPECT().UpdateWhatOfDataPolicy(mock.Anything, &maskInfo2, []string{"column2"}, []string(nil)).Return(nil)
+					repository.EXPECT().AddColumnPolicyTagUpdate(&maskInfo2, []string{"column2"}, []string(nil)).Return(&ColumnPolicyTagResult{Warnings: []string{"column2 keeps its policy tag"}})
 
-					repository.EXPECT().UpdateWhatOfDataPolicy(mock.Anything, &maskInfo, []string{"column1"}, []string{"column3"}).Return(nil)
+					repository.EXPECT().AddColumnPolicyTagUpdate(&maskInfo, []string{"column1"}, []string{"column3"}).Return(&ColumnPolicyTagResult{Errors: []string{"column1 already has a policy tag"}})
 				},
 				projectId:      "test-project",
 				maskingEnabled: true,
@@ -347,6 +654,8 @@ func TestBqMaskingService_ExportMasks(t *testing.T) {
 					ActualName:     "maskNameTag1,maskNameTag2",
 					ExternalId:     ptr.String("DataPolicy1,DataPolicy2"),
 					Type:           ptr.String(datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS.String()),
+					Errors:         []string{"column1 already has a policy tag"},
+					Warnings:       []string{"column2 keeps its policy tag"},
 					State: &importer.AccessProviderFeedbackState{
 						Who: importer.AccessProviderWhoFeedbackState{
 							Users:  []string{"user1@raito.io"},
@@ -360,6 +669,85 @@ func TestBqMaskingService_ExportMasks(t *testing.T) {
 				"DataPolicy2",
 			},
 		},
+		{
+			name: "Reject columns with unsupported data type",
+			fields: fields{
+				setup: func(repository *mockMaskingDataCatalogRepository) {
+					repository.EXPECT().GetLocationsForDataObjects(mock.Anything, &newMask).Return(map[string]string{"column1": "europe-west1", "column2": "europe-west2"}, map[string]string{"column3": "europe-west1"}, nil)
+					repository.EXPECT().GetColumnDataTypes(mock.Anything, mock.Anything).Return(map[string]string{"column1": "STRING", "column2": "INTEGER"}, nil)
+					repository.EXPECT().CreatePolicyTagWithDataPolicy(mock.Anything, "europe-west1", BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS}, &newMask).Return(&maskInfo, nil)
+					repository.EXPECT().UpdateAccess(mock.Anything, &maskInfo, &newMask.Who, newMask.DeletedWho).Return(nil)
+					repository.EXPECT().AddColumnPolicyTagUpdate(&maskInfo, []string{"column1"}, []string{"column3"}).Return(&ColumnPolicyTagResult{})
+				},
+				projectId:      "test-project",
+				maskingEnabled: true,
+			},
+			args: args{
+				ctx:            context.Background(),
+				accessProvider: &newMask,
+			},
+			wantErr: require.NoError,
+			wantFeedback: []importer.AccessProviderSyncFeedback{
+				{
+					AccessProvider: newMask.Id,
+					ActualName:     "maskNameTag1",
+					ExternalId:     ptr.String("DataPolicy1"),
+					Type:           ptr.String(datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS.String()),
+					Errors:         []string{`column "column2" has data type INTEGER, which is not supported by mask type FIRST_FOUR_CHARACTERS`},
+					State: &importer.AccessProviderFeedbackState{
+						Who: importer.AccessProviderWhoFeedbackState{
+							Users:  []string{"user1@raito.io"},
+							Groups: []string{"sales@raito.io"},
+						},
+					},
+				},
+			},
+			want: []string{
+				"DataPolicy1",
+			},
+		},
+		{
+			name: "Mask columns with unsupported data type with default masking value",
+			fields: fields{
+				setup: func(repository *mockMaskingDataCatalogRepository) {
+					repository.EXPECT().GetLocationsForDataObjects(mock.Anything, &newMask).Return(map[string]string{"column1": "europe-west1", "column2": "europe-west2"}, map[string]string{"column3": "europe-west1"}, nil)
+					repository.EXPECT().GetColumnDataTypes(mock.Anything, mock.Anything).Return(map[string]string{"column1": "STRING", "column2": "INTEGER"}, nil)
+					repository.EXPECT().CreatePolicyTagWithDataPolicy(mock.Anything, "europe-west1", BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS}, &newMask).Return(&maskInfo, nil)
+					repository.EXPECT().CreateFallbackPolicyTagWithDataPolicy(mock.Anything, "europe-west2", &newMask).Return(&fallbackMaskInfo, nil)
+					repository.EXPECT().UpdateAccess(mock.Anything, &maskInfo, &newMask.Who, newMask.DeletedWho).Return(nil)
+					repository.EXPECT().UpdateAccess(mock.Anything, &fallbackMaskInfo, &newMask.Who, newMask.DeletedWho).Return(nil)
+					repository.EXPECT().AddColumnPolicyTagUpdate(&maskInfo, []string{"column1"}, []string{"column3"}).Return(&ColumnPolicyTagResult{})
+					repository.EXPECT().AddColumnPolicyTagUpdate(&fallbackMaskInfo, []string{"column2"}, []string(nil)).Return(&ColumnPolicyTagResult{})
+				},
+				projectId:            "test-project",
+				maskingEnabled:       true,
+				defaultValueFallback: true,
+			},
+			args: args{
+				ctx:            context.Background(),
+				accessProvider: &newMask,
+			},
+			wantErr: require.NoError,
+			wantFeedback: []importer.AccessProviderSyncFeedback{
+				{
+					AccessProvider: newMask.Id,
+					ActualName:     "maskNameTag1,maskNameTag3",
+					ExternalId:     ptr.String("DataPolicy1,projects/test-project/locations/europe-west2/dataPolicies/DataPolicy3_fallback"),
+					Type:           ptr.String(datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS.String()),
+					Warnings:       []string{`column "column2" has data type INTEGER, which is not supported by mask type FIRST_FOUR_CHARACTERS, so the default masking value is used`},
+					State: &importer.AccessProviderFeedbackState{
+						Who: importer.AccessProviderWhoFeedbackState{
+							Users:  []string{"user1@raito.io"},
+							Groups: []string{"sales@raito.io"},
+						},
+					},
+				},
+			},
+			want: []string{
+				"DataPolicy1",
+				"projects/test-project/locations/europe-west2/dataPolicies/DataPolicy3_fallback",
+			},
+		},
 		{
 			name: "Delete mask",
 			fields: fields{
@@ -398,6 +786,7 @@ func TestBqMaskingService_ExportMasks(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			maskingService, repo := createMaskingService(t, tt.fields.projectId, tt.fields.maskingEnabled)
+			maskingService.defaultValueFallback = tt.fields.defaultValueFallback
 			tt.fields.setup(repo)
 
 			feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
@@ -409,6 +798,11 @@ func TestBqMaskingService_ExportMasks(t *testing.T) {
 				return
 			}
 
+			repo.EXPECT().ApplyColumnPolicyTagUpdates(mock.Anything).Return().Maybe()
+
+			err = maskingService.ApplyColumnPolicyTags(tt.args.ctx)
+			require.NoError(t, err)
+
 			assert.ElementsMatch(t, result, tt.want)
 			assert.ElementsMatch(t, feedbackHandler.AccessProviderFeedback, tt.wantFeedback)
 		})
@@ -419,8 +813,96 @@ func createMaskingService(t *testing.T, projectId string, maskingEnabled bool) (
 	t.Helper()
 	repo := newMockMaskingDataCatalogRepository(t)
 
-	service := NewBqMaskingService(repo, &config.ConfigMap{Parameters: map[string]string{common.GcpProjectId: projectId}})
+	service := NewBqMaskingService(repo, newMockCatalogTagRepository(t), &config.ConfigMap{Parameters: map[string]string{common.GcpProjectId: projectId}})
 	service.maskingEnabled = maskingEnabled
 
 	return service, repo
 }
+
+func TestParseMaskingType(t *testing.T) {
+	tests := []struct {
+		name     string
+		maskType *string
+		want     BQMaskingType
+		wantName string
+	}{
+		{
+			name:     "No type",
+			want:     BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_ALWAYS_NULL},
+			wantName: "ALWAYS_NULL",
+		},
+		{
+			name:     "Predefined expression",
+			maskType: ptr.String("SHA256"),
+			want:     BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_SHA256},
+			wantName: "SHA256",
+		},
+		{
+			name:     "Custom masking routine",
+			maskType: ptr.String("routine:project1.masking.mask_iban"),
+			want:     BQMaskingType{Routine: "projects/project1/datasets/masking/routines/mask_iban"},
+			wantName: "routine:project1.masking.mask_iban",
+		},
+		{
+			name:     "Custom masking routine in domain-scoped project",
+			maskType: ptr.String("routine:example.com:project1.masking.mask_iban"),
+			want:     BQMaskingType{Routine: "projects/example.com:project1/datasets/masking/routines/mask_iban"},
+			wantName: "routine:example.com:project1.masking.mask_iban",
+		},
+		{
+			name:     "Routine without project",
+			maskType: ptr.String("routine:masking.mask_iban"),
+			want:     BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_ALWAYS_NULL},
+			wantName: "ALWAYS_NULL",
+		},
+		{
+			name:     "Invalid routine",
+			maskType: ptr.String("routine:mask_iban"),
+			want:     BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_ALWAYS_NULL},
+			wantName: "ALWAYS_NULL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseMaskingType(tt.maskType)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantName, got.String())
+		})
+	}
+}
+
+func TestNewDataSourceMetaData_MaskingRoutines(t *testing.T) {
+	routineRepo := newMockMaskingRoutineRepository(t)
+	routineRepo.EXPECT().ListMaskingRoutines(mock.Anything).Return([]BQMaskingRoutine{
+		{Project: "project1", Dataset: "masking", Routine: "mask_iban", Description: "Show the country code of an IBAN", DataType: "STRING"},
+	}, nil)
+
+	metaData, err := NewDataSourceMetaData(context.Background(), &config.ConfigMap{Parameters: map[string]string{common.BqCatalogEnabled: "true"}}, routineRepo)
+	require.NoError(t, err)
+
+	assert.Contains(t, metaData.MaskingMetadata.MaskTypes, &data_source.MaskingType{
+		DisplayName: "mask_iban",
+		ExternalId:  "routine:project1.masking.mask_iban",
+		Description: "Show the country code of an IBAN",
+		DataTypes:   []string{"STRING"},
+	})
+}
+
+func TestNewDataSourceMetaData_MaskingRoutinesError(t *testing.T) {
+	routineRepo := newMockMaskingRoutineRepository(t)
+	routineRepo.EXPECT().ListMaskingRoutines(mock.Anything).Return(nil, errors.New("boom"))
+
+	metaData, err := NewDataSourceMetaData(context.Background(), &config.ConfigMap{Parameters: map[string]string{common.BqCatalogEnabled: "true"}}, routineRepo)
+	require.NoError(t, err)
+
+	assert.Equal(t, PredefinedMaskTypes(), metaData.MaskingMetadata.MaskTypes)
+}
+
+func TestFallbackDataPolicyLocations(t *testing.T) {
+	assert.Empty(t, fallbackDataPolicyLocations(nil))
+	assert.Equal(t, map[string]string{
+		"europe-west1": "projects/project1/locations/europe-west1/dataPolicies/abc_fallback",
+	}, fallbackDataPolicyLocations(ptr.String("projects/project1/locations/europe-west1/dataPolicies/def,projects/project1/locations/europe-west1/dataPolicies/abc_fallback")))
+}
@@ -0,0 +1,194 @@
+package bigquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+// UpdateMetadata writes back the description and labels of a project, dataset, table or column.
+// All updates on datasets and tables are guarded by their etag, so concurrent modifications are not overwritten.
+func (c *Repository) UpdateMetadata(ctx context.Context, update *org.MetadataUpdate) error {
+	entityIdParts := strings.Split(update.FullName, ".")
+
+	if entityIdParts[0] != c.projectId {
+		return fmt.Errorf("data object %q is not part of project %q", update.FullName, c.projectId)
+	}
+
+	// The updated data object is cached as child of its parent
+	c.InvalidateDataObjects(strings.Join(entityIdParts[:len(entityIdParts)-1], "."))
+
+	switch len(entityIdParts) {
+	case 1:
+		if update.Description != nil {
+			return errors.New("projects do not support descriptions")
+		}
+
+		if len(update.Labels) == 0 {
+			return nil
+		}
+
+		return c.projectClient.UpdateProjectLabels(ctx, c.projectId, update.Labels)
+	case 2:
+		return c.updateDatasetMetadata(ctx, entityIdParts[1], update)
+	case 3:
+		return c.updateTableMetadata(ctx, entityIdParts[1], entityIdParts[2], update)
+	case 4:
+		return c.updateColumnMetadata(ctx, entityIdParts[1], entityIdParts[2], entityIdParts[3], update)
+	default:
+		return fmt.Errorf("unknown entity type for %s (%s)", update.FullName, update.Type)
+	}
+}
+
+func (c *Repository) updateDatasetMetadata(ctx context.Context, datasetId string, update *org.MetadataUpdate) error {
+	ds := c.client.Dataset(datasetId)
+
+	meta, err := ds.Metadata(ctx)
+	if err != nil {
+		return fmt.Errorf("get metadata of dataset %q: %w", datasetId, err)
+	}
+
+	labels, changed, err := org.ApplyLabelUpdates(meta.Labels, update.Labels)
+	if err != nil {
+		return err
+	}
+
+	var toUpdate bigquery.DatasetMetadataToUpdate
+
+	if update.Description != nil && *update.Description != meta.Description {
+		toUpdate.Description = *update.Description
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	setLabelUpdates(&toUpdate, meta.Labels, labels)
+
+	_, err = ds.Update(ctx, toUpdate, meta.ETag)
+	if err != nil {
+		return fmt.Errorf("update metadata of dataset %q: %w", datasetId, err)
+	}
+
+	return nil
+}
+
+func (c *Repository) updateTableMetadata(ctx context.Context, datasetId string, tableId string, update *org.MetadataUpdate) error {
+	table := c.client.Dataset(datasetId).Table(tableId)
+
+	meta, err := table.Metadata(ctx)
+	if err != nil {
+		return fmt.Errorf("get metadata of table %q: %w", tableId, err)
+	}
+
+	labels, changed, err := org.ApplyLabelUpdates(meta.Labels, update.Labels)
+	if err != nil {
+		return err
+	}
+
+	var toUpdate bigquery.TableMetadataToUpdate
+
+	if update.Description != nil && *update.Description != meta.Description {
+		toUpdate.Description = *update.Description
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	setLabelUpdates(&toUpdate, meta.Labels, labels)
+
+	_, err = table.Update(ctx, toUpdate, meta.ETag)
+	if err != nil {
+		return fmt.Errorf("update metadata of table %q: %w", tableId, err)
+	}
+
+	return nil
+}
+
+func (c *Repository) updateColumnMetadata(ctx context.Context, datasetId string, tableId string, column string, update *org.MetadataUpdate) error {
+	if len(update.Labels) > 0 {
+		return errors.New("columns do not support labels")
+	}
+
+	if update.Description == nil {
+		return nil
+	}
+
+	table := c.client.Dataset(datasetId).Table(tableId)
+
+	meta, err := table.Metadata(ctx)
+	if err != nil {
+		return fmt.Errorf("get metadata of table %q: %w", tableId, err)
+	}
+
+	schema, changed, err := setColumnDescription(meta.Schema, column, *update.Description)
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		return nil
+	}
+
+	_, err = table.Update(ctx, bigquery.TableMetadataToUpdate{Schema: schema}, meta.ETag)
+	if err != nil {
+		return fmt.Errorf("update description of column %q: %w", column, err)
+	}
+
+	return nil
+}
+
+// setColumnDescription returns a copy of the schema in which the description of the column is updated.
+func setColumnDescription(schema bigquery.Schema, column string, description string) (bigquery.Schema, bool, error) {
+	result := make(bigquery.Schema, 0, len(schema))
+	found := false
+	changed := false
+
+	for _, field := range schema {
+		if field.Name == column {
+			found = true
+
+			if field.Description != description {
+				fieldCopy := *field
+				fieldCopy.Description = description
+				field = &fieldCopy
+				changed = true
+			}
+		}
+
+		result = append(result, field)
+	}
+
+	if !found {
+		return nil, false, fmt.Errorf("column %q not found", column)
+	}
+
+	return result, changed, nil
+}
+
+type labelUpdater interface {
+	SetLabel(name, value string)
+	DeleteLabel(name string)
+}
+
+func setLabelUpdates(updater labelUpdater, current map[string]string, labels map[string]string) {
+	for key := range current {
+		if _, found := labels[key]; !found {
+			updater.DeleteLabel(key)
+		}
+	}
+
+	for key, value := range labels {
+		if currentValue, found := current[key]; !found || currentValue != value {
+			updater.SetLabel(key, value)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package bigquery
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+func TestSetColumnDescription(t *testing.T) {
+	schema := bigquery.Schema{
+		{Name: "id", Description: "identifier"},
+		{Name: "name"},
+	}
+
+	t.Run("update description", func(t *testing.T) {
+		result, changed, err := setColumnDescription(schema, "name", "full name")
+
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, "full name", result[1].Description)
+		assert.Equal(t, "identifier", result[0].Description)
+		assert.Empty(t, schema[1].Description)
+	})
+
+	t.Run("unchanged description", func(t *testing.T) {
+		_, changed, err := setColumnDescription(schema, "id", "identifier")
+
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		_, _, err := setColumnDescription(schema, "unknown", "description")
+
+		assert.Error(t, err)
+	})
+}
+
+type labelUpdaterRecorder struct {
+	set     map[string]string
+	deleted []string
+}
+
+func (r *labelUpdaterRecorder) SetLabel(name, value string) {
+	r.set[name] = value
+}
+
+func (r *labelUpdaterRecorder) DeleteLabel(name string) {
+	r.deleted = append(r.deleted, name)
+}
+
+func TestSetLabelUpdates(t *testing.T) {
+	recorder := &labelUpdaterRecorder{set: map[string]string{}}
+
+	setLabelUpdates(recorder, map[string]string{"team": "sales", "env": "prod", "old": "value"}, map[string]string{"team": "finance", "env": "prod", "new": "value"})
+
+	assert.Equal(t, map[string]string{"team": "finance", "new": "value"}, recorder.set)
+	assert.Equal(t, []string{"old"}, recorder.deleted)
+}
+
+func TestRepository_UpdateMetadata_OtherProject(t *testing.T) {
+	repo := &Repository{projectId: "project1"}
+
+	for _, fullName := range []string{"project2", "project2.dataset1", "project2.dataset1.table1"} {
+		err := repo.UpdateMetadata(context.Background(), &org.MetadataUpdate{FullName: fullName, Labels: map[string]*string{"team": nil}})
+
+		require.Error(t, err, fullName)
+		assert.Contains(t, err.Error(), `is not part of project "project1"`)
+	}
+}
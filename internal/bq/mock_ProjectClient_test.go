@@ -130,6 +130,54 @@ func (_c *MockProjectClient_UpdateBinding_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// UpdateProjectLabels provides a mock function with given fields: ctx, projectId, labels
+func (_m *MockProjectClient) UpdateProjectLabels(ctx context.Context, projectId string, labels map[string]*string) error {
+	ret := _m.Called(ctx, projectId, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProjectLabels")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]*string) error); ok {
+		r0 = rf(ctx, projectId, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockProjectClient_UpdateProjectLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProjectLabels'
+type MockProjectClient_UpdateProjectLabels_Call struct {
+	*mock.Call
+}
+
+// UpdateProjectLabels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectId string
+//   - labels map[string]*string
+func (_e *MockProjectClient_Expecter) UpdateProjectLabels(ctx interface{}, projectId interface{}, labels interface{}) *MockProjectClient_UpdateProjectLabels_Call {
+	return &MockProjectClient_UpdateProjectLabels_Call{Call: _e.mock.On("UpdateProjectLabels", ctx, projectId, labels)}
+}
+
+func (_c *MockProjectClient_UpdateProjectLabels_Call) Run(run func(ctx context.Context, projectId string, labels map[string]*string)) *MockProjectClient_UpdateProjectLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(map[string]*string))
+	})
+	return _c
+}
+
+func (_c *MockProjectClient_UpdateProjectLabels_Call) Return(_a0 error) *MockProjectClient_UpdateProjectLabels_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockProjectClient_UpdateProjectLabels_Call) RunAndReturn(run func(context.Context, string, map[string]*string) error) *MockProjectClient_UpdateProjectLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockProjectClient creates a new instance of MockProjectClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockProjectClient(t interface {
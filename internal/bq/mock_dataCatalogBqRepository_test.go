@@ -24,6 +24,88 @@ func (_m *mockDataCatalogBqRepository) EXPECT() *mockDataCatalogBqRepository_Exp
 	return &mockDataCatalogBqRepository_Expecter{mock: &_m.Mock}
 }
 
+// InvalidateDataObjects provides a mock function with given fields: parentFullName
+func (_m *mockDataCatalogBqRepository) InvalidateDataObjects(parentFullName string) {
+	_m.Called(parentFullName)
+}
+
+// mockDataCatalogBqRepository_InvalidateDataObjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateDataObjects'
+type mockDataCatalogBqRepository_InvalidateDataObjects_Call struct {
+	*mock.Call
+}
+
+// InvalidateDataObjects is a helper method to define mock.On call
+//   - parentFullName string
+func (_e *mockDataCatalogBqRepository_Expecter) InvalidateDataObjects(parentFullName interface{}) *mockDataCatalogBqRepository_InvalidateDataObjects_Call {
+	return &mockDataCatalogBqRepository_InvalidateDataObjects_Call{Call: _e.mock.On("InvalidateDataObjects", parentFullName)}
+}
+
+func (_c *mockDataCatalogBqRepository_InvalidateDataObjects_Call) Run(run func(parentFullName string)) *mockDataCatalogBqRepository_InvalidateDataObjects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *mockDataCatalogBqRepository_InvalidateDataObjects_Call) Return() *mockDataCatalogBqRepository_InvalidateDataObjects_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockDataCatalogBqRepository_InvalidateDataObjects_Call) RunAndReturn(run func(string)) *mockDataCatalogBqRepository_InvalidateDataObjects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListColumns provides a mock function with given fields: ctx, tab, parent, fn
+func (_m *mockDataCatalogBqRepository) ListColumns(ctx context.Context, tab *gobigquery.Table, parent *org.GcpOrgEntity, fn func(context.Context, *org.GcpOrgEntity) error) error {
+	ret := _m.Called(ctx, tab, parent, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListColumns")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gobigquery.Table, *org.GcpOrgEntity, func(context.Context, *org.GcpOrgEntity) error) error); ok {
+		r0 = rf(ctx, tab, parent, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockDataCatalogBqRepository_ListColumns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListColumns'
+type mockDataCatalogBqRepository_ListColumns_Call struct {
+	*mock.Call
+}
+
+// ListColumns is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tab *gobigquery.Table
+//   - parent *org.GcpOrgEntity
+//   - fn func(context.Context , *org.GcpOrgEntity) error
+func (_e *mockDataCatalogBqRepository_Expecter) ListColumns(ctx interface{}, tab interface{}, parent interface{}, fn interface{}) *mockDataCatalogBqRepository_ListColumns_Call {
+	return &mockDataCatalogBqRepository_ListColumns_Call{Call: _e.mock.On("ListColumns", ctx, tab, parent, fn)}
+}
+
+func (_c *mockDataCatalogBqRepository_ListColumns_Call) Run(run func(ctx context.Context, tab *gobigquery.Table, parent *org.GcpOrgEntity, fn func(context.Context, *org.GcpOrgEntity) error)) *mockDataCatalogBqRepository_ListColumns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*gobigquery.Table), args[2].(*org.GcpOrgEntity), args[3].(func(context.Context, *org.GcpOrgEntity) error))
+	})
+	return _c
+}
+
+func (_c *mockDataCatalogBqRepository_ListColumns_Call) Return(_a0 error) *mockDataCatalogBqRepository_ListColumns_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDataCatalogBqRepository_ListColumns_Call) RunAndReturn(run func(context.Context, *gobigquery.Table, *org.GcpOrgEntity, func(context.Context, *org.GcpOrgEntity) error) error) *mockDataCatalogBqRepository_ListColumns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListDataSets provides a mock function with given fields: ctx, parent, fn
 func (_m *mockDataCatalogBqRepository) ListDataSets(ctx context.Context, parent *org.GcpOrgEntity, fn func(context.Context, *org.GcpOrgEntity, *gobigquery.Dataset) error) error {
 	ret := _m.Called(ctx, parent, fn)
@@ -5,10 +5,11 @@ package bigquery
 import (
 	context "context"
 
-	datapoliciespb "cloud.google.com/go/bigquery/datapolicies/apiv1/datapoliciespb"
 	mock "github.com/stretchr/testify/mock"
 
 	sync_to_target "github.com/raito-io/cli/base/access_provider/sync_to_target"
+
+	iam "github.com/raito-io/cli-plugin-gcp/internal/iam"
 )
 
 // mockMaskingDataCatalogRepository is an autogenerated mock type for the maskingDataCatalogRepository type
@@ -24,8 +25,211 @@ func (_m *mockMaskingDataCatalogRepository) EXPECT() *mockMaskingDataCatalogRepo
 	return &mockMaskingDataCatalogRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddColumnPolicyTagUpdate provides a mock function with given fields: policy, dataObjects, deletedDataObjects
+func (_m *mockMaskingDataCatalogRepository) AddColumnPolicyTagUpdate(policy *BQMaskingInformation, dataObjects []string, deletedDataObjects []string) *ColumnPolicyTagResult {
+	ret := _m.Called(policy, dataObjects, deletedDataObjects)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddColumnPolicyTagUpdate")
+	}
+
+	var r0 *ColumnPolicyTagResult
+	if rf, ok := ret.Get(0).(func(*BQMaskingInformation, []string, []string) *ColumnPolicyTagResult); ok {
+		r0 = rf(policy, dataObjects, deletedDataObjects)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ColumnPolicyTagResult)
+		}
+	}
+
+	return r0
+}
+
+// mockMaskingDataCatalogRepository_AddColumnPolicyTagUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddColumnPolicyTagUpdate'
+type mockMaskingDataCatalogRepository_AddColumnPolicyTagUpdate_Call struct {
+	*mock.Call
+}
+
+// AddColumnPolicyTagUpdate is a helper method to define mock.On call
+//   - policy *BQMaskingInformation
+//   - dataObjects []string
+//   - deletedDataObjects []string
+func (_e *mockMaskingDataCatalogRepository_Expecter) AddColumnPolicyTagUpdate(policy interface{}, dataObjects interface{}, deletedDataObjects interface{}) *mockMaskingDataCatalogRepository_AddColumnPolicyTagUpdate_Call {
+	return &mockMaskingDataCatalogRepository_AddColumnPolicyTagUpdate_Call{Call: _e.mock.On("AddColumnPolicyTagUpdate", policy, dataObjects, deletedDataObjects)}
+}
+
+func (_c *mockMaskingDataCatalogRepository_AddColumnPolicyTagUpdate_Call) Run(run func(policy *BQMaskingInformation, dataObjects []string, deletedDataObjects []string)) *mockMaskingDataCatalogRepository_AddColumnPolicyTagUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*BQMaskingInformation), args[1].([]string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_AddColumnPolicyTagUpdate_Call) Return(_a0 *ColumnPolicyTagResult) *mockMaskingDataCatalogRepository_AddColumnPolicyTagUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_AddColumnPolicyTagUpdate_Call) RunAndReturn(run func(*BQMaskingInformation, []string, []string) *ColumnPolicyTagResult) *mockMaskingDataCatalogRepository_AddColumnPolicyTagUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ApplyColumnPolicyTagUpdates provides a mock function with given fields: ctx
+func (_m *mockMaskingDataCatalogRepository) ApplyColumnPolicyTagUpdates(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// mockMaskingDataCatalogRepository_ApplyColumnPolicyTagUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyColumnPolicyTagUpdates'
+type mockMaskingDataCatalogRepository_ApplyColumnPolicyTagUpdates_Call struct {
+	*mock.Call
+}
+
+// ApplyColumnPolicyTagUpdates is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockMaskingDataCatalogRepository_Expecter) ApplyColumnPolicyTagUpdates(ctx interface{}) *mockMaskingDataCatalogRepository_ApplyColumnPolicyTagUpdates_Call {
+	return &mockMaskingDataCatalogRepository_ApplyColumnPolicyTagUpdates_Call{Call: _e.mock.On("ApplyColumnPolicyTagUpdates", ctx)}
+}
+
+func (_c *mockMaskingDataCatalogRepository_ApplyColumnPolicyTagUpdates_Call) Run(run func(ctx context.Context)) *mockMaskingDataCatalogRepository_ApplyColumnPolicyTagUpdates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_ApplyColumnPolicyTagUpdates_Call) Return() *mockMaskingDataCatalogRepository_ApplyColumnPolicyTagUpdates_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_ApplyColumnPolicyTagUpdates_Call) RunAndReturn(run func(context.Context)) *mockMaskingDataCatalogRepository_ApplyColumnPolicyTagUpdates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateColumnAccessPolicyTag provides a mock function with given fields: ctx, location, ap
+func (_m *mockMaskingDataCatalogRepository) CreateColumnAccessPolicyTag(ctx context.Context, location string, ap *sync_to_target.AccessProvider) (*BQPolicyTag, error) {
+	ret := _m.Called(ctx, location, ap)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateColumnAccessPolicyTag")
+	}
+
+	var r0 *BQPolicyTag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *sync_to_target.AccessProvider) (*BQPolicyTag, error)); ok {
+		return rf(ctx, location, ap)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *sync_to_target.AccessProvider) *BQPolicyTag); ok {
+		r0 = rf(ctx, location, ap)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BQPolicyTag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *sync_to_target.AccessProvider) error); ok {
+		r1 = rf(ctx, location, ap)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockMaskingDataCatalogRepository_CreateColumnAccessPolicyTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateColumnAccessPolicyTag'
+type mockMaskingDataCatalogRepository_CreateColumnAccessPolicyTag_Call struct {
+	*mock.Call
+}
+
+// CreateColumnAccessPolicyTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - location string
+//   - ap *sync_to_target.AccessProvider
+func (_e *mockMaskingDataCatalogRepository_Expecter) CreateColumnAccessPolicyTag(ctx interface{}, location interface{}, ap interface{}) *mockMaskingDataCatalogRepository_CreateColumnAccessPolicyTag_Call {
+	return &mockMaskingDataCatalogRepository_CreateColumnAccessPolicyTag_Call{Call: _e.mock.On("CreateColumnAccessPolicyTag", ctx, location, ap)}
+}
+
+func (_c *mockMaskingDataCatalogRepository_CreateColumnAccessPolicyTag_Call) Run(run func(ctx context.Context, location string, ap *sync_to_target.AccessProvider)) *mockMaskingDataCatalogRepository_CreateColumnAccessPolicyTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*sync_to_target.AccessProvider))
+	})
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_CreateColumnAccessPolicyTag_Call) Return(_a0 *BQPolicyTag, _a1 error) *mockMaskingDataCatalogRepository_CreateColumnAccessPolicyTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_CreateColumnAccessPolicyTag_Call) RunAndReturn(run func(context.Context, string, *sync_to_target.AccessProvider) (*BQPolicyTag, error)) *mockMaskingDataCatalogRepository_CreateColumnAccessPolicyTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateFallbackPolicyTagWithDataPolicy provides a mock function with given fields: ctx, location, ap
+func (_m *mockMaskingDataCatalogRepository) CreateFallbackPolicyTagWithDataPolicy(ctx context.Context, location string, ap *sync_to_target.AccessProvider) (*BQMaskingInformation, error) {
+	ret := _m.Called(ctx, location, ap)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFallbackPolicyTagWithDataPolicy")
+	}
+
+	var r0 *BQMaskingInformation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *sync_to_target.AccessProvider) (*BQMaskingInformation, error)); ok {
+		return rf(ctx, location, ap)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *sync_to_target.AccessProvider) *BQMaskingInformation); ok {
+		r0 = rf(ctx, location, ap)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BQMaskingInformation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *sync_to_target.AccessProvider) error); ok {
+		r1 = rf(ctx, location, ap)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockMaskingDataCatalogRepository_CreateFallbackPolicyTagWithDataPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFallbackPolicyTagWithDataPolicy'
+type mockMaskingDataCatalogRepository_CreateFallbackPolicyTagWithDataPolicy_Call struct {
+	*mock.Call
+}
+
+// CreateFallbackPolicyTagWithDataPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - location string
+//   - ap *sync_to_target.AccessProvider
+func (_e *mockMaskingDataCatalogRepository_Expecter) CreateFallbackPolicyTagWithDataPolicy(ctx interface{}, location interface{}, ap interface{}) *mockMaskingDataCatalogRepository_CreateFallbackPolicyTagWithDataPolicy_Call {
+	return &mockMaskingDataCatalogRepository_CreateFallbackPolicyTagWithDataPolicy_Call{Call: _e.mock.On("CreateFallbackPolicyTagWithDataPolicy", ctx, location, ap)}
+}
+
+func (_c *mockMaskingDataCatalogRepository_CreateFallbackPolicyTagWithDataPolicy_Call) Run(run func(ctx context.Context, location string, ap *sync_to_target.AccessProvider)) *mockMaskingDataCatalogRepository_CreateFallbackPolicyTagWithDataPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*sync_to_target.AccessProvider))
+	})
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_CreateFallbackPolicyTagWithDataPolicy_Call) Return(_a0 *BQMaskingInformation, _a1 error) *mockMaskingDataCatalogRepository_CreateFallbackPolicyTagWithDataPolicy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_CreateFallbackPolicyTagWithDataPolicy_Call) RunAndReturn(run func(context.Context, string, *sync_to_target.AccessProvider) (*BQMaskingInformation, error)) *mockMaskingDataCatalogRepository_CreateFallbackPolicyTagWithDataPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreatePolicyTagWithDataPolicy provides a mock function with given fields: ctx, location, maskingType, ap
-func (_m *mockMaskingDataCatalogRepository) CreatePolicyTagWithDataPolicy(ctx context.Context, location string, maskingType datapoliciespb.DataMaskingPolicy_PredefinedExpression, ap *sync_to_target.AccessProvider) (*BQMaskingInformation, error) {
+func (_m *mockMaskingDataCatalogRepository) CreatePolicyTagWithDataPolicy(ctx context.Context, location string, maskingType BQMaskingType, ap *sync_to_target.AccessProvider) (*BQMaskingInformation, error) {
 	ret := _m.Called(ctx, location, maskingType, ap)
 
 	if len(ret) == 0 {
@@ -34,10 +238,10 @@ func (_m *mockMaskingDataCatalogRepository) CreatePolicyTagWithDataPolicy(ctx co
 
 	var r0 *BQMaskingInformation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, datapoliciespb.DataMaskingPolicy_PredefinedExpression, *sync_to_target.AccessProvider) (*BQMaskingInformation, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, BQMaskingType, *sync_to_target.AccessProvider) (*BQMaskingInformation, error)); ok {
 		return rf(ctx, location, maskingType, ap)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, datapoliciespb.DataMaskingPolicy_PredefinedExpression, *sync_to_target.AccessProvider) *BQMaskingInformation); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, BQMaskingType, *sync_to_target.AccessProvider) *BQMaskingInformation); ok {
 		r0 = rf(ctx, location, maskingType, ap)
 	} else {
 		if ret.Get(0) != nil {
@@ -45,7 +249,7 @@ func (_m *mockMaskingDataCatalogRepository) CreatePolicyTagWithDataPolicy(ctx co
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, datapoliciespb.DataMaskingPolicy_PredefinedExpression, *sync_to_target.AccessProvider) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string, BQMaskingType, *sync_to_target.AccessProvider) error); ok {
 		r1 = rf(ctx, location, maskingType, ap)
 	} else {
 		r1 = ret.Error(1)
@@ -62,15 +266,15 @@ type mockMaskingDataCatalogRepository_CreatePolicyTagWithDataPolicy_Call struct
 // CreatePolicyTagWithDataPolicy is a helper method to define mock.On call
 //   - ctx context.Context
 //   - location string
-//   - maskingType datapoliciespb.DataMaskingPolicy_PredefinedExpression
+//   - maskingType BQMaskingType
 //   - ap *sync_to_target.AccessProvider
 func (_e *mockMaskingDataCatalogRepository_Expecter) CreatePolicyTagWithDataPolicy(ctx interface{}, location interface{}, maskingType interface{}, ap interface{}) *mockMaskingDataCatalogRepository_CreatePolicyTagWithDataPolicy_Call {
 	return &mockMaskingDataCatalogRepository_CreatePolicyTagWithDataPolicy_Call{Call: _e.mock.On("CreatePolicyTagWithDataPolicy", ctx, location, maskingType, ap)}
 }
 
-func (_c *mockMaskingDataCatalogRepository_CreatePolicyTagWithDataPolicy_Call) Run(run func(ctx context.Context, location string, maskingType datapoliciespb.DataMaskingPolicy_PredefinedExpression, ap *sync_to_target.AccessProvider)) *mockMaskingDataCatalogRepository_CreatePolicyTagWithDataPolicy_Call {
+func (_c *mockMaskingDataCatalogRepository_CreatePolicyTagWithDataPolicy_Call) Run(run func(ctx context.Context, location string, maskingType BQMaskingType, ap *sync_to_target.AccessProvider)) *mockMaskingDataCatalogRepository_CreatePolicyTagWithDataPolicy_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(datapoliciespb.DataMaskingPolicy_PredefinedExpression), args[3].(*sync_to_target.AccessProvider))
+		run(args[0].(context.Context), args[1].(string), args[2].(BQMaskingType), args[3].(*sync_to_target.AccessProvider))
 	})
 	return _c
 }
@@ -80,7 +284,123 @@ func (_c *mockMaskingDataCatalogRepository_CreatePolicyTagWithDataPolicy_Call) R
 	return _c
 }
 
-func (_c *mockMaskingDataCatalogRepository_CreatePolicyTagWithDataPolicy_Call) RunAndReturn(run func(context.Context, string, datapoliciespb.DataMaskingPolicy_PredefinedExpression, *sync_to_target.AccessProvider) (*BQMaskingInformation, error)) *mockMaskingDataCatalogRepository_CreatePolicyTagWithDataPolicy_Call {
+func (_c *mockMaskingDataCatalogRepository_CreatePolicyTagWithDataPolicy_Call) RunAndReturn(run func(context.Context, string, BQMaskingType, *sync_to_target.AccessProvider) (*BQMaskingInformation, error)) *mockMaskingDataCatalogRepository_CreatePolicyTagWithDataPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DataPoliciesForDataObject provides a mock function with given fields: ctx, dataObject
+func (_m *mockMaskingDataCatalogRepository) DataPoliciesForDataObject(ctx context.Context, dataObject *iam.DataObjectReference) ([]string, error) {
+	ret := _m.Called(ctx, dataObject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DataPoliciesForDataObject")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *iam.DataObjectReference) ([]string, error)); ok {
+		return rf(ctx, dataObject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *iam.DataObjectReference) []string); ok {
+		r0 = rf(ctx, dataObject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *iam.DataObjectReference) error); ok {
+		r1 = rf(ctx, dataObject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockMaskingDataCatalogRepository_DataPoliciesForDataObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DataPoliciesForDataObject'
+type mockMaskingDataCatalogRepository_DataPoliciesForDataObject_Call struct {
+	*mock.Call
+}
+
+// DataPoliciesForDataObject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dataObject *iam.DataObjectReference
+func (_e *mockMaskingDataCatalogRepository_Expecter) DataPoliciesForDataObject(ctx interface{}, dataObject interface{}) *mockMaskingDataCatalogRepository_DataPoliciesForDataObject_Call {
+	return &mockMaskingDataCatalogRepository_DataPoliciesForDataObject_Call{Call: _e.mock.On("DataPoliciesForDataObject", ctx, dataObject)}
+}
+
+func (_c *mockMaskingDataCatalogRepository_DataPoliciesForDataObject_Call) Run(run func(ctx context.Context, dataObject *iam.DataObjectReference)) *mockMaskingDataCatalogRepository_DataPoliciesForDataObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*iam.DataObjectReference))
+	})
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_DataPoliciesForDataObject_Call) Return(_a0 []string, _a1 error) *mockMaskingDataCatalogRepository_DataPoliciesForDataObject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_DataPoliciesForDataObject_Call) RunAndReturn(run func(context.Context, *iam.DataObjectReference) ([]string, error)) *mockMaskingDataCatalogRepository_DataPoliciesForDataObject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteColumnAccessPolicyTag provides a mock function with given fields: ctx, policyTag
+func (_m *mockMaskingDataCatalogRepository) DeleteColumnAccessPolicyTag(ctx context.Context, policyTag *BQPolicyTag) (bool, error) {
+	ret := _m.Called(ctx, policyTag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteColumnAccessPolicyTag")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *BQPolicyTag) (bool, error)); ok {
+		return rf(ctx, policyTag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *BQPolicyTag) bool); ok {
+		r0 = rf(ctx, policyTag)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *BQPolicyTag) error); ok {
+		r1 = rf(ctx, policyTag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockMaskingDataCatalogRepository_DeleteColumnAccessPolicyTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteColumnAccessPolicyTag'
+type mockMaskingDataCatalogRepository_DeleteColumnAccessPolicyTag_Call struct {
+	*mock.Call
+}
+
+// DeleteColumnAccessPolicyTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policyTag *BQPolicyTag
+func (_e *mockMaskingDataCatalogRepository_Expecter) DeleteColumnAccessPolicyTag(ctx interface{}, policyTag interface{}) *mockMaskingDataCatalogRepository_DeleteColumnAccessPolicyTag_Call {
+	return &mockMaskingDataCatalogRepository_DeleteColumnAccessPolicyTag_Call{Call: _e.mock.On("DeleteColumnAccessPolicyTag", ctx, policyTag)}
+}
+
+func (_c *mockMaskingDataCatalogRepository_DeleteColumnAccessPolicyTag_Call) Run(run func(ctx context.Context, policyTag *BQPolicyTag)) *mockMaskingDataCatalogRepository_DeleteColumnAccessPolicyTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*BQPolicyTag))
+	})
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_DeleteColumnAccessPolicyTag_Call) Return(_a0 bool, _a1 error) *mockMaskingDataCatalogRepository_DeleteColumnAccessPolicyTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_DeleteColumnAccessPolicyTag_Call) RunAndReturn(run func(context.Context, *BQPolicyTag) (bool, error)) *mockMaskingDataCatalogRepository_DeleteColumnAccessPolicyTag_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -132,24 +452,83 @@ func (_c *mockMaskingDataCatalogRepository_DeletePolicyAndTag_Call) RunAndReturn
 	return _c
 }
 
+// GetColumnDataTypes provides a mock function with given fields: ctx, columns
+func (_m *mockMaskingDataCatalogRepository) GetColumnDataTypes(ctx context.Context, columns []string) (map[string]string, error) {
+	ret := _m.Called(ctx, columns)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetColumnDataTypes")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]string, error)); ok {
+		return rf(ctx, columns)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]string); ok {
+		r0 = rf(ctx, columns)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, columns)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockMaskingDataCatalogRepository_GetColumnDataTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetColumnDataTypes'
+type mockMaskingDataCatalogRepository_GetColumnDataTypes_Call struct {
+	*mock.Call
+}
+
+// GetColumnDataTypes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - columns []string
+func (_e *mockMaskingDataCatalogRepository_Expecter) GetColumnDataTypes(ctx interface{}, columns interface{}) *mockMaskingDataCatalogRepository_GetColumnDataTypes_Call {
+	return &mockMaskingDataCatalogRepository_GetColumnDataTypes_Call{Call: _e.mock.On("GetColumnDataTypes", ctx, columns)}
+}
+
+func (_c *mockMaskingDataCatalogRepository_GetColumnDataTypes_Call) Run(run func(ctx context.Context, columns []string)) *mockMaskingDataCatalogRepository_GetColumnDataTypes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_GetColumnDataTypes_Call) Return(_a0 map[string]string, _a1 error) *mockMaskingDataCatalogRepository_GetColumnDataTypes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_GetColumnDataTypes_Call) RunAndReturn(run func(context.Context, []string) (map[string]string, error)) *mockMaskingDataCatalogRepository_GetColumnDataTypes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetFineGrainedReaderMembers provides a mock function with given fields: ctx, tagId
-func (_m *mockMaskingDataCatalogRepository) GetFineGrainedReaderMembers(ctx context.Context, tagId string) ([]string, error) {
+func (_m *mockMaskingDataCatalogRepository) GetFineGrainedReaderMembers(ctx context.Context, tagId string) (*FineGrainedReaders, error) {
 	ret := _m.Called(ctx, tagId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetFineGrainedReaderMembers")
 	}
 
-	var r0 []string
+	var r0 *FineGrainedReaders
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*FineGrainedReaders, error)); ok {
 		return rf(ctx, tagId)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *FineGrainedReaders); ok {
 		r0 = rf(ctx, tagId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).(*FineGrainedReaders)
 		}
 	}
 
@@ -181,12 +560,12 @@ func (_c *mockMaskingDataCatalogRepository_GetFineGrainedReaderMembers_Call) Run
 	return _c
 }
 
-func (_c *mockMaskingDataCatalogRepository_GetFineGrainedReaderMembers_Call) Return(_a0 []string, _a1 error) *mockMaskingDataCatalogRepository_GetFineGrainedReaderMembers_Call {
+func (_c *mockMaskingDataCatalogRepository_GetFineGrainedReaderMembers_Call) Return(_a0 *FineGrainedReaders, _a1 error) *mockMaskingDataCatalogRepository_GetFineGrainedReaderMembers_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *mockMaskingDataCatalogRepository_GetFineGrainedReaderMembers_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *mockMaskingDataCatalogRepository_GetFineGrainedReaderMembers_Call {
+func (_c *mockMaskingDataCatalogRepository_GetFineGrainedReaderMembers_Call) RunAndReturn(run func(context.Context, string) (*FineGrainedReaders, error)) *mockMaskingDataCatalogRepository_GetFineGrainedReaderMembers_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -259,6 +638,65 @@ func (_c *mockMaskingDataCatalogRepository_GetLocationsForDataObjects_Call) RunA
 	return _c
 }
 
+// GetPolicyTag provides a mock function with given fields: ctx, tagId
+func (_m *mockMaskingDataCatalogRepository) GetPolicyTag(ctx context.Context, tagId string) (*BQPolicyTag, error) {
+	ret := _m.Called(ctx, tagId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPolicyTag")
+	}
+
+	var r0 *BQPolicyTag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*BQPolicyTag, error)); ok {
+		return rf(ctx, tagId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *BQPolicyTag); ok {
+		r0 = rf(ctx, tagId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BQPolicyTag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tagId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockMaskingDataCatalogRepository_GetPolicyTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPolicyTag'
+type mockMaskingDataCatalogRepository_GetPolicyTag_Call struct {
+	*mock.Call
+}
+
+// GetPolicyTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tagId string
+func (_e *mockMaskingDataCatalogRepository_Expecter) GetPolicyTag(ctx interface{}, tagId interface{}) *mockMaskingDataCatalogRepository_GetPolicyTag_Call {
+	return &mockMaskingDataCatalogRepository_GetPolicyTag_Call{Call: _e.mock.On("GetPolicyTag", ctx, tagId)}
+}
+
+func (_c *mockMaskingDataCatalogRepository_GetPolicyTag_Call) Run(run func(ctx context.Context, tagId string)) *mockMaskingDataCatalogRepository_GetPolicyTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_GetPolicyTag_Call) Return(_a0 *BQPolicyTag, _a1 error) *mockMaskingDataCatalogRepository_GetPolicyTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_GetPolicyTag_Call) RunAndReturn(run func(context.Context, string) (*BQPolicyTag, error)) *mockMaskingDataCatalogRepository_GetPolicyTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListDataPolicies provides a mock function with given fields: ctx
 func (_m *mockMaskingDataCatalogRepository) ListDataPolicies(ctx context.Context) (map[string]BQMaskingInformation, error) {
 	ret := _m.Called(ctx)
@@ -366,29 +804,29 @@ func (_c *mockMaskingDataCatalogRepository_UpdateAccess_Call) RunAndReturn(run f
 	return _c
 }
 
-// UpdatePolicyTag provides a mock function with given fields: ctx, location, maskingType, ap, dataPolicyId
-func (_m *mockMaskingDataCatalogRepository) UpdatePolicyTag(ctx context.Context, location string, maskingType datapoliciespb.DataMaskingPolicy_PredefinedExpression, ap *sync_to_target.AccessProvider, dataPolicyId string) (*BQMaskingInformation, error) {
-	ret := _m.Called(ctx, location, maskingType, ap, dataPolicyId)
+// UpdateColumnAccessPolicyTag provides a mock function with given fields: ctx, policyTag, ap
+func (_m *mockMaskingDataCatalogRepository) UpdateColumnAccessPolicyTag(ctx context.Context, policyTag *BQPolicyTag, ap *sync_to_target.AccessProvider) (*BQPolicyTag, error) {
+	ret := _m.Called(ctx, policyTag, ap)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdatePolicyTag")
+		panic("no return value specified for UpdateColumnAccessPolicyTag")
 	}
 
-	var r0 *BQMaskingInformation
+	var r0 *BQPolicyTag
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, datapoliciespb.DataMaskingPolicy_PredefinedExpression, *sync_to_target.AccessProvider, string) (*BQMaskingInformation, error)); ok {
-		return rf(ctx, location, maskingType, ap, dataPolicyId)
+	if rf, ok := ret.Get(0).(func(context.Context, *BQPolicyTag, *sync_to_target.AccessProvider) (*BQPolicyTag, error)); ok {
+		return rf(ctx, policyTag, ap)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, datapoliciespb.DataMaskingPolicy_PredefinedExpression, *sync_to_target.AccessProvider, string) *BQMaskingInformation); ok {
-		r0 = rf(ctx, location, maskingType, ap, dataPolicyId)
+	if rf, ok := ret.Get(0).(func(context.Context, *BQPolicyTag, *sync_to_target.AccessProvider) *BQPolicyTag); ok {
+		r0 = rf(ctx, policyTag, ap)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*BQMaskingInformation)
+			r0 = ret.Get(0).(*BQPolicyTag)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, datapoliciespb.DataMaskingPolicy_PredefinedExpression, *sync_to_target.AccessProvider, string) error); ok {
-		r1 = rf(ctx, location, maskingType, ap, dataPolicyId)
+	if rf, ok := ret.Get(1).(func(context.Context, *BQPolicyTag, *sync_to_target.AccessProvider) error); ok {
+		r1 = rf(ctx, policyTag, ap)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -396,49 +834,47 @@ func (_m *mockMaskingDataCatalogRepository) UpdatePolicyTag(ctx context.Context,
 	return r0, r1
 }
 
-// mockMaskingDataCatalogRepository_UpdatePolicyTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePolicyTag'
-type mockMaskingDataCatalogRepository_UpdatePolicyTag_Call struct {
+// mockMaskingDataCatalogRepository_UpdateColumnAccessPolicyTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateColumnAccessPolicyTag'
+type mockMaskingDataCatalogRepository_UpdateColumnAccessPolicyTag_Call struct {
 	*mock.Call
 }
 
-// UpdatePolicyTag is a helper method to define mock.On call
+// UpdateColumnAccessPolicyTag is a helper method to define mock.On call
 //   - ctx context.Context
-//   - location string
-//   - maskingType datapoliciespb.DataMaskingPolicy_PredefinedExpression
+//   - policyTag *BQPolicyTag
 //   - ap *sync_to_target.AccessProvider
-//   - dataPolicyId string
-func (_e *mockMaskingDataCatalogRepository_Expecter) UpdatePolicyTag(ctx interface{}, location interface{}, maskingType interface{}, ap interface{}, dataPolicyId interface{}) *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call {
-	return &mockMaskingDataCatalogRepository_UpdatePolicyTag_Call{Call: _e.mock.On("UpdatePolicyTag", ctx, location, maskingType, ap, dataPolicyId)}
+func (_e *mockMaskingDataCatalogRepository_Expecter) UpdateColumnAccessPolicyTag(ctx interface{}, policyTag interface{}, ap interface{}) *mockMaskingDataCatalogRepository_UpdateColumnAccessPolicyTag_Call {
+	return &mockMaskingDataCatalogRepository_UpdateColumnAccessPolicyTag_Call{Call: _e.mock.On("UpdateColumnAccessPolicyTag", ctx, policyTag, ap)}
 }
 
-func (_c *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call) Run(run func(ctx context.Context, location string, maskingType datapoliciespb.DataMaskingPolicy_PredefinedExpression, ap *sync_to_target.AccessProvider, dataPolicyId string)) *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call {
+func (_c *mockMaskingDataCatalogRepository_UpdateColumnAccessPolicyTag_Call) Run(run func(ctx context.Context, policyTag *BQPolicyTag, ap *sync_to_target.AccessProvider)) *mockMaskingDataCatalogRepository_UpdateColumnAccessPolicyTag_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(datapoliciespb.DataMaskingPolicy_PredefinedExpression), args[3].(*sync_to_target.AccessProvider), args[4].(string))
+		run(args[0].(context.Context), args[1].(*BQPolicyTag), args[2].(*sync_to_target.AccessProvider))
 	})
 	return _c
 }
 
-func (_c *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call) Return(_a0 *BQMaskingInformation, _a1 error) *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call {
+func (_c *mockMaskingDataCatalogRepository_UpdateColumnAccessPolicyTag_Call) Return(_a0 *BQPolicyTag, _a1 error) *mockMaskingDataCatalogRepository_UpdateColumnAccessPolicyTag_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call) RunAndReturn(run func(context.Context, string, datapoliciespb.DataMaskingPolicy_PredefinedExpression, *sync_to_target.AccessProvider, string) (*BQMaskingInformation, error)) *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call {
+func (_c *mockMaskingDataCatalogRepository_UpdateColumnAccessPolicyTag_Call) RunAndReturn(run func(context.Context, *BQPolicyTag, *sync_to_target.AccessProvider) (*BQPolicyTag, error)) *mockMaskingDataCatalogRepository_UpdateColumnAccessPolicyTag_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateWhatOfDataPolicy provides a mock function with given fields: ctx, policy, dataObjects, deletedDataObjects
-func (_m *mockMaskingDataCatalogRepository) UpdateWhatOfDataPolicy(ctx context.Context, policy *BQMaskingInformation, dataObjects []string, deletedDataObjects []string) error {
-	ret := _m.Called(ctx, policy, dataObjects, deletedDataObjects)
+// UpdateMaskedReaders provides a mock function with given fields: ctx, dataPolicyId, membersToAdd, membersToRemove
+func (_m *mockMaskingDataCatalogRepository) UpdateMaskedReaders(ctx context.Context, dataPolicyId string, membersToAdd []string, membersToRemove []string) error {
+	ret := _m.Called(ctx, dataPolicyId, membersToAdd, membersToRemove)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateWhatOfDataPolicy")
+		panic("no return value specified for UpdateMaskedReaders")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *BQMaskingInformation, []string, []string) error); ok {
-		r0 = rf(ctx, policy, dataObjects, deletedDataObjects)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, []string) error); ok {
+		r0 = rf(ctx, dataPolicyId, membersToAdd, membersToRemove)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -446,33 +882,95 @@ func (_m *mockMaskingDataCatalogRepository) UpdateWhatOfDataPolicy(ctx context.C
 	return r0
 }
 
-// mockMaskingDataCatalogRepository_UpdateWhatOfDataPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWhatOfDataPolicy'
-type mockMaskingDataCatalogRepository_UpdateWhatOfDataPolicy_Call struct {
+// mockMaskingDataCatalogRepository_UpdateMaskedReaders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMaskedReaders'
+type mockMaskingDataCatalogRepository_UpdateMaskedReaders_Call struct {
 	*mock.Call
 }
 
-// UpdateWhatOfDataPolicy is a helper method to define mock.On call
+// UpdateMaskedReaders is a helper method to define mock.On call
 //   - ctx context.Context
-//   - policy *BQMaskingInformation
-//   - dataObjects []string
-//   - deletedDataObjects []string
-func (_e *mockMaskingDataCatalogRepository_Expecter) UpdateWhatOfDataPolicy(ctx interface{}, policy interface{}, dataObjects interface{}, deletedDataObjects interface{}) *mockMaskingDataCatalogRepository_UpdateWhatOfDataPolicy_Call {
-	return &mockMaskingDataCatalogRepository_UpdateWhatOfDataPolicy_Call{Call: _e.mock.On("UpdateWhatOfDataPolicy", ctx, policy, dataObjects, deletedDataObjects)}
+//   - dataPolicyId string
+//   - membersToAdd []string
+//   - membersToRemove []string
+func (_e *mockMaskingDataCatalogRepository_Expecter) UpdateMaskedReaders(ctx interface{}, dataPolicyId interface{}, membersToAdd interface{}, membersToRemove interface{}) *mockMaskingDataCatalogRepository_UpdateMaskedReaders_Call {
+	return &mockMaskingDataCatalogRepository_UpdateMaskedReaders_Call{Call: _e.mock.On("UpdateMaskedReaders", ctx, dataPolicyId, membersToAdd, membersToRemove)}
 }
 
-func (_c *mockMaskingDataCatalogRepository_UpdateWhatOfDataPolicy_Call) Run(run func(ctx context.Context, policy *BQMaskingInformation, dataObjects []string, deletedDataObjects []string)) *mockMaskingDataCatalogRepository_UpdateWhatOfDataPolicy_Call {
+func (_c *mockMaskingDataCatalogRepository_UpdateMaskedReaders_Call) Run(run func(ctx context.Context, dataPolicyId string, membersToAdd []string, membersToRemove []string)) *mockMaskingDataCatalogRepository_UpdateMaskedReaders_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*BQMaskingInformation), args[2].([]string), args[3].([]string))
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].([]string))
 	})
 	return _c
 }
 
-func (_c *mockMaskingDataCatalogRepository_UpdateWhatOfDataPolicy_Call) Return(_a0 error) *mockMaskingDataCatalogRepository_UpdateWhatOfDataPolicy_Call {
+func (_c *mockMaskingDataCatalogRepository_UpdateMaskedReaders_Call) Return(_a0 error) *mockMaskingDataCatalogRepository_UpdateMaskedReaders_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *mockMaskingDataCatalogRepository_UpdateWhatOfDataPolicy_Call) RunAndReturn(run func(context.Context, *BQMaskingInformation, []string, []string) error) *mockMaskingDataCatalogRepository_UpdateWhatOfDataPolicy_Call {
+func (_c *mockMaskingDataCatalogRepository_UpdateMaskedReaders_Call) RunAndReturn(run func(context.Context, string, []string, []string) error) *mockMaskingDataCatalogRepository_UpdateMaskedReaders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePolicyTag provides a mock function with given fields: ctx, location, maskingType, ap, dataPolicyId
+func (_m *mockMaskingDataCatalogRepository) UpdatePolicyTag(ctx context.Context, location string, maskingType BQMaskingType, ap *sync_to_target.AccessProvider, dataPolicyId string) (*BQMaskingInformation, error) {
+	ret := _m.Called(ctx, location, maskingType, ap, dataPolicyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePolicyTag")
+	}
+
+	var r0 *BQMaskingInformation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, BQMaskingType, *sync_to_target.AccessProvider, string) (*BQMaskingInformation, error)); ok {
+		return rf(ctx, location, maskingType, ap, dataPolicyId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, BQMaskingType, *sync_to_target.AccessProvider, string) *BQMaskingInformation); ok {
+		r0 = rf(ctx, location, maskingType, ap, dataPolicyId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BQMaskingInformation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, BQMaskingType, *sync_to_target.AccessProvider, string) error); ok {
+		r1 = rf(ctx, location, maskingType, ap, dataPolicyId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockMaskingDataCatalogRepository_UpdatePolicyTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePolicyTag'
+type mockMaskingDataCatalogRepository_UpdatePolicyTag_Call struct {
+	*mock.Call
+}
+
+// UpdatePolicyTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - location string
+//   - maskingType BQMaskingType
+//   - ap *sync_to_target.AccessProvider
+//   - dataPolicyId string
+func (_e *mockMaskingDataCatalogRepository_Expecter) UpdatePolicyTag(ctx interface{}, location interface{}, maskingType interface{}, ap interface{}, dataPolicyId interface{}) *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call {
+	return &mockMaskingDataCatalogRepository_UpdatePolicyTag_Call{Call: _e.mock.On("UpdatePolicyTag", ctx, location, maskingType, ap, dataPolicyId)}
+}
+
+func (_c *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call) Run(run func(ctx context.Context, location string, maskingType BQMaskingType, ap *sync_to_target.AccessProvider, dataPolicyId string)) *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(BQMaskingType), args[3].(*sync_to_target.AccessProvider), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call) Return(_a0 *BQMaskingInformation, _a1 error) *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call) RunAndReturn(run func(context.Context, string, BQMaskingType, *sync_to_target.AccessProvider, string) (*BQMaskingInformation, error)) *mockMaskingDataCatalogRepository_UpdatePolicyTag_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1,6 +1,7 @@
 package bigquery
 
 import (
+	"fmt"
 	"strings"
 
 	"cloud.google.com/go/bigquery"
@@ -45,6 +46,13 @@ type BQInformationSchemaReferencedTable struct {
 	Table   bigquery.NullString `bigquery:"table_id"`
 }
 
+type BQViewLineageEntity struct {
+	Project string                               `bigquery:"project_id"`
+	Dataset string                               `bigquery:"dataset_id"`
+	Table   string                               `bigquery:"table_id"`
+	Tables  []BQInformationSchemaReferencedTable `bigquery:"referenced_tables"`
+}
+
 type BQReferencedTable struct {
 	Project string `bigquery:"project_id"`
 	Dataset string `bigquery:"dataset_id"`
@@ -67,6 +75,111 @@ type BQMaskingInformation struct {
 type BQDataPolicy struct {
 	FullName   string
 	PolicyType datapoliciespb.DataMaskingPolicy_PredefinedExpression
+	Routine    string // Custom masking routine (projects/{project}/datasets/{dataset}/routines/{routine}), if the data policy is not predefined
+}
+
+// MaskingType returns the masking type of the data policy.
+func (p *BQDataPolicy) MaskingType() BQMaskingType {
+	return BQMaskingType{PredefinedExpression: p.PolicyType, Routine: p.Routine}
+}
+
+// RoutineMaskTypePrefix is the prefix of the Raito mask types that use a custom masking routine, e.g. routine:project.dataset.mask_iban
+const RoutineMaskTypePrefix = "routine:"
+
+// BQMaskingType is either a predefined masking expression or a custom masking routine.
+type BQMaskingType struct {
+	PredefinedExpression datapoliciespb.DataMaskingPolicy_PredefinedExpression
+	Routine              string // projects/{project}/datasets/{dataset}/routines/{routine}
+}
+
+// predefinedExpressionDataTypes are the column data types supported by the predefined masking expressions. Expressions without data types support all columns.
+var predefinedExpressionDataTypes = map[datapoliciespb.DataMaskingPolicy_PredefinedExpression][]string{
+	datapoliciespb.DataMaskingPolicy_SHA256:                {"STRING"},
+	datapoliciespb.DataMaskingPolicy_LAST_FOUR_CHARACTERS:  {"STRING"},
+	datapoliciespb.DataMaskingPolicy_FIRST_FOUR_CHARACTERS: {"STRING"},
+	datapoliciespb.DataMaskingPolicy_EMAIL_MASK:            {"STRING"},
+	datapoliciespb.DataMaskingPolicy_DATE_YEAR_MASK:        {"DATE", "DATETIME", "TIMESTAMP"},
+}
+
+// ParseMaskingType converts a Raito mask type into a masking type. Unknown or missing types result in ALWAYS_NULL.
+func ParseMaskingType(maskType *string) BQMaskingType {
+	if maskType == nil {
+		return BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_ALWAYS_NULL}
+	}
+
+	if routine, found := strings.CutPrefix(*maskType, RoutineMaskTypePrefix); found {
+		// Dataset and routine ids never contain a dot, but domain-scoped project ids do (e.g. example.com:project)
+		datasetIdx := strings.LastIndex(routine, ".")
+		projectIdx := strings.LastIndex(routine[:max(datasetIdx, 0)], ".")
+
+		if projectIdx > 0 && datasetIdx > projectIdx+1 && datasetIdx < len(routine)-1 {
+			return BQMaskingType{Routine: fmt.Sprintf("projects/%s/datasets/%s/routines/%s", routine[:projectIdx], routine[projectIdx+1:datasetIdx], routine[datasetIdx+1:])}
+		}
+	}
+
+	if expression, found := datapoliciespb.DataMaskingPolicy_PredefinedExpression_value[*maskType]; found {
+		return BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_PredefinedExpression(expression)}
+	}
+
+	return BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_ALWAYS_NULL}
+}
+
+// String returns the Raito mask type, e.g. SHA256 or routine:project.dataset.mask_iban
+func (t BQMaskingType) String() string {
+	if t.Routine == "" {
+		return t.PredefinedExpression.String()
+	}
+
+	parts := strings.Split(t.Routine, "/")
+	if len(parts) != 6 {
+		return RoutineMaskTypePrefix + t.Routine
+	}
+
+	return RoutineMaskTypePrefix + strings.Join([]string{parts[1], parts[3], parts[5]}, ".")
+}
+
+// fallbackDataPolicySuffix marks the data policies that mask the columns of a mask of which the data type is not supported by the mask type.
+const fallbackDataPolicySuffix = "_fallback"
+
+// IsFallbackDataPolicy returns true if the data policy masks the unsupported columns of a mask with the default masking value.
+func IsFallbackDataPolicy(dataPolicyId string) bool {
+	return strings.HasSuffix(dataPolicyId, fallbackDataPolicySuffix)
+}
+
+// DataTypes returns the column data types supported by a predefined masking expression.
+// Nil is returned if all data types are supported, or if they are unknown as for custom masking routines.
+func (t BQMaskingType) DataTypes() []string {
+	if t.Routine != "" {
+		return nil
+	}
+
+	return predefinedExpressionDataTypes[t.PredefinedExpression]
+}
+
+// DataMaskingPolicy returns the data masking policy of a data policy that masks with the masking type.
+func (t BQMaskingType) DataMaskingPolicy() *datapoliciespb.DataMaskingPolicy {
+	if t.Routine != "" {
+		return &datapoliciespb.DataMaskingPolicy{
+			MaskingExpression: &datapoliciespb.DataMaskingPolicy_Routine{Routine: t.Routine},
+		}
+	}
+
+	return &datapoliciespb.DataMaskingPolicy{
+		MaskingExpression: &datapoliciespb.DataMaskingPolicy_PredefinedExpression_{PredefinedExpression: t.PredefinedExpression},
+	}
+}
+
+// BQMaskingRoutine is a user defined function that can be used as custom masking rule in data policies.
+type BQMaskingRoutine struct {
+	Project     string
+	Dataset     string
+	Routine     string
+	Description string
+	DataType    string // Data type of the argument and return value (e.g. STRING)
+}
+
+func (r *BQMaskingRoutine) MaskingType() BQMaskingType {
+	return BQMaskingType{Routine: fmt.Sprintf("projects/%s/datasets/%s/routines/%s", r.Project, r.Dataset, r.Routine)}
 }
 
 type BQPolicyTag struct {
@@ -74,6 +187,9 @@ type BQPolicyTag struct {
 	Description string
 	FullName    string
 	ParentTag   string
+
+	// RaitoManaged is true if the policy tag is created by Raito, either in a Raito taxonomy or marked as managed by Raito in its description
+	RaitoManaged bool
 }
 
 func (t *BQPolicyTag) Taxonomy() string {
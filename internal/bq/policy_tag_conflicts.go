@@ -0,0 +1,76 @@
+package bigquery
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// Strategies to resolve a conflict between the policy tag of a mask or column access and the policy tag that is already attached to a column.
+// A column can only have a single policy tag.
+const (
+	PolicyTagConflictFail    = "fail"
+	PolicyTagConflictReplace = "replace"
+	PolicyTagConflictKeep    = "keep"
+)
+
+// tagColumns attaches the policy tag to the columns to add and detaches it from the columns to remove.
+// It returns true if the schema is updated, together with the warnings and conflicts for the columns of the table.
+func (r *DataCatalogRepository) tagColumns(table string, schema bigquery.Schema, policyTag string, update tableMaskUpdate) (bool, []string, []string) {
+	var warnings, conflicts []string
+
+	changed := false
+	normalizedTag := r.normalizeResourceName(policyTag)
+
+	for _, column := range schema {
+		columnName := table + "." + column.Name
+		currentTag := ""
+
+		if column.PolicyTags != nil && len(column.PolicyTags.Names) > 0 {
+			currentTag = column.PolicyTags.Names[0]
+		}
+
+		if update.ColumnsToAddMask != nil && update.ColumnsToAddMask.Contains(column.Name) {
+			if assignedTag, found := r.assignedPolicyTags[columnName]; found && assignedTag != normalizedTag {
+				conflicts = append(conflicts, fmt.Sprintf("column %q is targeted by multiple policy tags: %q and %q", columnName, assignedTag, policyTag))
+
+				continue
+			}
+
+			switch {
+			case r.normalizeResourceName(currentTag) == normalizedTag:
+				// Already tagged
+			case currentTag == "":
+				column.PolicyTags = &bigquery.PolicyTagList{Names: []string{policyTag}}
+				changed = true
+			case r.conflictResolution == PolicyTagConflictReplace:
+				warnings = append(warnings, fmt.Sprintf("policy tag %q of column %q is replaced by %q", currentTag, columnName, policyTag))
+				column.PolicyTags = &bigquery.PolicyTagList{Names: []string{policyTag}}
+				changed = true
+			case r.conflictResolution == PolicyTagConflictKeep:
+				warnings = append(warnings, fmt.Sprintf("column %q keeps its policy tag %q, so policy tag %q is not attached", columnName, currentTag, policyTag))
+
+				continue
+			default:
+				conflicts = append(conflicts, fmt.Sprintf("column %q already has policy tag %q, so policy tag %q is not attached", columnName, currentTag, policyTag))
+
+				continue
+			}
+
+			r.assignedPolicyTags[columnName] = normalizedTag
+		} else if update.ColumnsToRemoveMask != nil && update.ColumnsToRemoveMask.Contains(column.Name) {
+			// Only detach the policy tag if the column is not tagged by another policy tag in the meantime
+			if currentTag != "" && r.normalizeResourceName(currentTag) == normalizedTag {
+				column.PolicyTags = &bigquery.PolicyTagList{Names: []string{}}
+				changed = true
+			}
+		}
+	}
+
+	return changed, warnings, conflicts
+}
+
+// normalizeResourceName replaces the project number in a resource name by the project ID.
+func (r *DataCatalogRepository) normalizeResourceName(name string) string {
+	return projectNumberRegex.ReplaceAllString(name, fmt.Sprintf("projects/%s/", r.projectId))
+}
@@ -0,0 +1,110 @@
+package bigquery
+
+import (
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/raito-io/golang-set/set"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataCatalogRepository_tagColumns(t *testing.T) {
+	const (
+		table       = "project1.ds1.table1"
+		policyTag   = "projects/project1/locations/eu/taxonomies/1/policyTags/10"
+		otherTag    = "projects/project1/locations/eu/taxonomies/1/policyTags/20"
+		numberedTag = "projects/123456/locations/eu/taxonomies/1/policyTags/10"
+	)
+
+	schema := func() bigquery.Schema {
+		return bigquery.Schema{
+			{Name: "untagged"},
+			{Name: "empty", PolicyTags: &bigquery.PolicyTagList{Names: []string{}}},
+			{Name: "tagged", PolicyTags: &bigquery.PolicyTagList{Names: []string{numberedTag}}},
+			{Name: "other", PolicyTags: &bigquery.PolicyTagList{Names: []string{otherTag}}},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		conflictResolution string
+		assigned           map[string]string
+		update             tableMaskUpdate
+		wantChanged        bool
+		wantTags           map[string][]string
+		wantWarnings       []string
+		wantConflicts      []string
+	}{
+		{
+			name:               "Tag untagged columns",
+			conflictResolution: PolicyTagConflictFail,
+			update:             tableMaskUpdate{ColumnsToAddMask: set.NewSet("untagged", "empty", "tagged")},
+			wantChanged:        true,
+			wantTags:           map[string][]string{"untagged": {policyTag}, "empty": {policyTag}, "tagged": {numberedTag}, "other": {otherTag}},
+		},
+		{
+			name:               "Fail on other policy tag",
+			conflictResolution: PolicyTagConflictFail,
+			update:             tableMaskUpdate{ColumnsToAddMask: set.NewSet("other")},
+			wantTags:           map[string][]string{"untagged": nil, "empty": {}, "tagged": {numberedTag}, "other": {otherTag}},
+			wantConflicts:      []string{`column "project1.ds1.table1.other" already has policy tag "` + otherTag + `", so policy tag "` + policyTag + `" is not attached`},
+		},
+		{
+			name:               "Replace other policy tag",
+			conflictResolution: PolicyTagConflictReplace,
+			update:             tableMaskUpdate{ColumnsToAddMask: set.NewSet("other")},
+			wantChanged:        true,
+			wantTags:           map[string][]string{"untagged": nil, "empty": {}, "tagged": {numberedTag}, "other": {policyTag}},
+			wantWarnings:       []string{`policy tag "` + otherTag + `" of column "project1.ds1.table1.other" is replaced by "` + policyTag + `"`},
+		},
+		{
+			name:               "Keep other policy tag",
+			conflictResolution: PolicyTagConflictKeep,
+			update:             tableMaskUpdate{ColumnsToAddMask: set.NewSet("other")},
+			wantTags:           map[string][]string{"untagged": nil, "empty": {}, "tagged": {numberedTag}, "other": {otherTag}},
+			wantWarnings:       []string{`column "project1.ds1.table1.other" keeps its policy tag "` + otherTag + `", so policy tag "` + policyTag + `" is not attached`},
+		},
+		{
+			name:               "Multiple policy tags on the same column",
+			conflictResolution: PolicyTagConflictReplace,
+			assigned:           map[string]string{"project1.ds1.table1.untagged": otherTag},
+			update:             tableMaskUpdate{ColumnsToAddMask: set.NewSet("untagged")},
+			wantTags:           map[string][]string{"untagged": nil, "empty": {}, "tagged": {numberedTag}, "other": {otherTag}},
+			wantConflicts:      []string{`column "project1.ds1.table1.untagged" is targeted by multiple policy tags: "` + otherTag + `" and "` + policyTag + `"`},
+		},
+		{
+			name:               "Only remove own policy tag",
+			conflictResolution: PolicyTagConflictFail,
+			update:             tableMaskUpdate{ColumnsToRemoveMask: set.NewSet("tagged", "other")},
+			wantChanged:        true,
+			wantTags:           map[string][]string{"untagged": nil, "empty": {}, "tagged": {}, "other": {otherTag}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &DataCatalogRepository{projectId: "project1", conflictResolution: tt.conflictResolution, assignedPolicyTags: map[string]string{}}
+
+			for column, tag := range tt.assigned {
+				repo.assignedPolicyTags[column] = tag
+			}
+
+			columns := schema()
+
+			changed, warnings, conflicts := repo.tagColumns(table, columns, policyTag, tt.update)
+
+			assert.Equal(t, tt.wantChanged, changed)
+			assert.Equal(t, tt.wantWarnings, warnings)
+			assert.Equal(t, tt.wantConflicts, conflicts)
+
+			for _, column := range columns {
+				var tags []string
+				if column.PolicyTags != nil {
+					tags = column.PolicyTags.Names
+				}
+
+				assert.Equal(t, tt.wantTags[column.Name], tags, column.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package bigquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
+	"github.com/raito-io/cli/base/util/config"
+	bigquery2 "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/iterator"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/preflight"
+)
+
+var projectRequirements = map[preflight.Feature][]string{
+	preflight.DataSource:    {"resourcemanager.projects.get", "bigquery.datasets.get", "bigquery.tables.list", "bigquery.tables.get"},
+	preflight.AccessWrite:   {"resourcemanager.projects.getIamPolicy", "resourcemanager.projects.setIamPolicy", "bigquery.datasets.update", "bigquery.tables.getIamPolicy", "bigquery.tables.setIamPolicy"},
+	preflight.Masking:       {"datacatalog.taxonomies.get", "datacatalog.taxonomies.create", "datacatalog.taxonomies.update", "bigquery.dataPolicies.create", "bigquery.dataPolicies.update", "bigquery.dataPolicies.setIamPolicy", "bigquery.tables.setCategory"},
+	preflight.Filtering:     {"bigquery.rowAccessPolicies.list", "bigquery.rowAccessPolicies.create", "bigquery.rowAccessPolicies.delete", "bigquery.jobs.create"},
+	preflight.Usage:         {"bigquery.jobs.listAll", "bigquery.jobs.create"},
+	preflight.IdentityStore: {"resourcemanager.projects.getIamPolicy", "iam.serviceAccounts.list"},
+}
+
+// datasetPermission is the only permission verified on sampled datasets, as datasets do not support testIamPermissions.
+const datasetPermission = "bigquery.datasets.get"
+
+var datasetRequirements = map[preflight.Feature][]string{
+	preflight.DataSource: {datasetPermission},
+}
+
+// datasetUnverifiedRequirements are the other permissions the plugin needs on each dataset. They are only verified on the project.
+var datasetUnverifiedRequirements = map[preflight.Feature][]string{
+	preflight.DataSource:  {"bigquery.tables.list"},
+	preflight.AccessWrite: {"bigquery.datasets.update"},
+}
+
+var tableRequirements = map[preflight.Feature][]string{
+	preflight.DataSource:  {"bigquery.tables.get"},
+	preflight.AccessWrite: {"bigquery.tables.getIamPolicy", "bigquery.tables.setIamPolicy"},
+	preflight.Masking:     {"bigquery.tables.setCategory"},
+	preflight.Filtering:   {"bigquery.rowAccessPolicies.list", "bigquery.rowAccessPolicies.create"},
+}
+
+// PreflightSampler provides the project and a sample of its datasets and tables to the preflight check.
+type PreflightSampler struct {
+	projectClient preflight.IamPermissionTester
+	client        *bigquery.Client
+	tablesClient  *bigquery2.TablesService
+
+	projectId  string
+	sampleSize int
+}
+
+func NewPreflightSampler(projectClient *resourcemanager.ProjectsClient, client *bigquery.Client, service *bigquery2.Service, configMap *config.ConfigMap) *PreflightSampler {
+	return &PreflightSampler{
+		projectClient: projectClient,
+		client:        client,
+		tablesClient:  service.Tables,
+
+		projectId:  configMap.GetString(common.GcpProjectId),
+		sampleSize: configMap.GetIntWithDefault(common.GcpPreflightSampleSize, preflight.DefaultSampleSize),
+	}
+}
+
+func (s *PreflightSampler) PreflightResources(ctx context.Context) ([]preflight.Resource, error) {
+	if s.projectId == "" {
+		return nil, fmt.Errorf("parameter %q is not set", common.GcpProjectId)
+	}
+
+	resources := []preflight.Resource{{
+		Name:            fmt.Sprintf("project %q", s.projectId),
+		Requirements:    projectRequirements,
+		TestPermissions: preflight.TestIamPermissions(s.projectClient, "projects/"+s.projectId),
+	}}
+
+	dsIterator := s.client.Datasets(ctx)
+
+	for i := 0; i < s.sampleSize; i++ {
+		ds, err := dsIterator.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		} else if common.IsGoogle403Error(err) {
+			// Reported by the permissions of the project
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("list datasets: %w", err)
+		}
+
+		resources = append(resources, preflight.Resource{
+			Name:            fmt.Sprintf("dataset %q", ds.DatasetID),
+			Requirements:    datasetRequirements,
+			Unverified:      datasetUnverifiedRequirements,
+			TestPermissions: s.testDatasetPermissions(ds),
+		})
+
+		table, err := ds.Tables(ctx).Next()
+		if errors.Is(err, iterator.Done) || common.IsGoogle403Error(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("list tables of dataset %q: %w", ds.DatasetID, err)
+		}
+
+		resources = append(resources, preflight.Resource{
+			Name:            fmt.Sprintf("table %q", table.FullyQualifiedName()),
+			Requirements:    tableRequirements,
+			TestPermissions: s.testTablePermissions(table),
+		})
+	}
+
+	return resources, nil
+}
+
+func (s *PreflightSampler) PreflightApis() []preflight.Api {
+	return []preflight.Api{
+		{Name: "bigquery.googleapis.com", Features: []preflight.Feature{preflight.DataSource, preflight.AccessWrite, preflight.Masking, preflight.Filtering, preflight.Usage}},
+		{Name: "cloudresourcemanager.googleapis.com", Features: []preflight.Feature{preflight.DataSource, preflight.AccessWrite, preflight.IdentityStore}},
+		{Name: "iam.googleapis.com", Features: []preflight.Feature{preflight.IdentityStore}},
+		{Name: "bigquerydatapolicy.googleapis.com", Features: []preflight.Feature{preflight.Masking}},
+		{Name: "datacatalog.googleapis.com", Features: []preflight.Feature{preflight.Masking}},
+	}
+}
+
+func (s *PreflightSampler) testDatasetPermissions(ds *bigquery.Dataset) func(ctx context.Context, permissions []string) ([]string, error) {
+	return func(ctx context.Context, _ []string) ([]string, error) {
+		_, err := ds.Metadata(ctx)
+		if common.IsGoogle403Error(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("get metadata of dataset %q: %w", ds.DatasetID, err)
+		}
+
+		return []string{datasetPermission}, nil
+	}
+}
+
+func (s *PreflightSampler) testTablePermissions(table *bigquery.Table) func(ctx context.Context, permissions []string) ([]string, error) {
+	return func(ctx context.Context, permissions []string) ([]string, error) {
+		resource := fmt.Sprintf("projects/%s/datasets/%s/tables/%s", table.ProjectID, table.DatasetID, table.TableID)
+
+		response, err := s.tablesClient.TestIamPermissions(resource, &bigquery2.TestIamPermissionsRequest{Permissions: permissions}).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("test iam permissions on %q: %w", resource, err)
+		}
+
+		return response.Permissions, nil
+	}
+}
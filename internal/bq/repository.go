@@ -32,13 +32,11 @@ const (
 	specialGroupPrefix   = "special_group:"
 )
 
-var bqPolicyCache = make(map[string][]iam2.IamBinding)
-var bqDataObjectCache = make(map[string][]*org.GcpOrgEntity)
-
 //go:generate go run github.com/vektra/mockery/v2 --name=ProjectClient --with-expecter --inpackage
 type ProjectClient interface {
 	GetIamPolicy(ctx context.Context, projectId string) ([]iam2.IamBinding, error)
 	UpdateBinding(ctx context.Context, dataObject *iam2.DataObjectReference, bindingsToAdd []iam2.IamBinding, bindingsToDelete []iam2.IamBinding) error
+	UpdateProjectLabels(ctx context.Context, projectId string, labels map[string]*string) error
 }
 
 //go:generate go run github.com/vektra/mockery/v2 --name=BigQueryRowAccessPoliciesService --with-expecter --inpackage
@@ -55,21 +53,65 @@ type Repository struct {
 	projectClient   ProjectClient
 	client          *bigquery.Client
 	rowAccessClient BigQueryRowAccessPoliciesService
+	datasetsClient  BigQueryDatasetsService
+	routinesClient  BigQueryRoutinesService
 	projectId       string
 	listHidden      bool
+	catalogEnabled  bool
+
+	informationSchemaCrawl bool
+	isSnapshot             *informationSchemaSnapshot
 
-	options *RepositoryOptions
+	datasetAccessMode string
+	iamModeDatasets   set.Set[string]
+	excludedDatasets  set.Set[string]
+
+	cache       *repositoryCache
+	incremental *incrementalSync
+	options     *RepositoryOptions
 }
 
-func NewRepository(projectClient ProjectClient, client *bigquery.Client, rowAccessClient BigQueryRowAccessPoliciesService, configMap *config.ConfigMap, options *RepositoryOptions) *Repository {
+func NewRepository(projectClient ProjectClient, client *bigquery.Client, rowAccessClient BigQueryRowAccessPoliciesService, datasetsClient BigQueryDatasetsService, routinesClient BigQueryRoutinesService, configMap *config.ConfigMap, options *RepositoryOptions) *Repository {
+	var cache *repositoryCache
+	if options.EnableCache {
+		cache = getRepositoryCache(configMap)
+	}
+
+	iamModeDatasets := set.NewSet[string]()
+
+	if datasets := configMap.GetString(common.BqDatasetIamModeDatasets); datasets != "" {
+		for _, dataset := range strings.Split(datasets, ",") {
+			iamModeDatasets.Add(strings.TrimSpace(dataset))
+		}
+	}
+
+	excludedDatasets := set.NewSet[string]()
+
+	if datasets := configMap.GetString(common.BqExcludedDatasets); datasets != "" {
+		for _, dataset := range strings.Split(datasets, ",") {
+			excludedDatasets.Add(strings.TrimSpace(dataset))
+		}
+	}
+
 	return &Repository{
 		projectClient:   projectClient,
 		client:          client,
 		rowAccessClient: rowAccessClient,
+		datasetsClient:  datasetsClient,
+		routinesClient:  routinesClient,
 		projectId:       configMap.GetString(common.GcpProjectId),
 		listHidden:      configMap.GetBool(common.BqIncludeHiddenDatasets),
+		catalogEnabled:  configMap.GetBoolWithDefault(common.BqCatalogEnabled, false),
+
+		informationSchemaCrawl: configMap.GetBoolWithDefault(common.BqInformationSchemaCrawl, false),
 
-		options: options,
+		datasetAccessMode: configMap.GetStringWithDefault(common.BqDatasetAccessMode, DatasetAccessModeLegacy),
+		iamModeDatasets:   iamModeDatasets,
+		excludedDatasets:  excludedDatasets,
+
+		cache:       cache,
+		incremental: newIncrementalSync(configMap),
+		options:     options,
 	}
 }
 
@@ -94,6 +136,10 @@ func (c *Repository) ListDataSets(ctx context.Context, parent *org.GcpOrgEntity,
 		return err
 	}
 
+	if snapshot := c.informationSchema(ctx); snapshot != nil {
+		return c.listDataSetsFromInformationSchema(ctx, snapshot, parent, fn)
+	}
+
 	dsIterator := c.client.Datasets(ctx)
 	dsIterator.ListHidden = c.listHidden
 
@@ -137,9 +183,7 @@ func (c *Repository) ListDataSets(ctx context.Context, parent *org.GcpOrgEntity,
 		dataObjects = append(dataObjects, &entity)
 	}
 
-	if c.options.EnableCache {
-		bqDataObjectCache[parent.FullName] = dataObjects
-	}
+	c.cacheDataObjects(parent, dataObjects)
 
 	return nil
 }
@@ -151,6 +195,10 @@ func (c *Repository) ListTables(ctx context.Context, ds *bigquery.Dataset, paren
 		return err
 	}
 
+	if isDs := c.informationSchemaDataset(ctx, parent.Name); isDs != nil {
+		return c.listTablesFromInformationSchema(ctx, isDs, parent, fn)
+	}
+
 	if ds == nil {
 		ds = c.client.Dataset(parent.Name)
 
@@ -160,6 +208,12 @@ func (c *Repository) ListTables(ctx context.Context, ds *bigquery.Dataset, paren
 		}
 	}
 
+	if c.incremental != nil {
+		if done, err := c.listTablesIncremental(ctx, ds, parent, fn); done {
+			return err
+		}
+	}
+
 	tIterator := ds.Tables(ctx)
 
 	var dataObjects []*org.GcpOrgEntity
@@ -176,55 +230,70 @@ func (c *Repository) ListTables(ctx context.Context, ds *bigquery.Dataset, paren
 			return fmt.Errorf("table iterator: %w", err)
 		}
 
-		entityType := data_source.Table
-
-		meta, err := tab.Metadata(ctx)
-
-		if common.IsGoogle400Error(err) {
-			common.Logger.Warn(fmt.Sprintf("Encountered 4xx error while fetching metadata for table %q: %s", tab.TableID, err.Error()))
-
+		entity, err := c.tableEntity(ctx, tab, parent)
+		if err != nil {
+			return err
+		} else if entity == nil {
 			continue
-		} else if err != nil {
-			return fmt.Errorf("table metadata: %w", err)
-		}
-
-		if meta.Type == bigquery.ViewTable || meta.Type == bigquery.MaterializedView {
-			entityType = data_source.View
-		}
-
-		id := fmt.Sprintf("%s.%s", parent.Id, tab.TableID)
-
-		entity := org.GcpOrgEntity{
-			Type:        entityType,
-			Name:        tab.TableID,
-			Id:          id,
-			FullName:    id,
-			Description: meta.Description,
-			Parent:      parent,
-			Location:    meta.Location,
-			Tags:        meta.Labels,
 		}
 
-		err = fn(ctx, &entity, tab)
+		err = fn(ctx, entity, tab)
 		if err != nil {
 			return err
 		}
 
-		dataObjects = append(dataObjects, &entity)
+		dataObjects = append(dataObjects, entity)
 	}
 
-	if c.options.EnableCache {
-		bqDataObjectCache[parent.FullName] = dataObjects
-	}
+	c.cacheDataObjects(parent, dataObjects)
 
 	return nil
 }
 
+// tableEntity loads the metadata of a table. If the metadata cannot be loaded due to a 4xx error, the table is skipped and nil is returned.
+func (c *Repository) tableEntity(ctx context.Context, tab *bigquery.Table, parent *org.GcpOrgEntity) (*org.GcpOrgEntity, error) {
+	meta, err := tab.Metadata(ctx)
+
+	if common.IsGoogle400Error(err) {
+		common.Logger.Warn(fmt.Sprintf("Encountered 4xx error while fetching metadata for table %q: %s", tab.TableID, err.Error()))
+
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("table metadata: %w", err)
+	}
+
+	id := fmt.Sprintf("%s.%s", parent.Id, tab.TableID)
+
+	return &org.GcpOrgEntity{
+		Type:        dataObjectTypeForTableType(tableTypeFromMetadata(meta)),
+		Name:        tab.TableID,
+		Id:          id,
+		FullName:    id,
+		Description: meta.Description,
+		Parent:      parent,
+		Location:    meta.Location,
+		Tags:        tableTags(meta),
+	}, nil
+}
+
 func (c *Repository) ListColumns(ctx context.Context, tab *bigquery.Table, parent *org.GcpOrgEntity, fn func(ctx context.Context, entity *org.GcpOrgEntity) error) error {
 	if err, done := c.loadDataObjectsFromCache(ctx, parent, fn); done {
 		return err
 	}
 
+	if err, done := c.loadColumnsFromIncrementalState(ctx, parent, fn); done {
+		return err
+	}
+
+	// Policy tags are not available in the INFORMATION_SCHEMA, so columns are loaded through the API if the catalog is enabled
+	if !c.catalogEnabled {
+		if isDs := c.informationSchemaDataset(ctx, parent.Parent.Name); isDs != nil {
+			if isTab, found := isDs.tables[parent.Name]; found {
+				return c.listColumnsFromInformationSchema(ctx, isTab, parent, fn)
+			}
+		}
+	}
+
 	if tab == nil {
 		ds := c.client.Dataset(parent.Parent.Name)
 
@@ -271,16 +340,15 @@ func (c *Repository) ListColumns(ctx context.Context, tab *bigquery.Table, paren
 		dataObjects = append(dataObjects, &entity)
 	}
 
-	if c.options.EnableCache {
-		bqDataObjectCache[parent.FullName] = dataObjects
-	}
+	c.incremental.recordColumns(parent, dataObjects)
+	c.cacheDataObjects(parent, dataObjects)
 
 	return nil
 }
 
 func (c *Repository) ListViews(ctx context.Context, ds *bigquery.Dataset, parent *org.GcpOrgEntity, fn func(ctx context.Context, entity *org.GcpOrgEntity) error) error {
 	if err, done := c.loadDataObjectsFromCache(ctx, parent, func(ctx context.Context, item *org.GcpOrgEntity) error {
-		if item.Type == data_source.View {
+		if isViewDataObjectType(item.Type) {
 			return fn(ctx, item)
 		}
 
@@ -289,6 +357,16 @@ func (c *Repository) ListViews(ctx context.Context, ds *bigquery.Dataset, parent
 		return err
 	}
 
+	if isDs := c.informationSchemaDataset(ctx, parent.Name); isDs != nil {
+		return c.listTablesFromInformationSchema(ctx, isDs, parent, func(ctx context.Context, entity *org.GcpOrgEntity, _ *bigquery.Table) error {
+			if isViewDataObjectType(entity.Type) {
+				return fn(ctx, entity)
+			}
+
+			return nil
+		})
+	}
+
 	if ds == nil {
 		ds = c.client.Dataset(parent.Id)
 	}
@@ -323,14 +401,14 @@ func (c *Repository) ListViews(ctx context.Context, ds *bigquery.Dataset, parent
 		id := fmt.Sprintf("%s.%s", parent.Id, tab.TableID)
 
 		entity := org.GcpOrgEntity{
-			Type:        data_source.View,
+			Type:        dataObjectTypeForTableType(tableTypeFromMetadata(meta)),
 			Name:        tab.TableID,
 			Id:          id,
 			FullName:    id,
 			Description: meta.Description,
 			Parent:      parent,
 			Location:    meta.Location,
-			Tags:        meta.Labels,
+			Tags:        tableTags(meta),
 		}
 
 		err = fn(ctx, &entity)
@@ -343,10 +421,12 @@ func (c *Repository) ListViews(ctx context.Context, ds *bigquery.Dataset, parent
 }
 
 func (c *Repository) GetBindings(ctx context.Context, entity *org.GcpOrgEntity) ([]iam2.IamBinding, error) {
-	if bindings, found := bqPolicyCache[entity.Id]; c.options.EnableCache && found {
-		common.Logger.Debug(fmt.Sprintf("Found cached bindings for entity %s", entity.Id))
+	if c.cache != nil {
+		if bindings, found := c.cache.policies.Get(entity.Id); found {
+			common.Logger.Debug(fmt.Sprintf("Found cached bindings for entity %s", entity.Id))
 
-		return bindings, nil
+			return bindings, nil
+		}
 	}
 
 	common.Logger.Info(fmt.Sprintf("Fetching BigQuery IAM Policy for %s (%s)", entity.Id, entity.Type))
@@ -361,7 +441,7 @@ func (c *Repository) GetBindings(ctx context.Context, entity *org.GcpOrgEntity)
 		bindings, err = c.projectClient.GetIamPolicy(ctx, c.projectId)
 	case data_source.Dataset:
 		bindings, err = c.getDataSetBindings(ctx, entity, entityIdParts)
-	case data_source.Table, data_source.View:
+	case data_source.Table, data_source.View, TypeExternalTable, TypeSnapshot, TypeClone, TypeMaterializedView:
 		bindings, err = c.getTableBindings(ctx, entity, entityIdParts)
 	case data_source.Column:
 		// Do nothing
@@ -377,8 +457,8 @@ func (c *Repository) GetBindings(ctx context.Context, entity *org.GcpOrgEntity)
 		return nil, err
 	}
 
-	if c.options.EnableCache {
-		bqPolicyCache[entity.Id] = bindings
+	if c.cache != nil {
+		c.cache.policies.Set(entity.Id, bindings)
 	}
 
 	return bindings, nil
@@ -387,6 +467,10 @@ func (c *Repository) GetBindings(ctx context.Context, entity *org.GcpOrgEntity)
 func (c *Repository) UpdateBindings(ctx context.Context, dataObject *iam2.DataObjectReference, addBindings []iam2.IamBinding, removeBindings []iam2.IamBinding) error {
 	entityIdParts := strings.Split(dataObject.FullName, ".")
 
+	if c.cache != nil {
+		c.cache.policies.Invalidate(dataObject.FullName)
+	}
+
 	if len(entityIdParts) == 1 {
 		err := c.projectClient.UpdateBinding(ctx, dataObject, addBindings, removeBindings)
 		if err != nil {
@@ -442,15 +526,15 @@ func (c *Repository) GetDataUsage(ctx context.Context, windowStart *time.Time, u
 		}
 	}
 
-	allViews, err := c.getAllViews(ctx)
+	lineage, err := c.GetViewLineage(ctx, regions)
 	if err != nil {
-		return fmt.Errorf("get all views: %w", err)
+		return fmt.Errorf("get view lineage: %w", err)
 	}
 
 	for r := range regions {
 		common.Logger.Info(fmt.Sprintf("querying INFORMATION_SCHEMA in BigQuery region %s", r))
 
-		err = c.getDataUsage(ctx, strings.ToLower(r), windowStart, usageFirstUsed, usageLastUsed, allViews, fn)
+		err = c.getDataUsage(ctx, strings.ToLower(r), windowStart, usageFirstUsed, usageLastUsed, lineage, fn)
 
 		if common.IsGoogle400Error(err) {
 			common.Logger.Warn(fmt.Sprintf("Encountered 4xx error while querying INFORMATION_SCHEMA in BigQuery region %s: %s", r, err.Error()))
@@ -465,8 +549,8 @@ func (c *Repository) GetDataUsage(ctx context.Context, windowStart *time.Time, u
 }
 
 func (c *Repository) ListFilters(ctx context.Context, table *org.GcpOrgEntity, fn func(ctx context.Context, rap *bigquery2.RowAccessPolicy, users []string, groups []string, internalizable bool) error) error {
-	if table.Type != data_source.Table {
-		return errors.New("only tables can be filtered")
+	if !isFilterableDataObjectType(table.Type) {
+		return fmt.Errorf("data objects of type %s cannot be filtered", table.Type)
 	}
 
 	err := c.rowAccessClient.List(table.Parent.Parent.Name, table.Parent.Name, table.Name).Pages(ctx, func(response *bigquery2.ListRowAccessPoliciesResponse) error {
@@ -554,6 +638,8 @@ func (c *Repository) CreateOrUpdateFilter(ctx context.Context, filter *BQFilter)
 		return fmt.Errorf("create row access policy job: %w", status.Err())
 	}
 
+	c.invalidateTable(&filter.Table)
+
 	return nil
 }
 
@@ -590,25 +676,9 @@ func (c *Repository) DeleteFilter(ctx context.Context, table *BQReferencedTable,
 		return fmt.Errorf("delete row access policy job: %w", status.Err())
 	}
 
-	return nil
-}
-
-func (c *Repository) getAllViews(ctx context.Context) ([]org.GcpOrgEntity, error) {
-	allViews := make([]org.GcpOrgEntity, 0)
-
-	err := c.ListDataSets(ctx, c.Project(), func(ctx context.Context, entity *org.GcpOrgEntity, dataset *bigquery.Dataset) error {
-		return c.ListViews(ctx, dataset, entity, func(ctx context.Context, entity *org.GcpOrgEntity) error {
-			allViews = append(allViews, *entity)
-
-			return nil
-		})
-	})
+	c.invalidateTable(table)
 
-	if err != nil {
-		return nil, err
-	}
-
-	return allViews, nil
+	return nil
 }
 
 // Table based on https://cloud.google.com/bigquery/docs/reference/auditlogs/rest/Shared.Types/BigQueryAuditMetadata.QueryStatementType
@@ -655,7 +725,7 @@ var QueryStatementTypeMap = map[string]data_usage.ActionType{
 	"CALL":                  data_usage.Read,
 }
 
-func (c *Repository) getDataUsage(ctx context.Context, region string, windowStart *time.Time, usageFirstUsed *time.Time, usageLastUsed *time.Time, allViews []org.GcpOrgEntity, fn func(ctx context.Context, entity *BQInformationSchemaEntity) error) error {
+func (c *Repository) getDataUsage(ctx context.Context, region string, windowStart *time.Time, usageFirstUsed *time.Time, usageLastUsed *time.Time, lineage *ViewLineage, fn func(ctx context.Context, entity *BQInformationSchemaEntity) error) error {
 	if usageFirstUsed != nil && usageLastUsed != nil {
 		common.Logger.Info(fmt.Sprintf("Using start date %s, excluding [%s, %s]", windowStart.Format(time.RFC3339), usageFirstUsed.Format(time.RFC3339), usageLastUsed.Format(time.RFC3339)))
 	} else {
@@ -747,19 +817,7 @@ func (c *Repository) getDataUsage(ctx context.Context, region string, windowStar
 			minTime = row.StartTime
 		}
 
-		for viewIdx := range allViews {
-			if strings.Contains(row.Query, allViews[viewIdx].FullName) {
-				row.Tables = append(row.Tables, BQInformationSchemaReferencedTable{
-					Project: bigquery.NullString{StringVal: c.projectId, Valid: true},
-					Dataset: bigquery.NullString{StringVal: strings.Split(allViews[viewIdx].Parent.Id, ".")[1], Valid: true},
-					Table:   bigquery.NullString{StringVal: allViews[viewIdx].Name, Valid: true},
-				})
-
-				common.Logger.Debug(fmt.Sprintf("Query %q contains view %q, adding a reference to it for usage", row.Query, allViews[viewIdx].FullName))
-
-				break
-			}
-		}
+		lineage.AttributeUsage(&row, c.projectId)
 
 		err = fn(ctx, &row)
 		if err != nil {
@@ -777,14 +835,25 @@ func (c *Repository) getDataUsage(ctx context.Context, region string, windowStar
 func (c *Repository) getDataSetBindings(ctx context.Context, entity *org.GcpOrgEntity, entityIdParts []string) ([]iam2.IamBinding, error) {
 	ds := c.client.Dataset(entityIdParts[1])
 
-	dsMeta, err := ds.Metadata(ctx)
+	dsMeta, err := ds.MetadataWithOptions(ctx, bigquery.WithAccessPolicyVersion(datasetAccessPolicyVersion))
 	if err != nil {
 		return nil, fmt.Errorf("metadata of dataset %q: %w", entityIdParts[1], err)
 	}
 
+	if c.useDatasetIamPolicy(entityIdParts[1]) {
+		return datasetIamBindings(entity, dsMeta.Access), nil
+	}
+
 	var resultBindings []iam2.IamBinding
 
 	for _, a := range dsMeta.Access {
+		// Conditional access entries can only be managed through the IAM policy mode
+		if a.Condition != nil {
+			common.Logger.Debug(fmt.Sprintf("Ignoring conditional access entry for %q on dataset %q", a.Entity, entityIdParts[1]))
+
+			continue
+		}
+
 		if a.EntityType == bigquery.UserEmailEntity || a.EntityType == bigquery.GroupEmailEntity || a.EntityType == bigquery.SpecialGroupEntity {
 			prefix := userPrefix
 
@@ -811,17 +880,27 @@ func (c *Repository) getDataSetBindings(ctx context.Context, entity *org.GcpOrgE
 func (c *Repository) updateDatasetBindings(ctx context.Context, dataset string, bindingsToAdd []iam2.IamBinding, bindingsToRemove []iam2.IamBinding) error {
 	ds := c.client.Dataset(dataset)
 
-	dsMeta, err := ds.Metadata(ctx)
+	dsMeta, err := ds.MetadataWithOptions(ctx, bigquery.WithAccessPolicyVersion(datasetAccessPolicyVersion))
 	if err != nil {
 		return fmt.Errorf("metadata of dataset %q: %w", dataset, err)
 	}
 
-	update, err := mergeBindings(dsMeta.Access, bindingsToAdd, bindingsToRemove)
+	merge := mergeBindings
+	if c.useDatasetIamPolicy(dataset) {
+		merge = mergeIamBindings
+	}
+
+	update, err := merge(dsMeta.Access, bindingsToAdd, bindingsToRemove)
+	if err != nil {
+		return err
+	}
+
+	err = iam2.ValidatePolicySize(dataset, datasetIamBindings(&org.GcpOrgEntity{Id: dataset}, update.Access))
 	if err != nil {
 		return err
 	}
 
-	_, err = ds.Update(ctx, *update, dsMeta.ETag)
+	_, err = ds.UpdateWithOptions(ctx, *update, dsMeta.ETag, bigquery.WithAccessPolicyVersion(datasetAccessPolicyVersion))
 	if err != nil {
 		return fmt.Errorf("update dataset %q: %w", dataset, err)
 	}
@@ -845,6 +924,13 @@ func mergeBindings(existingAccess []*bigquery.AccessEntry, bindingsToAdd []iam2.
 
 	// Remove old bindings
 	for _, a := range existingAccess {
+		// Conditional access entries are never removed in the legacy mode
+		if a.Condition != nil {
+			update.Access = append(update.Access, a)
+
+			continue
+		}
+
 		memberId := fmt.Sprintf("%s:%s", entityToString(a.EntityType), a.Entity)
 		role := getRoleForBQEntity(a.Role)
 
@@ -916,6 +1002,11 @@ func (c *Repository) updateTableBindings(ctx context.Context, dataset, table str
 		policy.Add(b.Member, iam.RoleName(b.Role))
 	}
 
+	err = iam2.ValidatePolicySize(fmt.Sprintf("%s.%s", dataset, table), iam2.PolicyBindings(policy.InternalProto, table, data_source.Table))
+	if err != nil {
+		return err
+	}
+
 	err = t.IAM().SetPolicy(ctx, policy)
 	if err != nil {
 		return fmt.Errorf("set policy of '%s.%s': %w", dataset, table, err)
@@ -924,25 +1015,6 @@ func (c *Repository) updateTableBindings(ctx context.Context, dataset, table str
 	return nil
 }
 
-func (c *Repository) loadDataObjectsFromCache(ctx context.Context, parent *org.GcpOrgEntity, fn func(ctx context.Context, item *org.GcpOrgEntity) error) (error, bool) {
-	if !c.options.EnableCache {
-		return nil, false
-	}
-
-	if result, found := bqDataObjectCache[parent.FullName]; found {
-		for _, entity := range result {
-			err := fn(ctx, entity)
-			if err != nil {
-				return err, true
-			}
-		}
-
-		return nil, true
-	}
-
-	return nil, false
-}
-
 func getBQEntityForRole(t string) bigquery.AccessRole {
 	switch t {
 	case "roles/bigquery.dataOwner":
@@ -375,6 +375,27 @@ func TestAccessMerge(t *testing.T) {
 				"user@raito.io|WRITER",
 			},
 		},
+		{
+			Name: "Conditional entries are kept",
+			Existing: []*bigquery.AccessEntry{
+				{
+					Role:       bigquery.ReaderRole,
+					EntityType: bigquery.UserEmailEntity,
+					Entity:     "user@raito.io",
+					Condition:  &bigquery.Expr{Expression: "request.time < timestamp('2030-01-01T00:00:00Z')"},
+				},
+			},
+			ToAdd: []iam.IamBinding{},
+			ToRemove: []iam.IamBinding{
+				{
+					Role:   getRoleForBQEntity(bigquery.ReaderRole),
+					Member: "user:user@raito.io",
+				},
+			},
+			Expected: []string{
+				"user@raito.io|READER",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,158 @@
+package bigquery
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	ds "github.com/raito-io/cli/base/data_source"
+)
+
+// Tag keys of the table metadata that is exposed as tags on the data objects.
+// Keys contain a dot, which is not allowed in BigQuery labels, so they never collide with labels.
+const (
+	TagTableType         = "bq.table_type"
+	TagPartitioningType  = "bq.partitioning_type"
+	TagPartitioningField = "bq.partitioning_field"
+	TagClusteringFields  = "bq.clustering_fields"
+	TagExpirationTime    = "bq.expiration_time"
+	TagNumRows           = "bq.num_rows"
+	TagLogicalBytes      = "bq.logical_bytes"
+	TagLastModifiedTime  = "bq.last_modified_time"
+	TagKmsKeyName        = "bq.kms_key_name"
+	TagViewQuery         = "bq.view_query"
+	TagReferencedTables  = "bq.referenced_tables"
+)
+
+const (
+	TableTypeTable            = "table"
+	TableTypeView             = "view"
+	TableTypeMaterializedView = "materialized_view"
+	TableTypeExternal         = "external"
+	TableTypeSnapshot         = "snapshot"
+	TableTypeClone            = "clone"
+)
+
+// dataObjectTypeForTableType returns the Raito data object type for a table type.
+func dataObjectTypeForTableType(tableType string) string {
+	switch tableType {
+	case TableTypeView:
+		return ds.View
+	case TableTypeMaterializedView:
+		return TypeMaterializedView
+	case TableTypeExternal:
+		return TypeExternalTable
+	case TableTypeSnapshot:
+		return TypeSnapshot
+	case TableTypeClone:
+		return TypeClone
+	default:
+		return ds.Table
+	}
+}
+
+// tableTypeFromMetadata returns the table type of a table as returned by the BigQuery API.
+func tableTypeFromMetadata(meta *bigquery.TableMetadata) string {
+	switch meta.Type {
+	case bigquery.ViewTable:
+		return TableTypeView
+	case bigquery.MaterializedView:
+		return TableTypeMaterializedView
+	case bigquery.ExternalTable:
+		return TableTypeExternal
+	case bigquery.Snapshot:
+		return TableTypeSnapshot
+	}
+
+	if meta.CloneDefinition != nil {
+		return TableTypeClone
+	}
+
+	return TableTypeTable
+}
+
+// tableTypeFromInformationSchema returns the table type of the table_type column in INFORMATION_SCHEMA.TABLES.
+func tableTypeFromInformationSchema(tableType string) string {
+	switch tableType {
+	case "VIEW":
+		return TableTypeView
+	case "MATERIALIZED VIEW":
+		return TableTypeMaterializedView
+	case "EXTERNAL":
+		return TableTypeExternal
+	case "SNAPSHOT":
+		return TableTypeSnapshot
+	case "CLONE":
+		return TableTypeClone
+	default:
+		return TableTypeTable
+	}
+}
+
+// tableTags merges the labels of a table with its metadata tags.
+func tableTags(meta *bigquery.TableMetadata) map[string]string {
+	tags := make(map[string]string, len(meta.Labels)+9)
+
+	for k, v := range meta.Labels {
+		tags[k] = v
+	}
+
+	tags[TagTableType] = tableTypeFromMetadata(meta)
+
+	if meta.TimePartitioning != nil {
+		tags[TagPartitioningType] = string(meta.TimePartitioning.Type)
+
+		if meta.TimePartitioning.Field != "" {
+			tags[TagPartitioningField] = meta.TimePartitioning.Field
+		}
+	} else if meta.RangePartitioning != nil {
+		tags[TagPartitioningType] = "RANGE"
+		tags[TagPartitioningField] = meta.RangePartitioning.Field
+	}
+
+	if meta.Clustering != nil && len(meta.Clustering.Fields) > 0 {
+		tags[TagClusteringFields] = strings.Join(meta.Clustering.Fields, ",")
+	}
+
+	if !meta.ExpirationTime.IsZero() {
+		tags[TagExpirationTime] = formatTagTime(meta.ExpirationTime)
+	}
+
+	if meta.Type != bigquery.ViewTable {
+		tags[TagNumRows] = strconv.FormatUint(meta.NumRows, 10)
+		tags[TagLogicalBytes] = strconv.FormatInt(meta.NumBytes, 10)
+	}
+
+	if !meta.LastModifiedTime.IsZero() {
+		tags[TagLastModifiedTime] = formatTagTime(meta.LastModifiedTime)
+	}
+
+	if meta.EncryptionConfig != nil && meta.EncryptionConfig.KMSKeyName != "" {
+		tags[TagKmsKeyName] = meta.EncryptionConfig.KMSKeyName
+	}
+
+	viewQuery := meta.ViewQuery
+	if meta.MaterializedView != nil {
+		viewQuery = meta.MaterializedView.Query
+	}
+
+	if viewQuery != "" {
+		project, _, _ := strings.Cut(meta.FullID, ":")
+		addViewDefinitionTags(tags, viewQuery, project)
+	}
+
+	return tags
+}
+
+func addViewDefinitionTags(tags map[string]string, query string, project string) {
+	tags[TagViewQuery] = query
+
+	if referencedTables := referencedTablesFromQuery(query, project); len(referencedTables) > 0 {
+		tags[TagReferencedTables] = strings.Join(referencedTables, ",")
+	}
+}
+
+func formatTagTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
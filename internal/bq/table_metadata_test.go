@@ -0,0 +1,140 @@
+package bigquery
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	ds "github.com/raito-io/cli/base/data_source"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_tableTags(t *testing.T) {
+	lastModified := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	expiration := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		meta *bigquery.TableMetadata
+		want map[string]string
+	}{
+		{
+			name: "partitioned and clustered table with cmek",
+			meta: &bigquery.TableMetadata{
+				Type:             bigquery.RegularTable,
+				Labels:           map[string]string{"env": "prod"},
+				TimePartitioning: &bigquery.TimePartitioning{Type: bigquery.DayPartitioningType, Field: "created_at"},
+				Clustering:       &bigquery.Clustering{Fields: []string{"country", "city"}},
+				ExpirationTime:   expiration,
+				NumRows:          42,
+				NumBytes:         1024,
+				LastModifiedTime: lastModified,
+				EncryptionConfig: &bigquery.EncryptionConfig{KMSKeyName: "projects/p/locations/eu/keyRings/r/cryptoKeys/k"},
+			},
+			want: map[string]string{
+				"env":                "prod",
+				TagTableType:         TableTypeTable,
+				TagPartitioningType:  "DAY",
+				TagPartitioningField: "created_at",
+				TagClusteringFields:  "country,city",
+				TagExpirationTime:    "2025-01-01T00:00:00Z",
+				TagNumRows:           "42",
+				TagLogicalBytes:      "1024",
+				TagLastModifiedTime:  "2024-03-01T12:30:00Z",
+				TagKmsKeyName:        "projects/p/locations/eu/keyRings/r/cryptoKeys/k",
+			},
+		},
+		{
+			name: "range partitioned clone",
+			meta: &bigquery.TableMetadata{
+				Type:              bigquery.RegularTable,
+				RangePartitioning: &bigquery.RangePartitioning{Field: "id"},
+				CloneDefinition:   &bigquery.CloneDefinition{},
+				LastModifiedTime:  lastModified,
+			},
+			want: map[string]string{
+				TagTableType:         TableTypeClone,
+				TagPartitioningType:  "RANGE",
+				TagPartitioningField: "id",
+				TagNumRows:           "0",
+				TagLogicalBytes:      "0",
+				TagLastModifiedTime:  "2024-03-01T12:30:00Z",
+			},
+		},
+		{
+			name: "view",
+			meta: &bigquery.TableMetadata{
+				FullID:           "project1:dataset1.view1",
+				Type:             bigquery.ViewTable,
+				ViewQuery:        "SELECT * FROM dataset1.table1",
+				LastModifiedTime: lastModified,
+			},
+			want: map[string]string{
+				TagTableType:        TableTypeView,
+				TagLastModifiedTime: "2024-03-01T12:30:00Z",
+				TagViewQuery:        "SELECT * FROM dataset1.table1",
+				TagReferencedTables: "project1.dataset1.table1",
+			},
+		},
+		{
+			name: "materialized view",
+			meta: &bigquery.TableMetadata{
+				FullID:           "project1:dataset1.mv1",
+				Type:             bigquery.MaterializedView,
+				MaterializedView: &bigquery.MaterializedViewDefinition{Query: "SELECT a, COUNT(*) FROM `project2.dataset1.table1` GROUP BY a"},
+				NumRows:          5,
+				NumBytes:         10,
+			},
+			want: map[string]string{
+				TagTableType:        TableTypeMaterializedView,
+				TagNumRows:          "5",
+				TagLogicalBytes:     "10",
+				TagViewQuery:        "SELECT a, COUNT(*) FROM `project2.dataset1.table1` GROUP BY a",
+				TagReferencedTables: "project2.dataset1.table1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tableTags(tt.meta))
+		})
+	}
+}
+
+func Test_tableTypeFromInformationSchema(t *testing.T) {
+	tests := []struct {
+		tableType string
+		want      string
+	}{
+		{tableType: "BASE TABLE", want: TableTypeTable},
+		{tableType: "VIEW", want: TableTypeView},
+		{tableType: "MATERIALIZED VIEW", want: TableTypeMaterializedView},
+		{tableType: "EXTERNAL", want: TableTypeExternal},
+		{tableType: "SNAPSHOT", want: TableTypeSnapshot},
+		{tableType: "CLONE", want: TableTypeClone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tableType, func(t *testing.T) {
+			assert.Equal(t, tt.want, tableTypeFromInformationSchema(tt.tableType))
+		})
+	}
+}
+
+func Test_dataObjectTypeForTableType(t *testing.T) {
+	tests := []struct {
+		tableType string
+		want      string
+	}{
+		{tableType: TableTypeTable, want: ds.Table},
+		{tableType: TableTypeView, want: ds.View},
+		{tableType: TableTypeMaterializedView, want: TypeMaterializedView},
+		{tableType: TableTypeExternal, want: TypeExternalTable},
+		{tableType: TableTypeSnapshot, want: TypeSnapshot},
+		{tableType: TableTypeClone, want: TypeClone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tableType, func(t *testing.T) {
+			assert.Equal(t, tt.want, dataObjectTypeForTableType(tt.tableType))
+		})
+	}
+}
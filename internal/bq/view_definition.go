@@ -0,0 +1,48 @@
+package bigquery
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/raito-io/golang-set/set"
+)
+
+var (
+	sqlCommentRegex     = regexp.MustCompile(`(?s)--[^\n]*|#[^\n]*|/\*.*?\*/`)
+	sqlStringRegex      = regexp.MustCompile(`(?s)'''.*?'''|"""(?:.*?)"""|'(?:[^'\\]|\\.)*'|"(?:[^"\\]|\\.)*"`)
+	sqlTableReference   = regexp.MustCompile("(?i)\\b(?:FROM|JOIN)\\s+((?:`[^`]+`|[A-Za-z_][\\w-]*)(?:\\s*\\.\\s*(?:`[^`]+`|[A-Za-z_][\\w-]*))*)")
+	sqlIdentifierQuotes = strings.NewReplacer("`", "", " ", "", "\t", "", "\n", "", "\r", "")
+)
+
+// referencedTablesFromQuery extracts the full names (project.dataset.table) of the tables and views that are referenced in the FROM and JOIN clauses of a query.
+// Unqualified names (e.g. common table expressions) are ignored and references without project are resolved to the given default project.
+func referencedTablesFromQuery(query string, defaultProject string) []string {
+	query = sqlCommentRegex.ReplaceAllString(query, " ")
+	query = sqlStringRegex.ReplaceAllString(query, "''")
+
+	references := set.NewSet[string]()
+
+	for _, match := range sqlTableReference.FindAllStringSubmatch(query, -1) {
+		parts := strings.Split(sqlIdentifierQuotes.Replace(match[1]), ".")
+
+		switch len(parts) {
+		case 2:
+			parts = append([]string{defaultProject}, parts...)
+		case 3:
+		default:
+			continue
+		}
+
+		if strings.EqualFold(parts[1], "INFORMATION_SCHEMA") || strings.HasPrefix(strings.ToLower(parts[1]), "region-") {
+			continue
+		}
+
+		references.Add(strings.Join(parts, "."))
+	}
+
+	result := references.Slice()
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,49 @@
+package bigquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_referencedTablesFromQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "fully qualified backquoted name",
+			query: "SELECT * FROM `project1.dataset1.table1`",
+			want:  []string{"project1.dataset1.table1"},
+		},
+		{
+			name:  "separately quoted parts",
+			query: "SELECT * FROM `project-2`.`dataset1`.`table1` t",
+			want:  []string{"project-2.dataset1.table1"},
+		},
+		{
+			name:  "dataset qualified name resolves to default project",
+			query: "SELECT a FROM dataset1.table1 JOIN dataset2.table2 USING (a)",
+			want:  []string{"project1.dataset1.table1", "project1.dataset2.table2"},
+		},
+		{
+			name: "common table expressions, comments and strings are ignored",
+			query: `WITH cte AS (SELECT * FROM dataset1.table1)
+				-- SELECT * FROM dataset1.commented
+				SELECT "FROM dataset1.quoted" FROM cte LEFT JOIN ` + "`project1.dataset2.table2`" + ` ON true
+				/* JOIN dataset1.block_comment */`,
+			want: []string{"project1.dataset1.table1", "project1.dataset2.table2"},
+		},
+		{
+			name:  "information schema is ignored",
+			query: "SELECT * FROM `region-eu`.INFORMATION_SCHEMA.JOBS",
+			want:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, referencedTablesFromQuery(tt.query, "project1"))
+		})
+	}
+}
@@ -17,24 +17,34 @@ var Wired = wire.NewSet(
 	NewBiqQueryClient,
 	NewPolicyTagClient,
 	NewDataPolicyClient,
+	NewDataCatalogClient,
 	NewServiceClient,
 	NewRowAccessClient,
+	NewDatasetsClient,
+	NewRoutinesClient,
 
 	NewRepository,
 	NewDataCatalogRepository,
+	NewCatalogTagRepository,
 	NewDataObjectIterator,
 	NewBqFilteringService,
 
 	NewBqMaskingService,
 
+	NewPreflightSampler,
+
 	NewDataSourceMetaData,
 	NewIdentityStoreMetadata,
 
 	wire.Bind(new(maskingDataCatalogRepository), new(*DataCatalogRepository)),
 	wire.Bind(new(dataCatalogBqRepository), new(*Repository)),
 	wire.Bind(new(filteringRepository), new(*Repository)),
+	wire.Bind(new(maskingRoutineRepository), new(*Repository)),
+	wire.Bind(new(catalogTagRepository), new(*CatalogTagRepository)),
 	wire.Bind(new(filteringDataObjectIterator), new(*DataObjectIterator)),
 	wire.Bind(new(BigQueryRowAccessPoliciesService), new(*bigquery2.RowAccessPoliciesService)),
+	wire.Bind(new(BigQueryDatasetsService), new(*bigquery2.DatasetsService)),
+	wire.Bind(new(BigQueryRoutinesService), new(*bigquery2.RoutinesService)),
 )
 
 // TESTING
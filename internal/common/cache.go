@@ -0,0 +1,173 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type cacheEntry[T any] struct {
+	Key       string    `json:"key"`
+	Value     T         `json:"value"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func (e *cacheEntry[T]) expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && now.After(e.ExpiresAt)
+}
+
+// Cache is a thread-safe key-value cache of which the entries expire after a TTL.
+// If a directory is provided, all entries are also persisted in that directory (one file per key), so they can be reused by later runs.
+// Failures of the on-disk store are logged and never returned, as the cache can always be rebuilt.
+type Cache[T any] struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	dir     string
+	entries map[string]*cacheEntry[T]
+
+	now func() time.Time
+}
+
+// NewCache creates a new cache. A TTL of 0 means entries never expire. An empty directory means the cache is only kept in memory.
+func NewCache[T any](ttl time.Duration, dir string) *Cache[T] {
+	return &Cache[T]{
+		ttl:     ttl,
+		dir:     dir,
+		entries: make(map[string]*cacheEntry[T]),
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached value for a key. The in-memory entries are checked first, after which the on-disk store is checked.
+func (c *Cache[T]) Get(key string) (T, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, found := c.entries[key]
+	if !found {
+		entry = c.readEntry(key)
+	}
+
+	if entry == nil || entry.expired(c.now()) {
+		c.invalidate(key)
+
+		var empty T
+
+		return empty, false
+	}
+
+	c.entries[key] = entry
+
+	return entry.Value, true
+}
+
+// Set stores a value for a key, replacing any previously cached value.
+func (c *Cache[T]) Set(key string, value T) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry := &cacheEntry[T]{
+		Key:   key,
+		Value: value,
+	}
+
+	if c.ttl > 0 {
+		entry.ExpiresAt = c.now().Add(c.ttl)
+	}
+
+	c.entries[key] = entry
+	c.writeEntry(entry)
+}
+
+// Invalidate removes a key from the cache and the on-disk store.
+func (c *Cache[T]) Invalidate(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.invalidate(key)
+}
+
+func (c *Cache[T]) invalidate(key string) {
+	delete(c.entries, key)
+
+	if c.dir == "" {
+		return
+	}
+
+	err := os.Remove(c.entryFile(key))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		Logger.Warn(fmt.Sprintf("Unable to remove cache entry %q: %s", key, err.Error()))
+	}
+}
+
+func (c *Cache[T]) entryFile(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:])+".json")
+}
+
+func (c *Cache[T]) readEntry(key string) *cacheEntry[T] {
+	if c.dir == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(c.entryFile(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		Logger.Warn(fmt.Sprintf("Unable to read cache entry %q: %s", key, err.Error()))
+
+		return nil
+	}
+
+	var entry cacheEntry[T]
+
+	err = json.Unmarshal(content, &entry)
+	if err != nil || entry.Key != key {
+		Logger.Warn(fmt.Sprintf("Ignoring invalid cache entry %q", key))
+
+		return nil
+	}
+
+	return &entry
+}
+
+func (c *Cache[T]) writeEntry(entry *cacheEntry[T]) {
+	if c.dir == "" {
+		return
+	}
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		Logger.Warn(fmt.Sprintf("Unable to marshal cache entry %q: %s", entry.Key, err.Error()))
+
+		return
+	}
+
+	err = os.MkdirAll(c.dir, 0700)
+	if err != nil {
+		Logger.Warn(fmt.Sprintf("Unable to create cache directory %q: %s", c.dir, err.Error()))
+
+		return
+	}
+
+	// Write to a temporary file first, so concurrent runs never read a partially written entry
+	file := c.entryFile(entry.Key)
+	tmpFile := fmt.Sprintf("%s.%d.tmp", file, os.Getpid())
+
+	err = os.WriteFile(tmpFile, content, 0600)
+	if err == nil {
+		err = os.Rename(tmpFile, file)
+	}
+
+	if err != nil {
+		Logger.Warn(fmt.Sprintf("Unable to write cache entry %q: %s", entry.Key, err.Error()))
+	}
+}
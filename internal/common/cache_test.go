@@ -0,0 +1,99 @@
+package common
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_GetSet(t *testing.T) {
+	cache := NewCache[[]string](0, "")
+
+	_, found := cache.Get("key")
+	assert.False(t, found)
+
+	cache.Set("key", []string{"value1", "value2"})
+
+	value, found := cache.Get("key")
+	assert.True(t, found)
+	assert.Equal(t, []string{"value1", "value2"}, value)
+
+	cache.Invalidate("key")
+
+	_, found = cache.Get("key")
+	assert.False(t, found)
+}
+
+func TestCache_Ttl(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cache := NewCache[string](time.Hour, "")
+	cache.now = func() time.Time { return now }
+
+	cache.Set("key", "value")
+
+	now = now.Add(59 * time.Minute)
+
+	value, found := cache.Get("key")
+	assert.True(t, found)
+	assert.Equal(t, "value", value)
+
+	now = now.Add(2 * time.Minute)
+
+	_, found = cache.Get("key")
+	assert.False(t, found)
+}
+
+func TestCache_OnDiskStore(t *testing.T) {
+	dir := t.TempDir()
+
+	cache := NewCache[map[string]int](time.Hour, dir)
+	cache.Set("key1", map[string]int{"a": 1})
+	cache.Set("key2", map[string]int{"b": 2})
+	cache.Invalidate("key2")
+
+	// A new cache (e.g. a later run) reads the persisted entries
+	otherCache := NewCache[map[string]int](time.Hour, dir)
+
+	value, found := otherCache.Get("key1")
+	assert.True(t, found)
+	assert.Equal(t, map[string]int{"a": 1}, value)
+
+	_, found = otherCache.Get("key2")
+	assert.False(t, found)
+
+	// Expired entries on disk are ignored
+	otherCache = NewCache[map[string]int](time.Hour, dir)
+	otherCache.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	_, found = otherCache.Get("key1")
+	assert.False(t, found)
+}
+
+func TestCache_Concurrency(t *testing.T) {
+	cache := NewCache[int](time.Hour, t.TempDir())
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			key := fmt.Sprintf("key%d", i%5)
+
+			cache.Set(key, i)
+			cache.Get(key)
+
+			if i%3 == 0 {
+				cache.Invalidate(key)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
@@ -1,23 +1,56 @@
 package common
 
 const (
-	GcpSAFileLocation                       = "gcp-serviceaccount-json-location"
-	GcpOrgId                                = "gcp-organization-id"
-	GcpProjectId                            = "gcp-project-id"
-	GsuiteIdentityStoreSync                 = "gsuite-identity-store-sync"
-	GsuiteImpersonateSubject                = "gsuite-impersonate-subject"
-	GsuiteCustomerId                        = "gsuite-customer-id"
-	ExcludeNonAplicablePermissions          = "skip-non-applicable-permissions"
-	GcpRolesToGroupByIdentity               = "gcp-roles-to-group-by-identity"
-	GcpMaskedReader                         = "gcp-masked-reader"
-	GcpIncludePaths                         = "gcp-include-paths"
-	GcpExcludePaths                         = "gcp-exclude-paths"
-	GcpServiceAccountsInIdentitySyncEnabled = "gcp-service-accounts-in-identity-sync-enabled"
+	GcpSAFileLocation                        = "gcp-serviceaccount-json-location"
+	GcpCredentialsMode                       = "gcp-credentials-mode"
+	GcpImpersonateServiceAccount             = "gcp-impersonate-service-account"
+	GcpImpersonateDelegates                  = "gcp-impersonate-delegates"
+	GcpOrgId                                 = "gcp-organization-id"
+	GcpProjectId                             = "gcp-project-id"
+	GsuiteIdentityStoreSync                  = "gsuite-identity-store-sync"
+	GsuiteImpersonateSubject                 = "gsuite-impersonate-subject"
+	GsuiteCustomerId                         = "gsuite-customer-id"
+	ExcludeNonAplicablePermissions           = "skip-non-applicable-permissions"
+	GcpRolesToGroupByIdentity                = "gcp-roles-to-group-by-identity"
+	GcpMaskedReader                          = "gcp-masked-reader"
+	GcpIncludePaths                          = "gcp-include-paths"
+	GcpExcludePaths                          = "gcp-exclude-paths"
+	GcpServiceAccountsInIdentitySyncEnabled  = "gcp-service-accounts-in-identity-sync-enabled"
+	GcpMetadataWriteBack                     = "gcp-metadata-write-back"
+	GcpManagedGroups                         = "gcp-managed-groups"
+	GcpManagedGroupsDomain                   = "gcp-managed-groups-domain"
+	GcpManagedGroupsPrefix                   = "gcp-managed-groups-prefix"
+	GcpManagedGroupsThreshold                = "gcp-managed-groups-threshold"
+	GcpAccessMaxRemovedBindings              = "gcp-access-max-removed-bindings"
+	GcpAccessMaxRemovedBindingsPercentage    = "gcp-access-max-removed-bindings-percentage"
+	GcpAccessMaxRemovedBindingsPerDataObject = "gcp-access-max-removed-bindings-per-data-object"
+	GcpProtectedPrincipals                   = "gcp-protected-principals"
+	GcpProtectedRoles                        = "gcp-protected-roles"
+	GcpAccessGuardrailMode                   = "gcp-access-guardrail-mode"
+	GcpPreflightSampleSize                   = "gcp-preflight-sample-size"
+	GcpStateDir                              = "gcp-state-dir"
+	GcpMaskingEnabled                        = "gcp-masking-enabled"
+	GcpMaskingLocations                      = "gcp-masking-locations"
 
-	BqExcludedDatasets      = "bq-excluded-datasets"
-	BqIncludeHiddenDatasets = "bq-include-hidden-datasets"
-	BqDataUsageWindow       = "bq-data-usage-window"
-	BqCatalogEnabled        = "bq-catalog-enabled"
+	BqExcludedDatasets            = "bq-excluded-datasets"
+	BqIncludeHiddenDatasets       = "bq-include-hidden-datasets"
+	BqDataUsageWindow             = "bq-data-usage-window"
+	BqCatalogEnabled              = "bq-catalog-enabled"
+	BqCatalogTagsEnabled          = "bq-catalog-tags-enabled"
+	BqInformationSchemaCrawl      = "bq-information-schema-crawl"
+	BqCacheTtl                    = "bq-cache-ttl"
+	BqCacheDir                    = "bq-cache-dir"
+	BqIncrementalSync             = "bq-incremental-sync"
+	BqIncrementalSyncDir          = "bq-incremental-sync-dir"
+	BqFullSyncInterval            = "bq-full-sync-interval"
+	BqForceFullSync               = "bq-force-full-sync"
+	BqDatasetAccessMode           = "bq-dataset-access-mode"
+	BqDatasetIamModeDatasets      = "bq-dataset-iam-mode-datasets"
+	BqMaskingTaxonomies           = "bq-masking-taxonomies"
+	BqMaskingParentPolicyTags     = "bq-masking-parent-policy-tags"
+	BqPolicyTagConflictResolution = "bq-policy-tag-conflict-resolution"
+	BqMaskDefaultValueFallback    = "bq-mask-default-value-fallback"
+	BqMaskDeleteOrphaned          = "bq-mask-delete-orphaned"
 
 	TagSource = "gcp"
 )
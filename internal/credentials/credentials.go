@@ -0,0 +1,179 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/raito-io/cli/base/util/config"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+)
+
+const (
+	// ModeAuto uses the file in gcp-serviceaccount-json-location if set, and Application Default Credentials otherwise.
+	ModeAuto = "auto"
+	// ModeServiceAccountKey requires a service account key file.
+	ModeServiceAccountKey = "service-account-key"
+	// ModeExternalAccount requires an external account (workload identity federation) configuration file.
+	ModeExternalAccount = "external-account"
+	// ModeADC uses Application Default Credentials: GOOGLE_APPLICATION_CREDENTIALS, the gcloud credentials or the metadata server (e.g. GKE workload identity).
+	ModeADC = "adc"
+
+	serviceAccountType  = "service_account"
+	externalAccountType = "external_account"
+)
+
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// New returns the credentials configured for the plugin with the given scopes.
+// If gcp-impersonate-service-account is set, the credentials impersonate that service account, optionally through the chain of gcp-impersonate-delegates.
+func New(ctx context.Context, configMap *config.ConfigMap, scopes ...string) (*google.Credentials, error) {
+	return NewWithSubject(ctx, configMap, "", scopes...)
+}
+
+// NewWithSubject returns credentials that act on behalf of the subject, using domain wide delegation.
+// Domain wide delegation requires a service account key or service account impersonation.
+func NewWithSubject(ctx context.Context, configMap *config.ConfigMap, subject string, scopes ...string) (*google.Credentials, error) {
+	if len(scopes) == 0 {
+		scopes = []string{cloudPlatformScope}
+	}
+
+	targetPrincipal := configMap.GetString(common.GcpImpersonateServiceAccount)
+
+	if targetPrincipal == "" {
+		creds, err := baseCredentials(ctx, configMap, google.CredentialsParams{Scopes: scopes, Subject: subject})
+		if err != nil {
+			return nil, err
+		}
+
+		if subject != "" && credentialsType(creds.JSON) != serviceAccountType {
+			return nil, fmt.Errorf("domain wide delegation requires a service account key or %q", common.GcpImpersonateServiceAccount)
+		}
+
+		return creds, nil
+	}
+
+	creds, err := baseCredentials(ctx, configMap, google.CredentialsParams{Scopes: []string{cloudPlatformScope}})
+	if err != nil {
+		return nil, err
+	}
+
+	tokenSource, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: targetPrincipal,
+		Scopes:          scopes,
+		Delegates:       parseList(configMap.GetString(common.GcpImpersonateDelegates)),
+		Subject:         subject,
+	}, option.WithCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("impersonate service account %q: %w", targetPrincipal, err)
+	}
+
+	return &google.Credentials{
+		ProjectID:   creds.ProjectID,
+		TokenSource: tokenSource,
+	}, nil
+}
+
+// ClientOption returns the option to create a Google API client with the credentials of the plugin.
+func ClientOption(ctx context.Context, configMap *config.ConfigMap, scopes ...string) (option.ClientOption, error) {
+	creds, err := New(ctx, configMap, scopes...)
+	if err != nil {
+		return nil, err
+	}
+
+	return option.WithCredentials(creds), nil
+}
+
+func baseCredentials(ctx context.Context, configMap *config.ConfigMap, params google.CredentialsParams) (*google.Credentials, error) {
+	mode := configMap.GetStringWithDefault(common.GcpCredentialsMode, ModeAuto)
+	location := configMap.GetString(common.GcpSAFileLocation)
+
+	switch mode {
+	case ModeAuto:
+		if location == "" {
+			return defaultCredentials(ctx, params)
+		}
+
+		return fileCredentials(ctx, location, "", params)
+	case ModeADC:
+		return defaultCredentials(ctx, params)
+	case ModeServiceAccountKey, ModeExternalAccount:
+		if location == "" {
+			location = os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+		}
+
+		if location == "" {
+			return nil, fmt.Errorf("credentials mode %q requires %q or GOOGLE_APPLICATION_CREDENTIALS to be set", mode, common.GcpSAFileLocation)
+		}
+
+		expectedType := serviceAccountType
+		if mode == ModeExternalAccount {
+			expectedType = externalAccountType
+		}
+
+		return fileCredentials(ctx, location, expectedType, params)
+	default:
+		return nil, fmt.Errorf("unsupported credentials mode %q", mode)
+	}
+}
+
+func defaultCredentials(ctx context.Context, params google.CredentialsParams) (*google.Credentials, error) {
+	creds, err := google.FindDefaultCredentialsWithParams(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("find application default credentials: %w", err)
+	}
+
+	return creds, nil
+}
+
+func fileCredentials(ctx context.Context, location string, expectedType string, params google.CredentialsParams) (*google.Credentials, error) {
+	credentialsJSON, err := os.ReadFile(location)
+	if err != nil {
+		return nil, fmt.Errorf("read file %q: %w", location, err)
+	}
+
+	if expectedType != "" {
+		if t := credentialsType(credentialsJSON); t != expectedType {
+			return nil, fmt.Errorf("credentials file %q has type %q, expected %q", location, t, expectedType)
+		}
+	}
+
+	creds, err := google.CredentialsFromJSONWithParams(ctx, credentialsJSON, params)
+	if err != nil {
+		return nil, fmt.Errorf("create credentials from file %q: %w", location, err)
+	}
+
+	return creds, nil
+}
+
+// credentialsType returns the type of a credentials JSON file (e.g. service_account), or an empty string if unknown.
+func credentialsType(credentialsJSON []byte) string {
+	var f struct {
+		Type string `json:"type"`
+	}
+
+	if len(credentialsJSON) == 0 || json.Unmarshal(credentialsJSON, &f) != nil {
+		return ""
+	}
+
+	return f.Type
+}
+
+func parseList(value string) []string {
+	var result []string
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,328 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	datapolicies "cloud.google.com/go/bigquery/datapolicies/apiv1"
+	"cloud.google.com/go/bigquery/datapolicies/apiv1/datapoliciespb"
+	datacatalog "cloud.google.com/go/datacatalog/apiv1"
+	"cloud.google.com/go/datacatalog/apiv1/datacatalogpb"
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	bigquery "github.com/raito-io/cli-plugin-gcp/internal/bq"
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/common/roles"
+	"github.com/raito-io/cli-plugin-gcp/internal/iam"
+)
+
+// projectNumberRegex matches the project number in resource names returned by the data policy and policy tag APIs
+var projectNumberRegex = regexp.MustCompile(`projects/\d*/`)
+
+// DataPolicyMask is a BigQuery data policy of a project in the organization, together with its policy tag.
+type DataPolicyMask struct {
+	bigquery.BQMaskingInformation
+
+	ProjectId string
+	Location  string
+
+	// Display name of the taxonomy of the policy tag
+	Taxonomy string
+}
+
+// DataPolicyRepository lists and updates the BigQuery data policies and policy tags of any project in the organization.
+type DataPolicyRepository struct {
+	policyTagClient  *datacatalog.PolicyTagManagerClient
+	policyTagReaders *bigquery.PolicyTagReaders
+	dataPolicyClient *datapolicies.DataPolicyClient
+}
+
+func NewDataPolicyRepository(tagClient *datacatalog.PolicyTagManagerClient, dataPolicyClient *datapolicies.DataPolicyClient) *DataPolicyRepository {
+	return &DataPolicyRepository{
+		policyTagClient:  tagClient,
+		policyTagReaders: bigquery.NewPolicyTagReaders(tagClient),
+		dataPolicyClient: dataPolicyClient,
+	}
+}
+
+// ListMasks enumerates the taxonomies and data masking policies of the project in the location.
+// Projects of which the data policies cannot be listed, e.g. because the API is not enabled, are skipped with a warning.
+func (r *DataPolicyRepository) ListMasks(ctx context.Context, projectId string, location string) ([]DataPolicyMask, error) {
+	parent := fmt.Sprintf("projects/%s/locations/%s", projectId, location)
+
+	common.Logger.Info(fmt.Sprintf("Listing taxonomies and data policies of project %s in location %s", projectId, location))
+
+	policyTags, err := r.listPolicyTags(ctx, projectId, parent)
+	if isSkippableProjectError(err) {
+		common.Logger.Warn(fmt.Sprintf("Unable to list taxonomies of %q. Data policies of this project are skipped: %s", parent, err.Error()))
+
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var result []DataPolicyMask
+
+	it := r.dataPolicyClient.ListDataPolicies(ctx, &datapoliciespb.ListDataPoliciesRequest{
+		Parent: parent,
+	})
+
+	for {
+		policy, err2 := it.Next()
+
+		if errors.Is(err2, iterator.Done) {
+			break
+		} else if isSkippableProjectError(err2) {
+			common.Logger.Warn(fmt.Sprintf("Unable to list data policies of %q. Data policies of this project are skipped: %s", parent, err2.Error()))
+
+			return nil, nil
+		} else if err2 != nil {
+			return nil, fmt.Errorf("list data policy iterator of %q: %w", parent, err2)
+		}
+
+		if policy.DataPolicyType != datapoliciespb.DataPolicy_DATA_MASKING_POLICY {
+			continue
+		}
+
+		policyTag, found := policyTags[normalizeProjectNumber(policy.GetPolicyTag(), projectId)]
+		if !found {
+			common.Logger.Warn(fmt.Sprintf("Policy tag %q of data policy %q not found. This data policy will be ignored.", policy.GetPolicyTag(), policy.GetName()))
+
+			continue
+		}
+
+		result = append(result, DataPolicyMask{
+			BQMaskingInformation: bigquery.BQMaskingInformation{
+				DataPolicy: dataPolicy(policy, projectId),
+				PolicyTag:  policyTag.tag,
+			},
+			ProjectId: projectId,
+			Location:  location,
+			Taxonomy:  policyTag.taxonomy,
+		})
+	}
+
+	return result, nil
+}
+
+type taxonomyPolicyTag struct {
+	tag      bigquery.BQPolicyTag
+	taxonomy string
+}
+
+// listPolicyTags returns the policy tags of all taxonomies in the location, by their name.
+func (r *DataPolicyRepository) listPolicyTags(ctx context.Context, projectId string, parent string) (map[string]taxonomyPolicyTag, error) {
+	result := make(map[string]taxonomyPolicyTag)
+
+	taxonomyIt := r.policyTagClient.ListTaxonomies(ctx, &datacatalogpb.ListTaxonomiesRequest{Parent: parent})
+
+	for {
+		taxonomy, err := taxonomyIt.Next()
+
+		if errors.Is(err, iterator.Done) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("list taxonomy iterator of %q: %w", parent, err)
+		}
+
+		tagIt := r.policyTagClient.ListPolicyTags(ctx, &datacatalogpb.ListPolicyTagsRequest{Parent: taxonomy.GetName()})
+
+		for {
+			policyTag, err2 := tagIt.Next()
+
+			if errors.Is(err2, iterator.Done) {
+				break
+			} else if err2 != nil {
+				return nil, fmt.Errorf("list policy tag iterator of %q: %w", taxonomy.GetName(), err2)
+			}
+
+			name := normalizeProjectNumber(policyTag.GetName(), projectId)
+			parentTag := normalizeProjectNumber(policyTag.GetParentPolicyTag(), projectId)
+
+			result[name] = taxonomyPolicyTag{
+				tag: bigquery.BQPolicyTag{
+					FullName:    name,
+					Name:        policyTag.GetDisplayName(),
+					Description: policyTag.GetDescription(),
+					ParentTag:   parentTag,
+				},
+				taxonomy: taxonomy.GetDisplayName(),
+			}
+
+			r.policyTagReaders.SetParent(name, parentTag)
+		}
+	}
+
+	return result, nil
+}
+
+// GetMask returns the data policy and its policy tag. Nil is returned if the data policy or policy tag does not exist.
+func (r *DataPolicyRepository) GetMask(ctx context.Context, dataPolicyId string) (*DataPolicyMask, error) {
+	policy, err := r.dataPolicyClient.GetDataPolicy(ctx, &datapoliciespb.GetDataPolicyRequest{Name: dataPolicyId})
+	if isNotFoundError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("get data policy %q: %w", dataPolicyId, err)
+	}
+
+	projectId, location := resourceProjectAndLocation(dataPolicyId)
+
+	policyTag, err := r.policyTagClient.GetPolicyTag(ctx, &datacatalogpb.GetPolicyTagRequest{Name: policy.GetPolicyTag()})
+	if isNotFoundError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("get policy tag %q: %w", policy.GetPolicyTag(), err)
+	}
+
+	return &DataPolicyMask{
+		BQMaskingInformation: bigquery.BQMaskingInformation{
+			DataPolicy: dataPolicy(policy, projectId),
+			PolicyTag: bigquery.BQPolicyTag{
+				FullName:    normalizeProjectNumber(policyTag.GetName(), projectId),
+				Name:        policyTag.GetDisplayName(),
+				Description: policyTag.GetDescription(),
+				ParentTag:   normalizeProjectNumber(policyTag.GetParentPolicyTag(), projectId),
+			},
+		},
+		ProjectId: projectId,
+		Location:  location,
+	}, nil
+}
+
+// UpdateMaskingType updates the masking rule of the data policy.
+func (r *DataPolicyRepository) UpdateMaskingType(ctx context.Context, dataPolicyId string, maskingType bigquery.BQMaskingType) error {
+	_, err := r.dataPolicyClient.UpdateDataPolicy(ctx, &datapoliciespb.UpdateDataPolicyRequest{
+		DataPolicy: &datapoliciespb.DataPolicy{
+			Name: dataPolicyId,
+			Policy: &datapoliciespb.DataPolicy_DataMaskingPolicy{
+				DataMaskingPolicy: maskingType.DataMaskingPolicy(),
+			},
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"data_masking_policy"}},
+	})
+	if err != nil {
+		return fmt.Errorf("update masking type of data policy %q: %w", dataPolicyId, err)
+	}
+
+	return nil
+}
+
+// DeleteDataPolicy deletes the data policy. The policy tag of the data policy is not deleted.
+func (r *DataPolicyRepository) DeleteDataPolicy(ctx context.Context, dataPolicyId string) error {
+	err := r.dataPolicyClient.DeleteDataPolicy(ctx, &datapoliciespb.DeleteDataPolicyRequest{Name: dataPolicyId})
+	if isNotFoundError(err) {
+		common.Logger.Warn(fmt.Sprintf("Cannot find data policy %q. Assuming data policy is already deleted", dataPolicyId))
+
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("delete data policy %q: %w", dataPolicyId, err)
+	}
+
+	return nil
+}
+
+// GetFineGrainedReaderMembers returns the fine-grained readers of the policy tag and the readers inherited from its parent tags.
+func (r *DataPolicyRepository) GetFineGrainedReaderMembers(ctx context.Context, tagId string) (*bigquery.FineGrainedReaders, error) {
+	return r.policyTagReaders.FineGrainedReaders(ctx, tagId)
+}
+
+// UpdateFineGrainedReaders adds the who items as fine-grained readers of the policy tag and removes the deleted who items.
+func (r *DataPolicyRepository) UpdateFineGrainedReaders(ctx context.Context, tagId string, who *sync_to_target.WhoItem, deletedWho *sync_to_target.WhoItem) error {
+	policy, err := r.policyTagClient.GetIamPolicy(ctx, &iampb.GetIamPolicyRequest{Resource: tagId})
+	if err != nil {
+		return fmt.Errorf("get iam policy of policy tag %q: %w", tagId, err)
+	}
+
+	_, err = r.policyTagClient.SetIamPolicy(ctx, &iampb.SetIamPolicyRequest{
+		Resource: tagId,
+		Policy:   iam.UpdateRoleMembers(policy, roles.RolesBigQueryCatalogFineGrainedAccess.Name, bigquery.ParseWhoToMembers(who), bigquery.ParseWhoToMembers(deletedWho)),
+	})
+	if err != nil {
+		return fmt.Errorf("set fine grained readers on %q: %w", tagId, err)
+	}
+
+	return nil
+}
+
+// UpdateMaskedReaders adds and removes the members as masked reader of the data policy.
+func (r *DataPolicyRepository) UpdateMaskedReaders(ctx context.Context, dataPolicyId string, membersToAdd []string, membersToRemove []string) error {
+	policy, err := r.dataPolicyClient.GetIamPolicy(ctx, &iampb.GetIamPolicyRequest{Resource: dataPolicyId})
+	if err != nil {
+		return fmt.Errorf("get iam policy of data policy %q: %w", dataPolicyId, err)
+	}
+
+	_, err = r.dataPolicyClient.SetIamPolicy(ctx, &iampb.SetIamPolicyRequest{
+		Resource: dataPolicyId,
+		Policy:   iam.UpdateRoleMembers(policy, roles.RolesBigQueryMaskedReader.Name, membersToAdd, membersToRemove),
+	})
+	if err != nil {
+		return fmt.Errorf("set iam policy of data policy %q: %w", dataPolicyId, err)
+	}
+
+	return nil
+}
+
+func dataPolicy(policy *datapoliciespb.DataPolicy, projectId string) bigquery.BQDataPolicy {
+	return bigquery.BQDataPolicy{
+		FullName:   normalizeProjectNumber(policy.GetName(), projectId),
+		PolicyType: policy.GetDataMaskingPolicy().GetPredefinedExpression(),
+		Routine:    normalizeProjectNumber(policy.GetDataMaskingPolicy().GetRoutine(), projectId),
+	}
+}
+
+// normalizeProjectNumber replaces the project number in a resource name by the project id.
+func normalizeProjectNumber(name string, projectId string) string {
+	if name == "" {
+		return ""
+	}
+
+	return projectNumberRegex.ReplaceAllString(name, fmt.Sprintf("projects/%s/", projectId))
+}
+
+// resourceProjectAndLocation returns the project and location of a resource name (projects/{project}/locations/{location}/...).
+func resourceProjectAndLocation(name string) (string, string) {
+	parts := strings.Split(name, "/")
+	if len(parts) < 4 || parts[0] != "projects" || parts[2] != "locations" {
+		return "", ""
+	}
+
+	return parts[1], parts[3]
+}
+
+func isNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if rpcError, isRpcError := status.FromError(err); isRpcError && rpcError.Code() == codes.NotFound {
+		return true
+	}
+
+	var apiError *googleapi.Error
+
+	return errors.As(err, &apiError) && apiError.Code == 404
+}
+
+// isSkippableProjectError returns true if the error indicates the data policies of a project cannot be listed, e.g. because the API is not enabled.
+func isSkippableProjectError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if common.IsGoogle403Error(err) || common.IsGoogle400Error(err) {
+		return true
+	}
+
+	rpcError, isRpcError := status.FromError(err)
+
+	return isRpcError && (rpcError.Code() == codes.FailedPrecondition || rpcError.Code() == codes.NotFound)
+}
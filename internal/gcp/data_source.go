@@ -3,14 +3,18 @@ package gcp
 import (
 	"strings"
 
+	"cloud.google.com/go/bigquery/datapolicies/apiv1/datapoliciespb"
 	"github.com/raito-io/cli/base/access_provider"
 	ds "github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/util/config"
 
+	bigquery "github.com/raito-io/cli-plugin-gcp/internal/bq"
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
 	"github.com/raito-io/cli-plugin-gcp/internal/common/roles"
 	"github.com/raito-io/cli-plugin-gcp/internal/iam"
 )
 
-func NewDataSourceMetaData() *ds.MetaData {
+func NewDataSourceMetaData(configMap *config.ConfigMap) *ds.MetaData {
 	managed_permissions := []*ds.DataObjectTypePermission{
 		roles.RolesOwner.ToDataObjectTypePermission(roles.ServiceGcp),
 		roles.RolesEditor.ToDataObjectTypePermission(roles.ServiceGcp),
@@ -38,7 +42,7 @@ func NewDataSourceMetaData() *ds.MetaData {
 	project := strings.ToLower(iam.Project.String())
 	folder := strings.ToLower(iam.Folder.String())
 
-	return &ds.MetaData{
+	metaData := &ds.MetaData{
 		Type:                  "gcp",
 		SupportedFeatures:     []string{},
 		SupportsApInheritance: false,
@@ -79,4 +83,18 @@ func NewDataSourceMetaData() *ds.MetaData {
 			},
 		},
 	}
+
+	if configMap.GetBoolWithDefault(common.GcpMaskingEnabled, false) {
+		metaData.SupportedFeatures = append(metaData.SupportedFeatures, ds.ColumnMasking)
+		metaData.MaskingMetadata = &ds.MaskingMetadata{
+			MaskTypes:               bigquery.PredefinedMaskTypes(),
+			DefaultMaskExternalName: datapoliciespb.DataMaskingPolicy_PredefinedExpression_name[int32(datapoliciespb.DataMaskingPolicy_ALWAYS_NULL)],
+			MaskOverridePermissions: []string{
+				roles.RolesBigQueryCatalogFineGrainedAccess.Name,
+			},
+			ApplicableTypes: []string{project},
+		}
+	}
+
+	return metaData
 }
@@ -4,53 +4,309 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/aws/smithy-go/ptr"
+	"github.com/raito-io/cli/base/access_provider/sync_from_target"
 	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/access_provider/types"
+	ds "github.com/raito-io/cli/base/data_source"
 	"github.com/raito-io/cli/base/util/config"
 	"github.com/raito-io/cli/base/wrappers"
 	"github.com/raito-io/golang-set/set"
 
+	bigquery "github.com/raito-io/cli-plugin-gcp/internal/bq"
 	"github.com/raito-io/cli-plugin-gcp/internal/common"
 	"github.com/raito-io/cli-plugin-gcp/internal/iam"
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
 )
 
-type NoMasking struct {
-	organisationId string
+const defaultMaskingLocations = "eu,us"
+
+//go:generate go run github.com/vektra/mockery/v2 --name=DataObjectIterator --with-expecter --inpackage
+type DataObjectIterator interface {
+	DataObjects(ctx context.Context, config *ds.DataSourceSyncConfig, fn func(ctx context.Context, object *org.GcpOrgEntity) error) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2 --name=dataPolicyRepository --with-expecter --inpackage
+type dataPolicyRepository interface {
+	ListMasks(ctx context.Context, projectId string, location string) ([]DataPolicyMask, error)
+	GetMask(ctx context.Context, dataPolicyId string) (*DataPolicyMask, error)
+	UpdateMaskingType(ctx context.Context, dataPolicyId string, maskingType bigquery.BQMaskingType) error
+	DeleteDataPolicy(ctx context.Context, dataPolicyId string) error
+	GetFineGrainedReaderMembers(ctx context.Context, tagId string) (*bigquery.FineGrainedReaders, error)
+	UpdateFineGrainedReaders(ctx context.Context, tagId string, who *importer.WhoItem, deletedWho *importer.WhoItem) error
+	UpdateMaskedReaders(ctx context.Context, dataPolicyId string, membersToAdd []string, membersToRemove []string) error
+}
+
+// GcpMaskingService manages the BigQuery data policies of all projects in the organization as masks.
+// Masks can only be created in the BigQuery data source, as the GCP data source has no columns to attach policy tags to.
+type GcpMaskingService struct {
+	dataObjectIterator   DataObjectIterator
+	dataPolicyRepository dataPolicyRepository
+
+	configMap      *config.ConfigMap
+	maskingEnabled bool
+	locations      []string
+
+	// Cache
+	projectDataPolicies []projectDataPolicies
+}
+
+// projectDataPolicies contains the data policies of a project, and the full names of the project and its folders and organization.
+type projectDataPolicies struct {
+	project   *org.GcpOrgEntity
+	ancestors set.Set[string]
+	masks     []DataPolicyMask
+}
+
+func NewGcpMaskingService(dataObjectIterator DataObjectIterator, dataPolicyRepository dataPolicyRepository, configmap *config.ConfigMap) *GcpMaskingService {
+	maskingEnabled := configmap.GetBoolWithDefault(common.GcpMaskingEnabled, false)
+
+	if maskingEnabled && configmap.GetString(common.GcpMaskingLocations) == "" {
+		common.Logger.Warn(fmt.Sprintf("No %s configured. Only data policies in the %s multi-regions are synced; data policies in regional locations, e.g. europe-west1, are ignored.", common.GcpMaskingLocations, defaultMaskingLocations))
+	}
+
+	var locations []string
+
+	for _, location := range strings.Split(configmap.GetStringWithDefault(common.GcpMaskingLocations, defaultMaskingLocations), ",") {
+		location = strings.ToLower(strings.TrimSpace(location))
+		if location != "" {
+			locations = append(locations, location)
+		}
+	}
+
+	return &GcpMaskingService{
+		dataObjectIterator:   dataObjectIterator,
+		dataPolicyRepository: dataPolicyRepository,
+
+		configMap:      configmap,
+		maskingEnabled: maskingEnabled,
+		locations:      locations,
+	}
 }
 
-func NewNoMasking(configmap *config.ConfigMap) *NoMasking {
-	return &NoMasking{
-		organisationId: fmt.Sprintf("gcp-org-%s", configmap.GetString(common.GcpOrgId)),
+// ImportMasks imports the data policies of all projects in the organization, in the configured locations, as masks on their project.
+// Data policies created by Raito are managed by the BigQuery data source and are not imported.
+func (m *GcpMaskingService) ImportMasks(ctx context.Context, accessProviderHandler wrappers.AccessProviderHandler, _ set.Set[string], _ map[string][]string, raitoMasks set.Set[string]) error {
+	if !m.maskingEnabled {
+		return errors.New("masking is not enabled for GCP")
+	}
+
+	err := m.loadProjectDataPolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, project := range m.projectDataPolicies {
+		for i := range project.masks {
+			err = m.importMask(ctx, accessProviderHandler, project.project, &project.masks[i], raitoMasks)
+			if err != nil {
+				return err
+			}
+		}
 	}
+
+	return nil
 }
 
-func (n *NoMasking) ImportMasks(_ context.Context, _ wrappers.AccessProviderHandler, _ set.Set[string], _ map[string][]string, _ set.Set[string]) error {
-	return errors.New("masking is not supported for GCP")
+func (m *GcpMaskingService) importMask(ctx context.Context, accessProviderHandler wrappers.AccessProviderHandler, project *org.GcpOrgEntity, mask *DataPolicyMask, raitoMasks set.Set[string]) error {
+	if raitoMasks.Contains(mask.DataPolicy.FullName) || bigquery.IsRaitoManagedPolicyTag(mask.Taxonomy, mask.PolicyTag.Description) {
+		common.Logger.Debug(fmt.Sprintf("Ignore raito created mask %q", mask.DataPolicy.FullName))
+
+		return nil
+	}
+
+	readers, err := m.dataPolicyRepository.GetFineGrainedReaderMembers(ctx, mask.PolicyTag.FullName)
+	if err != nil {
+		return fmt.Errorf("fine grained reader members for %q: %w", mask.PolicyTag.FullName, err)
+	}
+
+	whoItem := bigquery.MembersToWhoItem(readers.Direct)
+
+	err = accessProviderHandler.AddAccessProviders(
+		&sync_from_target.AccessProvider{
+			Name: mask.PolicyTag.Name,
+			Type: ptr.String(mask.DataPolicy.MaskingType().String()),
+			What: []sync_from_target.WhatItem{
+				{
+					DataObject: &ds.DataObjectReference{
+						FullName: project.FullName,
+						Type:     project.Type,
+					},
+					Permissions: []string{},
+				},
+			},
+			Action:     types.Mask,
+			ExternalId: mask.DataPolicy.FullName,
+			Who:        &whoItem,
+			ActualName: mask.PolicyTag.Name,
+			Tags:       readers.InheritedReadersTags(),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("add mask %q to ap handler: %w", mask.DataPolicy.FullName, err)
+	}
+
+	return nil
 }
 
-func (n *NoMasking) ExportMasks(_ context.Context, accessProvider *importer.AccessProvider, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler) ([]string, error) {
-	err := accessProviderFeedbackHandler.AddAccessProviderFeedback(importer.AccessProviderSyncFeedback{
+// ExportMasks updates the masking type and fine-grained readers of the data policy of an imported mask, or deletes the data policy if the mask is deleted.
+func (m *GcpMaskingService) ExportMasks(ctx context.Context, accessProvider *importer.AccessProvider, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler) ([]string, error) {
+	feedback := importer.AccessProviderSyncFeedback{
 		AccessProvider: accessProvider.Id,
 		ActualName:     accessProvider.Name,
-		Errors:         []string{"masking is not supported in data source"},
-	})
+		ExternalId:     accessProvider.ExternalId,
+	}
+
+	dataPolicyIds, err := m.exportMask(ctx, accessProvider, &feedback)
+	if err != nil {
+		feedback.Errors = append(feedback.Errors, err.Error())
+	}
+
+	err = accessProviderFeedbackHandler.AddAccessProviderFeedback(feedback)
 	if err != nil {
 		return nil, fmt.Errorf("add access provider feedback: %w", err)
 	}
 
-	return nil, nil
+	return dataPolicyIds, nil
 }
 
-func (n *NoMasking) MaskedBinding(_ context.Context, members []string) ([]iam.IamBinding, error) {
-	bindings := make([]iam.IamBinding, 0, len(members))
-	for _, member := range members {
-		bindings = append(bindings, iam.IamBinding{
-			Member:       member,
-			Role:         "roles/bigquerydatapolicy.maskedReader",
-			Resource:     n.organisationId,
-			ResourceType: "organization",
-		})
+func (m *GcpMaskingService) exportMask(ctx context.Context, accessProvider *importer.AccessProvider, feedback *importer.AccessProviderSyncFeedback) ([]string, error) {
+	if !m.maskingEnabled {
+		return nil, errors.New("masking is not supported in data source")
+	}
+
+	if accessProvider.ExternalId == nil || *accessProvider.ExternalId == "" {
+		if accessProvider.Delete {
+			return nil, nil
+		}
+
+		return nil, errors.New("masks can only be created in the BigQuery data source")
 	}
 
-	return bindings, nil
+	dataPolicyId := *accessProvider.ExternalId
+
+	if accessProvider.Delete {
+		common.Logger.Info(fmt.Sprintf("Delete data policy %s of mask %s", dataPolicyId, accessProvider.Name))
+
+		err := m.dataPolicyRepository.DeleteDataPolicy(ctx, dataPolicyId)
+		if err != nil {
+			return []string{dataPolicyId}, err
+		}
+
+		return nil, nil
+	}
+
+	mask, err := m.dataPolicyRepository.GetMask(ctx, dataPolicyId)
+	if err != nil {
+		return []string{dataPolicyId}, err
+	}
+
+	if mask == nil {
+		return nil, fmt.Errorf("data policy %q not found", dataPolicyId)
+	}
+
+	maskingType := bigquery.ParseMaskingType(accessProvider.Type)
+
+	if mask.DataPolicy.MaskingType().String() != maskingType.String() {
+		common.Logger.Info(fmt.Sprintf("Update masking type of data policy %s to %s", dataPolicyId, maskingType.String()))
+
+		err = m.dataPolicyRepository.UpdateMaskingType(ctx, dataPolicyId, maskingType)
+		if err != nil {
+			return []string{dataPolicyId}, err
+		}
+	}
+
+	err = m.dataPolicyRepository.UpdateFineGrainedReaders(ctx, mask.PolicyTag.FullName, &accessProvider.Who, accessProvider.DeletedWho)
+	if err != nil {
+		return []string{dataPolicyId}, fmt.Errorf("update fine grained readers of %q: %w", mask.PolicyTag.FullName, err)
+	}
+
+	feedback.ActualName = mask.PolicyTag.Name
+	feedback.Type = ptr.String(maskingType.String())
+
+	return []string{dataPolicyId}, nil
+}
+
+func (m *GcpMaskingService) ExportColumnAccess(_ context.Context, _ *importer.AccessProvider, feedback *importer.AccessProviderSyncFeedback) []string {
+	feedback.Errors = append(feedback.Errors, "column-level access is not supported for GCP")
+
+	return nil
+}
+
+func (m *GcpMaskingService) ApplyColumnPolicyTags(_ context.Context) error {
+	return nil
+}
+
+// MaskedReaderDataPolicies returns the data policies of all projects in the data object, in the configured locations.
+func (m *GcpMaskingService) MaskedReaderDataPolicies(ctx context.Context, dataObject *iam.DataObjectReference) ([]string, error) {
+	err := m.loadProjectDataPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+
+	for _, project := range m.projectDataPolicies {
+		if dataObject.ObjectType == org.TypeOrg || project.ancestors.Contains(dataObject.FullName) {
+			for i := range project.masks {
+				result = append(result, project.masks[i].DataPolicy.FullName)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// loadProjectDataPolicies lists the data policies of all projects in the configured locations once, for both the import of the masks and the masked readers.
+// The projects are crawled with the sync configuration of the data source, so excluded projects are skipped.
+func (m *GcpMaskingService) loadProjectDataPolicies(ctx context.Context) error {
+	if m.projectDataPolicies != nil {
+		return nil
+	}
+
+	result := make([]projectDataPolicies, 0)
+
+	err := m.dataObjectIterator.DataObjects(ctx, &ds.DataSourceSyncConfig{ConfigMap: m.configMap}, func(ctx context.Context, object *org.GcpOrgEntity) error {
+		if object.Type != org.TypeProject {
+			return nil
+		}
+
+		project := projectDataPolicies{project: object, ancestors: set.NewSet[string]()}
+
+		for ancestor := object; ancestor != nil; ancestor = ancestor.Parent {
+			project.ancestors.Add(ancestor.FullName)
+		}
+
+		for _, location := range m.locations {
+			masks, err := m.dataPolicyRepository.ListMasks(ctx, object.Id, location)
+			if err != nil {
+				return fmt.Errorf("list masks of project %q in location %q: %w", object.Id, location, err)
+			}
+
+			project.masks = append(project.masks, masks...)
+		}
+
+		result = append(result, project)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	m.projectDataPolicies = result
+
+	return nil
+}
+
+// UpdateMaskedReaders adds and removes the members as masked reader of the data policy.
+func (m *GcpMaskingService) UpdateMaskedReaders(ctx context.Context, dataPolicyId string, membersToAdd []string, membersToRemove []string) error {
+	return m.dataPolicyRepository.UpdateMaskedReaders(ctx, dataPolicyId, membersToAdd, membersToRemove)
+}
+
+// LegacyMaskedReaderResource returns the organization on which earlier versions granted the masked reader role.
+func (m *GcpMaskingService) LegacyMaskedReaderResource() *iam.DataObjectReference {
+	return &iam.DataObjectReference{FullName: fmt.Sprintf("gcp-org-%s", m.configMap.GetString(common.GcpOrgId)), ObjectType: org.TypeOrg}
 }
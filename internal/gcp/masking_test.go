@@ -6,13 +6,28 @@ import (
 	"reflect"
 	"testing"
 
+	"cloud.google.com/go/bigquery/datapolicies/apiv1/datapoliciespb"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/raito-io/cli/base/access_provider/sync_from_target"
 	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/access_provider/types"
+	ds "github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/util/config"
 	"github.com/raito-io/cli/base/wrappers/mocks"
+	"github.com/raito-io/golang-set/set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	bigquery "github.com/raito-io/cli-plugin-gcp/internal/bq"
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/iam"
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
 )
 
-func TestNoMasking_ExportMasks(t *testing.T) {
+func TestGcpMaskingService_ExportMasks_Disabled(t *testing.T) {
 	type fields struct {
-		organisationId string
+		maskingEnabled bool
 	}
 	type args struct {
 		ctx                               context.Context
@@ -29,7 +44,7 @@ func TestNoMasking_ExportMasks(t *testing.T) {
 		{
 			name: "export masks result in not support",
 			fields: fields{
-				organisationId: "test-org",
+				maskingEnabled: false,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -51,7 +66,7 @@ func TestNoMasking_ExportMasks(t *testing.T) {
 		{
 			name: "export masks result in not support - error in handler",
 			fields: fields{
-				organisationId: "test-org",
+				maskingEnabled: false,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -73,8 +88,8 @@ func TestNoMasking_ExportMasks(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			n := &NoMasking{
-				organisationId: tt.fields.organisationId,
+			n := &GcpMaskingService{
+				maskingEnabled: tt.fields.maskingEnabled,
 			}
 
 			handlerMock := mocks.NewAccessProviderFeedbackHandler(t)
@@ -91,3 +106,252 @@ func TestNoMasking_ExportMasks(t *testing.T) {
 		})
 	}
 }
+
+func TestGcpMaskingService_ImportMasks(t *testing.T) {
+	iterator := NewMockDataObjectIterator(t)
+	repo := newMockDataPolicyRepository(t)
+	handler := mocks.NewAccessProviderHandler(t)
+
+	configMap := &config.ConfigMap{Parameters: map[string]string{common.GcpMaskingEnabled: "true", common.GcpMaskingLocations: "EU, us-east1"}}
+	service := NewGcpMaskingService(iterator, repo, configMap)
+
+	folder := &org.GcpOrgEntity{Id: "folder1", FullName: "folder1", Type: org.TypeFolder}
+	project := &org.GcpOrgEntity{Id: "project1", FullName: "project1", Type: org.TypeProject, Parent: folder}
+
+	iterator.EXPECT().DataObjects(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, syncConfig *ds.DataSourceSyncConfig, fn func(context.Context, *org.GcpOrgEntity) error) error {
+		assert.Same(t, configMap, syncConfig.ConfigMap)
+
+		for _, object := range []*org.GcpOrgEntity{folder, project} {
+			err := fn(ctx, object)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}).Once()
+
+	repo.EXPECT().ListMasks(mock.Anything, "project1", "eu").Return([]DataPolicyMask{
+		{
+			BQMaskingInformation: bigquery.BQMaskingInformation{
+				DataPolicy: bigquery.BQDataPolicy{FullName: "projects/project1/locations/eu/dataPolicies/policy1", PolicyType: datapoliciespb.DataMaskingPolicy_SHA256},
+				PolicyTag:  bigquery.BQPolicyTag{FullName: "projects/project1/locations/eu/taxonomies/1/policyTags/1", Name: "email"},
+			},
+			ProjectId: "project1",
+			Location:  "eu",
+			Taxonomy:  "pii",
+		},
+		{
+			BQMaskingInformation: bigquery.BQMaskingInformation{
+				DataPolicy: bigquery.BQDataPolicy{FullName: "projects/project1/locations/eu/dataPolicies/policy2"},
+				PolicyTag:  bigquery.BQPolicyTag{FullName: "projects/project1/locations/eu/taxonomies/2/policyTags/2", Name: "raito"},
+			},
+			ProjectId: "project1",
+			Location:  "eu",
+			Taxonomy:  "raito_taxonomy_eu",
+		},
+		{
+			BQMaskingInformation: bigquery.BQMaskingInformation{
+				DataPolicy: bigquery.BQDataPolicy{FullName: "projects/project1/locations/eu/dataPolicies/policy3"},
+				PolicyTag:  bigquery.BQPolicyTag{FullName: "projects/project1/locations/eu/taxonomies/1/policyTags/3", Name: "exported"},
+			},
+			ProjectId: "project1",
+			Location:  "eu",
+			Taxonomy:  "pii",
+		},
+	}, nil).Once()
+	repo.EXPECT().ListMasks(mock.Anything, "project1", "us-east1").Return(nil, nil).Once()
+	repo.EXPECT().GetFineGrainedReaderMembers(mock.Anything, "projects/project1/locations/eu/taxonomies/1/policyTags/1").Return(&bigquery.FineGrainedReaders{Direct: []string{"user:ruben@raito.io", "group:sales@raito.io"}}, nil).Once()
+
+	handler.EXPECT().AddAccessProviders(&sync_from_target.AccessProvider{
+		Name: "email",
+		Type: ptr.String("SHA256"),
+		What: []sync_from_target.WhatItem{
+			{
+				DataObject:  &ds.DataObjectReference{FullName: "project1", Type: org.TypeProject},
+				Permissions: []string{},
+			},
+		},
+		Action:     types.Mask,
+		ExternalId: "projects/project1/locations/eu/dataPolicies/policy1",
+		Who:        &sync_from_target.WhoItem{Users: []string{"ruben@raito.io"}, Groups: []string{"sales@raito.io"}},
+		ActualName: "email",
+	}).Return(nil).Once()
+
+	err := service.ImportMasks(context.Background(), handler, set.NewSet[string](), nil, set.NewSet("projects/project1/locations/eu/dataPolicies/policy3"))
+
+	require.NoError(t, err)
+
+	// The data policies of the crawl are reused for the masked readers
+	dataPolicies, err := service.MaskedReaderDataPolicies(context.Background(), &iam.DataObjectReference{FullName: "folder1", ObjectType: org.TypeFolder})
+
+	require.NoError(t, err)
+	assert.Len(t, dataPolicies, 3)
+}
+
+func TestGcpMaskingService_ExportMasks(t *testing.T) {
+	dataPolicyId := "projects/project1/locations/eu/dataPolicies/policy1"
+	tagId := "projects/project1/locations/eu/taxonomies/1/policyTags/1"
+
+	type args struct {
+		accessProvider *importer.AccessProvider
+	}
+	tests := []struct {
+		name         string
+		args         args
+		repoSetup    func(repo *mockDataPolicyRepository)
+		want         []string
+		wantFeedback importer.AccessProviderSyncFeedback
+	}{
+		{
+			name: "update masking type and fine grained readers",
+			args: args{
+				accessProvider: &importer.AccessProvider{
+					Id:         "ap1",
+					Name:       "email",
+					ExternalId: &dataPolicyId,
+					Type:       ptr.String("EMAIL_MASK"),
+					Who:        importer.WhoItem{Users: []string{"ruben@raito.io"}},
+					DeletedWho: &importer.WhoItem{Groups: []string{"sales@raito.io"}},
+				},
+			},
+			repoSetup: func(repo *mockDataPolicyRepository) {
+				repo.EXPECT().GetMask(mock.Anything, dataPolicyId).Return(&DataPolicyMask{
+					BQMaskingInformation: bigquery.BQMaskingInformation{
+						DataPolicy: bigquery.BQDataPolicy{FullName: dataPolicyId, PolicyType: datapoliciespb.DataMaskingPolicy_SHA256},
+						PolicyTag:  bigquery.BQPolicyTag{FullName: tagId, Name: "email_tag"},
+					},
+				}, nil).Once()
+				repo.EXPECT().UpdateMaskingType(mock.Anything, dataPolicyId, bigquery.BQMaskingType{PredefinedExpression: datapoliciespb.DataMaskingPolicy_EMAIL_MASK}).Return(nil).Once()
+				repo.EXPECT().UpdateFineGrainedReaders(mock.Anything, tagId, &importer.WhoItem{Users: []string{"ruben@raito.io"}}, &importer.WhoItem{Groups: []string{"sales@raito.io"}}).Return(nil).Once()
+			},
+			want: []string{dataPolicyId},
+			wantFeedback: importer.AccessProviderSyncFeedback{
+				AccessProvider: "ap1",
+				ActualName:     "email_tag",
+				ExternalId:     &dataPolicyId,
+				Type:           ptr.String("EMAIL_MASK"),
+			},
+		},
+		{
+			name: "delete data policy",
+			args: args{
+				accessProvider: &importer.AccessProvider{
+					Id:         "ap1",
+					Name:       "email",
+					ExternalId: &dataPolicyId,
+					Delete:     true,
+				},
+			},
+			repoSetup: func(repo *mockDataPolicyRepository) {
+				repo.EXPECT().DeleteDataPolicy(mock.Anything, dataPolicyId).Return(nil).Once()
+			},
+			want: nil,
+			wantFeedback: importer.AccessProviderSyncFeedback{
+				AccessProvider: "ap1",
+				ActualName:     "email",
+				ExternalId:     &dataPolicyId,
+			},
+		},
+		{
+			name: "new mask",
+			args: args{
+				accessProvider: &importer.AccessProvider{
+					Id:   "ap1",
+					Name: "email",
+				},
+			},
+			repoSetup: func(repo *mockDataPolicyRepository) {},
+			want:      nil,
+			wantFeedback: importer.AccessProviderSyncFeedback{
+				AccessProvider: "ap1",
+				ActualName:     "email",
+				Errors:         []string{"masks can only be created in the BigQuery data source"},
+			},
+		},
+		{
+			name: "data policy not found",
+			args: args{
+				accessProvider: &importer.AccessProvider{
+					Id:         "ap1",
+					Name:       "email",
+					ExternalId: &dataPolicyId,
+				},
+			},
+			repoSetup: func(repo *mockDataPolicyRepository) {
+				repo.EXPECT().GetMask(mock.Anything, dataPolicyId).Return(nil, nil).Once()
+			},
+			want: nil,
+			wantFeedback: importer.AccessProviderSyncFeedback{
+				AccessProvider: "ap1",
+				ActualName:     "email",
+				ExternalId:     &dataPolicyId,
+				Errors:         []string{"data policy \"projects/project1/locations/eu/dataPolicies/policy1\" not found"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockDataPolicyRepository(t)
+			tt.repoSetup(repo)
+
+			handler := mocks.NewAccessProviderFeedbackHandler(t)
+			handler.EXPECT().AddAccessProviderFeedback(tt.wantFeedback).Return(nil).Once()
+
+			service := NewGcpMaskingService(NewMockDataObjectIterator(t), repo, &config.ConfigMap{Parameters: map[string]string{common.GcpMaskingEnabled: "true"}})
+
+			got, err := service.ExportMasks(context.Background(), tt.args.accessProvider, handler)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGcpMaskingService_MaskedReaderDataPolicies(t *testing.T) {
+	iterator := NewMockDataObjectIterator(t)
+	repo := newMockDataPolicyRepository(t)
+
+	service := NewGcpMaskingService(iterator, repo, &config.ConfigMap{Parameters: map[string]string{common.GcpMaskingLocations: "eu"}})
+
+	organization := &org.GcpOrgEntity{Id: "org1", FullName: "org1", Type: org.TypeOrg}
+	folder := &org.GcpOrgEntity{Id: "folder1", FullName: "folder1", Type: org.TypeFolder, Parent: organization}
+	project1 := &org.GcpOrgEntity{Id: "project1", FullName: "project1", Type: org.TypeProject, Parent: folder}
+	project2 := &org.GcpOrgEntity{Id: "project2", FullName: "project2", Type: org.TypeProject, Parent: organization}
+
+	iterator.EXPECT().DataObjects(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, _ *ds.DataSourceSyncConfig, fn func(context.Context, *org.GcpOrgEntity) error) error {
+		for _, object := range []*org.GcpOrgEntity{organization, folder, project1, project2} {
+			err := fn(ctx, object)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}).Once()
+
+	repo.EXPECT().ListMasks(mock.Anything, "project1", "eu").Return([]DataPolicyMask{
+		{BQMaskingInformation: bigquery.BQMaskingInformation{DataPolicy: bigquery.BQDataPolicy{FullName: "projects/project1/locations/eu/dataPolicies/policy1"}}},
+	}, nil).Once()
+	repo.EXPECT().ListMasks(mock.Anything, "project2", "eu").Return([]DataPolicyMask{
+		{BQMaskingInformation: bigquery.BQMaskingInformation{DataPolicy: bigquery.BQDataPolicy{FullName: "projects/project2/locations/eu/dataPolicies/policy2"}}},
+	}, nil).Once()
+
+	tests := []struct {
+		dataObject *iam.DataObjectReference
+		want       []string
+	}{
+		{dataObject: &iam.DataObjectReference{FullName: "project1", ObjectType: org.TypeProject}, want: []string{"projects/project1/locations/eu/dataPolicies/policy1"}},
+		{dataObject: &iam.DataObjectReference{FullName: "folder1", ObjectType: org.TypeFolder}, want: []string{"projects/project1/locations/eu/dataPolicies/policy1"}},
+		{dataObject: &iam.DataObjectReference{FullName: "org1", ObjectType: org.TypeOrg}, want: []string{"projects/project1/locations/eu/dataPolicies/policy1", "projects/project2/locations/eu/dataPolicies/policy2"}},
+		{dataObject: &iam.DataObjectReference{FullName: "project3", ObjectType: org.TypeProject}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dataObject.FullName, func(t *testing.T) {
+			got, err := service.MaskedReaderDataPolicies(context.Background(), tt.dataObject)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -5,11 +5,19 @@ package gcp
 
 import (
 	"github.com/google/wire"
+
+	bigquery "github.com/raito-io/cli-plugin-gcp/internal/bq"
 )
 
 var Wired = wire.NewSet(
 	NewDataSourceMetaData,
 	NewIdentityStoreMetadata,
-	NewNoMasking,
+	NewGcpMaskingService,
+	NewDataPolicyRepository,
 	NewNoFiltering,
+
+	bigquery.NewPolicyTagClient,
+	bigquery.NewDataPolicyClient,
+
+	wire.Bind(new(dataPolicyRepository), new(*DataPolicyRepository)),
 )
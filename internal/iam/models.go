@@ -1,16 +1,21 @@
 package iam
 
 import (
+	"errors"
 	"strings"
 
 	crmV1 "google.golang.org/api/cloudresourcemanager/v1"
 	crmV2 "google.golang.org/api/cloudresourcemanager/v2"
 )
 
+// ErrLastOwnerRemoval is returned when an update would remove the last owner of a project or organization.
+var ErrLastOwnerRemoval = errors.New("refusing to remove the last owner (roles/owner)")
+
 type GroupEntity struct {
-	ExternalId string
-	Email      string
-	Members    []string
+	ExternalId  string
+	Email       string
+	Description string
+	Members     []string
 }
 
 type UserEntity struct {
@@ -24,6 +29,7 @@ type IamBinding struct {
 	Role         string
 	Resource     string
 	ResourceType string
+	Condition    string // CEL expression of the IAM condition, empty for unconditional bindings
 }
 
 //go:generate go run github.com/raito-io/enumer -gqlgen -type=IamType
@@ -44,7 +50,7 @@ type IAMPolicyContainer struct {
 }
 
 func (a IamBinding) Equals(b IamBinding) bool {
-	return strings.EqualFold(a.Member, b.Member) && strings.EqualFold(a.Role, b.Role) && strings.EqualFold(a.Resource, b.Resource) && strings.EqualFold(a.ResourceType, b.ResourceType)
+	return strings.EqualFold(a.Member, b.Member) && strings.EqualFold(a.Role, b.Role) && strings.EqualFold(a.Resource, b.Resource) && strings.EqualFold(a.ResourceType, b.ResourceType) && a.Condition == b.Condition
 }
 
 type DataObjectReference struct {
@@ -0,0 +1,77 @@
+package iam
+
+import (
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+)
+
+// Limits of a single IAM policy. SetIamPolicy fails when a policy exceeds one of them.
+// See https://cloud.google.com/iam/quotas#limits
+const (
+	MaxPolicyPrincipals          = 1500
+	MaxPolicyConditionalBindings = 100
+)
+
+// PolicySizeError is returned when the resulting IAM policy of a resource would exceed the limits of GCP.
+type PolicySizeError struct {
+	Resource            string
+	Principals          int
+	ConditionalBindings int
+}
+
+func (e *PolicySizeError) Error() string {
+	var exceeded []string
+
+	if e.Principals > MaxPolicyPrincipals {
+		exceeded = append(exceeded, fmt.Sprintf("%d principals (limit %d)", e.Principals, MaxPolicyPrincipals))
+	}
+
+	if e.ConditionalBindings > MaxPolicyConditionalBindings {
+		exceeded = append(exceeded, fmt.Sprintf("%d conditional bindings (limit %d)", e.ConditionalBindings, MaxPolicyConditionalBindings))
+	}
+
+	return fmt.Sprintf("IAM policy of %q would contain %s", e.Resource, strings.Join(exceeded, " and "))
+}
+
+// ValidatePolicySize returns a PolicySizeError if the resulting bindings of a resource exceed the IAM policy limits.
+// Every principal in every binding counts towards the principal limit, conditional bindings are counted per role and condition.
+func ValidatePolicySize(resource string, bindings []IamBinding) error {
+	conditionalBindings := make(map[string]struct{})
+
+	for i := range bindings {
+		if bindings[i].Condition != "" {
+			conditionalBindings[bindings[i].Role+"|"+bindings[i].Condition] = struct{}{}
+		}
+	}
+
+	if len(bindings) <= MaxPolicyPrincipals && len(conditionalBindings) <= MaxPolicyConditionalBindings {
+		return nil
+	}
+
+	return &PolicySizeError{
+		Resource:            resource,
+		Principals:          len(bindings),
+		ConditionalBindings: len(conditionalBindings),
+	}
+}
+
+// PolicyBindings flattens an IAM policy into one binding per principal.
+func PolicyBindings(policy *iampb.Policy, resource string, resourceType string) []IamBinding {
+	var result []IamBinding
+
+	for _, binding := range policy.GetBindings() {
+		for _, member := range binding.Members {
+			result = append(result, IamBinding{
+				Member:       member,
+				Role:         binding.Role,
+				Resource:     resource,
+				ResourceType: resourceType,
+				Condition:    binding.GetCondition().GetExpression(),
+			})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,88 @@
+package iam
+
+import (
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/type/expr"
+)
+
+func TestValidatePolicySize(t *testing.T) {
+	principals := func(n int, condition func(i int) string) []IamBinding {
+		bindings := make([]IamBinding, 0, n)
+		for i := 0; i < n; i++ {
+			bindings = append(bindings, IamBinding{Member: fmt.Sprintf("user:user%d@raito.io", i), Role: "roles/viewer", Condition: condition(i)})
+		}
+
+		return bindings
+	}
+
+	noCondition := func(int) string { return "" }
+
+	tests := []struct {
+		name     string
+		bindings []IamBinding
+		wantErr  *PolicySizeError
+	}{
+		{
+			name:     "Empty policy",
+			bindings: nil,
+		},
+		{
+			name:     "Principal limit",
+			bindings: principals(MaxPolicyPrincipals, noCondition),
+		},
+		{
+			name:     "Too many principals",
+			bindings: principals(MaxPolicyPrincipals+1, noCondition),
+			wantErr:  &PolicySizeError{Resource: "projects/p1", Principals: MaxPolicyPrincipals + 1},
+		},
+		{
+			name:     "Conditions shared by principals",
+			bindings: principals(MaxPolicyConditionalBindings*2, func(i int) string { return fmt.Sprintf("condition%d", i%MaxPolicyConditionalBindings) }),
+		},
+		{
+			name:     "Too many conditional bindings",
+			bindings: principals(MaxPolicyConditionalBindings+1, func(i int) string { return fmt.Sprintf("condition%d", i) }),
+			wantErr:  &PolicySizeError{Resource: "projects/p1", Principals: MaxPolicyConditionalBindings + 1, ConditionalBindings: MaxPolicyConditionalBindings + 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePolicySize("projects/p1", tt.bindings)
+
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			var policySizeErr *PolicySizeError
+			require.ErrorAs(t, err, &policySizeErr)
+			assert.Equal(t, tt.wantErr, policySizeErr)
+		})
+	}
+}
+
+func TestPolicySizeError_Error(t *testing.T) {
+	assert.Equal(t, `IAM policy of "projects/p1" would contain 1501 principals (limit 1500)`, (&PolicySizeError{Resource: "projects/p1", Principals: 1501}).Error())
+	assert.Equal(t, `IAM policy of "projects/p1" would contain 1600 principals (limit 1500) and 101 conditional bindings (limit 100)`, (&PolicySizeError{Resource: "projects/p1", Principals: 1600, ConditionalBindings: 101}).Error())
+}
+
+func TestPolicyBindings(t *testing.T) {
+	policy := &iampb.Policy{
+		Bindings: []*iampb.Binding{
+			{Role: "roles/viewer", Members: []string{"user:ruben@raito.io", "group:sales@raito.io"}},
+			{Role: "roles/editor", Members: []string{"user:ruben@raito.io"}, Condition: &expr.Expr{Expression: "request.time < timestamp('2030-01-01T00:00:00Z')"}},
+		},
+	}
+
+	assert.Equal(t, []IamBinding{
+		{Member: "user:ruben@raito.io", Role: "roles/viewer", Resource: "p1", ResourceType: "project"},
+		{Member: "group:sales@raito.io", Role: "roles/viewer", Resource: "p1", ResourceType: "project"},
+		{Member: "user:ruben@raito.io", Role: "roles/editor", Resource: "p1", ResourceType: "project", Condition: "request.time < timestamp('2030-01-01T00:00:00Z')"},
+	}, PolicyBindings(policy, "p1", "project"))
+}
@@ -0,0 +1,48 @@
+package iam
+
+import (
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"github.com/raito-io/golang-set/set"
+)
+
+// UpdateRoleMembers returns a copy of the policy in which the members are added to and removed from the unconditional binding of the role.
+// The binding is dropped if no members are left. Other bindings are kept as is.
+func UpdateRoleMembers(policy *iampb.Policy, role string, membersToAdd []string, membersToRemove []string) *iampb.Policy {
+	updatedPolicy := &iampb.Policy{
+		Version:      policy.GetVersion(),
+		AuditConfigs: policy.GetAuditConfigs(),
+		Etag:         policy.GetEtag(),
+	}
+
+	toRemove := set.NewSet(membersToRemove...)
+	members := set.NewSet[string]()
+	roleBinding := &iampb.Binding{Role: role}
+
+	for _, binding := range policy.GetBindings() {
+		if binding.Role != role || binding.Condition != nil {
+			updatedPolicy.Bindings = append(updatedPolicy.Bindings, binding)
+
+			continue
+		}
+
+		for _, member := range binding.Members {
+			if !toRemove.Contains(member) && !members.Contains(member) {
+				members.Add(member)
+				roleBinding.Members = append(roleBinding.Members, member)
+			}
+		}
+	}
+
+	for _, member := range membersToAdd {
+		if !members.Contains(member) {
+			members.Add(member)
+			roleBinding.Members = append(roleBinding.Members, member)
+		}
+	}
+
+	if len(roleBinding.Members) > 0 {
+		updatedPolicy.Bindings = append(updatedPolicy.Bindings, roleBinding)
+	}
+
+	return updatedPolicy
+}
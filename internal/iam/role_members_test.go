@@ -0,0 +1,50 @@
+package iam
+
+import (
+	"testing"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/type/expr"
+)
+
+func TestUpdateRoleMembers(t *testing.T) {
+	conditional := &iampb.Binding{Role: "roles/bigquerydatapolicy.maskedReader", Members: []string{"user:thomas@raito.io"}, Condition: &expr.Expr{Expression: "true"}}
+	other := &iampb.Binding{Role: "roles/viewer", Members: []string{"user:ruben@raito.io"}}
+
+	policy := &iampb.Policy{
+		Version: 3,
+		Etag:    []byte("etag"),
+		Bindings: []*iampb.Binding{
+			{Role: "roles/bigquerydatapolicy.maskedReader", Members: []string{"user:ruben@raito.io", "group:sales@raito.io"}},
+			conditional,
+			other,
+		},
+	}
+
+	t.Run("Add and remove members", func(t *testing.T) {
+		result := UpdateRoleMembers(policy, "roles/bigquerydatapolicy.maskedReader", []string{"user:michael@raito.io", "user:ruben@raito.io"}, []string{"group:sales@raito.io", "user:thomas@raito.io"})
+
+		assert.Equal(t, &iampb.Policy{
+			Version: 3,
+			Etag:    []byte("etag"),
+			Bindings: []*iampb.Binding{
+				conditional,
+				other,
+				{Role: "roles/bigquerydatapolicy.maskedReader", Members: []string{"user:ruben@raito.io", "user:michael@raito.io"}},
+			},
+		}, result)
+	})
+
+	t.Run("Remove all members", func(t *testing.T) {
+		result := UpdateRoleMembers(policy, "roles/bigquerydatapolicy.maskedReader", nil, []string{"group:sales@raito.io", "user:ruben@raito.io"})
+
+		assert.Equal(t, []*iampb.Binding{conditional, other}, result.Bindings)
+	})
+
+	t.Run("New binding", func(t *testing.T) {
+		result := UpdateRoleMembers(&iampb.Policy{}, "roles/bigquerydatapolicy.maskedReader", []string{"user:ruben@raito.io"}, nil)
+
+		assert.Equal(t, []*iampb.Binding{{Role: "roles/bigquerydatapolicy.maskedReader", Members: []string{"user:ruben@raito.io"}}}, result.Bindings)
+	})
+}
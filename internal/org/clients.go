@@ -7,13 +7,17 @@ import (
 	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
 	"github.com/raito-io/cli/base/util/config"
 	"google.golang.org/api/iam/v1"
-	"google.golang.org/api/option"
 
-	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/credentials"
 )
 
 func NewProjectsClient(ctx context.Context, configMap *config.ConfigMap) (*resourcemanager.ProjectsClient, func(), error) {
-	c, err := resourcemanager.NewProjectsClient(ctx, option.WithCredentialsFile(configMap.GetString(common.GcpSAFileLocation)))
+	credentialsOption, err := credentials.ClientOption(ctx, configMap)
+	if err != nil {
+		return nil, nil, fmt.Errorf("projects client credentials: %w", err)
+	}
+
+	c, err := resourcemanager.NewProjectsClient(ctx, credentialsOption)
 	if err != nil {
 		return nil, nil, fmt.Errorf("new projects client: %w", err)
 	}
@@ -22,7 +26,12 @@ func NewProjectsClient(ctx context.Context, configMap *config.ConfigMap) (*resou
 }
 
 func NewFoldersClient(ctx context.Context, configMap *config.ConfigMap) (*resourcemanager.FoldersClient, func(), error) {
-	c, err := resourcemanager.NewFoldersClient(ctx, option.WithCredentialsFile(configMap.GetString(common.GcpSAFileLocation)))
+	credentialsOption, err := credentials.ClientOption(ctx, configMap)
+	if err != nil {
+		return nil, nil, fmt.Errorf("folders client credentials: %w", err)
+	}
+
+	c, err := resourcemanager.NewFoldersClient(ctx, credentialsOption)
 	if err != nil {
 		return nil, nil, fmt.Errorf("new folders client: %w", err)
 	}
@@ -31,7 +40,12 @@ func NewFoldersClient(ctx context.Context, configMap *config.ConfigMap) (*resour
 }
 
 func NewOrganizationsClient(ctx context.Context, configMap *config.ConfigMap) (*resourcemanager.OrganizationsClient, func(), error) {
-	c, err := resourcemanager.NewOrganizationsClient(ctx, option.WithCredentialsFile(configMap.GetString(common.GcpSAFileLocation)))
+	credentialsOption, err := credentials.ClientOption(ctx, configMap)
+	if err != nil {
+		return nil, nil, fmt.Errorf("organizations client credentials: %w", err)
+	}
+
+	c, err := resourcemanager.NewOrganizationsClient(ctx, credentialsOption)
 	if err != nil {
 		return nil, nil, fmt.Errorf("new organizations client: %w", err)
 	}
@@ -40,7 +54,12 @@ func NewOrganizationsClient(ctx context.Context, configMap *config.ConfigMap) (*
 }
 
 func NewIamClient(ctx context.Context, configMap *config.ConfigMap) (*iam.ProjectsServiceAccountsService, error) {
-	c, err := iam.NewService(ctx, option.WithCredentialsFile(configMap.GetString(common.GcpSAFileLocation)))
+	credentialsOption, err := credentials.ClientOption(ctx, configMap)
+	if err != nil {
+		return nil, fmt.Errorf("iam client credentials: %w", err)
+	}
+
+	c, err := iam.NewService(ctx, credentialsOption)
 	if err != nil {
 		return nil, fmt.Errorf("new iam client: %w", err)
 	}
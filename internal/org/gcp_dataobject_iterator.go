@@ -24,6 +24,7 @@ type iamRepo interface {
 type projectRepo interface {
 	iamRepo
 	GetProjects(ctx context.Context, config *ds.DataSourceSyncConfig, parentName string, parent *GcpOrgEntity, fn func(ctx context.Context, project *GcpOrgEntity) error) error
+	UpdateProjectLabels(ctx context.Context, projectId string, labels map[string]*string) error
 }
 
 //go:generate go run github.com/vektra/mockery/v2 --name=folderRepo --with-expecter --inpackage
@@ -120,6 +121,21 @@ func (r *GcpDataObjectIterator) Bindings(ctx context.Context, config *ds.DataSou
 	})
 }
 
+// GetBindings returns the current bindings of a single data object.
+func (r *GcpDataObjectIterator) GetBindings(ctx context.Context, dataObject *iam.DataObjectReference) ([]iam.IamBinding, error) {
+	repo := r.getIamRepository(dataObject.ObjectType)
+	if repo == nil {
+		return nil, fmt.Errorf("unknown data object type: %s", dataObject.ObjectType)
+	}
+
+	bindings, err := repo.GetIamPolicy(ctx, dataObject.FullName)
+	if err != nil {
+		return nil, fmt.Errorf("get iam policies of (%s, %s): %w", dataObject.ObjectType, dataObject.FullName, err)
+	}
+
+	return bindings, nil
+}
+
 func (r *GcpDataObjectIterator) UpdateBindings(ctx context.Context, dataObject *iam.DataObjectReference, addBindings []iam.IamBinding, removeBindings []iam.IamBinding) error {
 	repo := r.getIamRepository(dataObject.ObjectType)
 	if repo == nil {
@@ -134,6 +150,33 @@ func (r *GcpDataObjectIterator) UpdateBindings(ctx context.Context, dataObject *
 	return nil
 }
 
+// UpdateMetadata writes back the labels of a project. Other data object types don't support metadata updates.
+func (r *GcpDataObjectIterator) UpdateMetadata(ctx context.Context, update *MetadataUpdate) error {
+	if update.Type != TypeProject {
+		return fmt.Errorf("metadata updates are not supported for data object type %q", update.Type)
+	}
+
+	if update.Description != nil {
+		return errors.New("projects do not support descriptions")
+	}
+
+	if len(update.Labels) == 0 {
+		return nil
+	}
+
+	projectId := update.FullName
+	if idx := strings.LastIndex(projectId, "."); idx >= 0 {
+		projectId = projectId[idx+1:]
+	}
+
+	err := r.projectRepo.UpdateProjectLabels(ctx, projectId, update.Labels)
+	if err != nil {
+		return fmt.Errorf("update labels of project %q: %w", projectId, err)
+	}
+
+	return nil
+}
+
 func (r *GcpDataObjectIterator) DataSourceType() string {
 	return TypeOrg
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/aws/smithy-go/ptr"
 	"github.com/raito-io/cli/base/data_source"
 
 	"github.com/raito-io/cli/base/util/config"
@@ -494,6 +495,46 @@ func TestGcpDataObjectIterator_DataObjects(t *testing.T) {
 	}
 }
 
+func TestGcpDataObjectIterator_UpdateMetadata(t *testing.T) {
+	labels := map[string]*string{"team": ptr.String("finance")}
+
+	tests := []struct {
+		name      string
+		update    *MetadataUpdate
+		mockSetup func(projectRepo *mockProjectRepo)
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "update project labels",
+			update: &MetadataUpdate{FullName: "gcp-org-1.project1", Type: TypeProject, Labels: labels},
+			mockSetup: func(projectRepo *mockProjectRepo) {
+				projectRepo.EXPECT().UpdateProjectLabels(mock.Anything, "project1", labels).Return(nil).Once()
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "project description is not supported",
+			update:    &MetadataUpdate{FullName: "project1", Type: TypeProject, Description: ptr.String("description")},
+			mockSetup: func(projectRepo *mockProjectRepo) {},
+			wantErr:   assert.Error,
+		},
+		{
+			name:      "folders are not supported",
+			update:    &MetadataUpdate{FullName: "folder1", Type: TypeFolder, Labels: labels},
+			mockSetup: func(projectRepo *mockProjectRepo) {},
+			wantErr:   assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iterator, projectRepo, _, _ := createGcpDataObjectIteratorTest(t, "gcp-org-1", "", "")
+			tt.mockSetup(projectRepo)
+
+			tt.wantErr(t, iterator.UpdateMetadata(context.Background(), tt.update))
+		})
+	}
+}
+
 func createGcpDataObjectIteratorTest(t *testing.T, organisationId, includes, excludes string) (*GcpDataObjectIterator, *mockProjectRepo, *mockFolderRepo, *mockOrganizationRepo) {
 	t.Helper()
 
@@ -182,6 +182,54 @@ func (_c *mockProjectRepo_UpdateBinding_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// UpdateProjectLabels provides a mock function with given fields: ctx, projectId, labels
+func (_m *mockProjectRepo) UpdateProjectLabels(ctx context.Context, projectId string, labels map[string]*string) error {
+	ret := _m.Called(ctx, projectId, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProjectLabels")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]*string) error); ok {
+		r0 = rf(ctx, projectId, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockProjectRepo_UpdateProjectLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProjectLabels'
+type mockProjectRepo_UpdateProjectLabels_Call struct {
+	*mock.Call
+}
+
+// UpdateProjectLabels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectId string
+//   - labels map[string]*string
+func (_e *mockProjectRepo_Expecter) UpdateProjectLabels(ctx interface{}, projectId interface{}, labels interface{}) *mockProjectRepo_UpdateProjectLabels_Call {
+	return &mockProjectRepo_UpdateProjectLabels_Call{Call: _e.mock.On("UpdateProjectLabels", ctx, projectId, labels)}
+}
+
+func (_c *mockProjectRepo_UpdateProjectLabels_Call) Run(run func(ctx context.Context, projectId string, labels map[string]*string)) *mockProjectRepo_UpdateProjectLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(map[string]*string))
+	})
+	return _c
+}
+
+func (_c *mockProjectRepo_UpdateProjectLabels_Call) Return(_a0 error) *mockProjectRepo_UpdateProjectLabels_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockProjectRepo_UpdateProjectLabels_Call) RunAndReturn(run func(context.Context, string, map[string]*string) error) *mockProjectRepo_UpdateProjectLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // newMockProjectRepo creates a new instance of mockProjectRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockProjectRepo(t interface {
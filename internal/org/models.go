@@ -16,3 +16,12 @@ type GcpOrgEntity struct {
 	DataType    *string
 	Tags        map[string]string
 }
+
+// MetadataUpdate describes the metadata of a data object that should be written back to GCP.
+// A label with a nil value is removed.
+type MetadataUpdate struct {
+	FullName    string             `json:"fullName"`
+	Type        string             `json:"type"`
+	Description *string            `json:"description,omitempty"`
+	Labels      map[string]*string `json:"labels,omitempty"`
+}
@@ -0,0 +1,121 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	resourcemanager "cloud.google.com/go/resourcemanager/apiv3"
+	"cloud.google.com/go/resourcemanager/apiv3/resourcemanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/raito-io/cli/base/util/config"
+	"google.golang.org/api/iterator"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/preflight"
+)
+
+type preflightFolderClient interface {
+	preflight.IamPermissionTester
+	ListFolders(ctx context.Context, req *resourcemanagerpb.ListFoldersRequest, opts ...gax.CallOption) *resourcemanager.FolderIterator
+}
+
+type preflightProjectClient interface {
+	preflight.IamPermissionTester
+	ListProjects(ctx context.Context, req *resourcemanagerpb.ListProjectsRequest, opts ...gax.CallOption) *resourcemanager.ProjectIterator
+}
+
+var organizationRequirements = map[preflight.Feature][]string{
+	preflight.DataSource:    {"resourcemanager.organizations.get", "resourcemanager.folders.list", "resourcemanager.projects.list"},
+	preflight.AccessWrite:   {"resourcemanager.organizations.getIamPolicy", "resourcemanager.organizations.setIamPolicy"},
+	preflight.IdentityStore: {"resourcemanager.organizations.getIamPolicy"},
+}
+
+var folderRequirements = map[preflight.Feature][]string{
+	preflight.DataSource:  {"resourcemanager.folders.get", "resourcemanager.folders.list", "resourcemanager.projects.list"},
+	preflight.AccessWrite: {"resourcemanager.folders.getIamPolicy", "resourcemanager.folders.setIamPolicy"},
+}
+
+var projectRequirements = map[preflight.Feature][]string{
+	preflight.DataSource:    {"resourcemanager.projects.get"},
+	preflight.AccessWrite:   {"resourcemanager.projects.getIamPolicy", "resourcemanager.projects.setIamPolicy"},
+	preflight.IdentityStore: {"resourcemanager.projects.getIamPolicy", "iam.serviceAccounts.list"},
+}
+
+// PreflightSampler provides the organization and a sample of its top level folders and projects to the preflight check.
+type PreflightSampler struct {
+	organizationClient preflight.IamPermissionTester
+	folderClient       preflightFolderClient
+	projectClient      preflightProjectClient
+
+	organizationId string
+	sampleSize     int
+}
+
+func NewPreflightSampler(organizationClient *resourcemanager.OrganizationsClient, folderClient *resourcemanager.FoldersClient, projectClient *resourcemanager.ProjectsClient, configMap *config.ConfigMap) *PreflightSampler {
+	return &PreflightSampler{
+		organizationClient: organizationClient,
+		folderClient:       folderClient,
+		projectClient:      projectClient,
+
+		organizationId: configMap.GetString(common.GcpOrgId),
+		sampleSize:     configMap.GetIntWithDefault(common.GcpPreflightSampleSize, preflight.DefaultSampleSize),
+	}
+}
+
+func (s *PreflightSampler) PreflightResources(ctx context.Context) ([]preflight.Resource, error) {
+	if s.organizationId == "" {
+		return nil, fmt.Errorf("parameter %q is not set", common.GcpOrgId)
+	}
+
+	parent := "organizations/" + s.organizationId
+
+	resources := []preflight.Resource{{
+		Name:            fmt.Sprintf("organization %q", s.organizationId),
+		Requirements:    organizationRequirements,
+		TestPermissions: preflight.TestIamPermissions(s.organizationClient, parent),
+	}}
+
+	folderIterator := s.folderClient.ListFolders(ctx, &resourcemanagerpb.ListFoldersRequest{Parent: parent})
+
+	for i := 0; i < s.sampleSize; i++ {
+		folder, err := folderIterator.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("list folders: %w", err)
+		}
+
+		resources = append(resources, preflight.Resource{
+			Name:            fmt.Sprintf("folder %q", folder.DisplayName),
+			Requirements:    folderRequirements,
+			TestPermissions: preflight.TestIamPermissions(s.folderClient, folder.Name),
+		})
+	}
+
+	projectIterator := s.projectClient.ListProjects(ctx, &resourcemanagerpb.ListProjectsRequest{Parent: parent})
+
+	for i := 0; i < s.sampleSize; i++ {
+		project, err := projectIterator.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("list projects: %w", err)
+		}
+
+		resources = append(resources, preflight.Resource{
+			Name:            fmt.Sprintf("project %q", project.ProjectId),
+			Requirements:    projectRequirements,
+			TestPermissions: preflight.TestIamPermissions(s.projectClient, project.Name),
+		})
+	}
+
+	return resources, nil
+}
+
+func (s *PreflightSampler) PreflightApis() []preflight.Api {
+	return []preflight.Api{
+		{Name: "cloudresourcemanager.googleapis.com", Features: []preflight.Feature{preflight.DataSource, preflight.AccessWrite, preflight.IdentityStore}},
+		{Name: "iam.googleapis.com", Features: []preflight.Feature{preflight.IdentityStore}},
+	}
+}
@@ -12,6 +12,7 @@ import (
 	ds "github.com/raito-io/cli/base/data_source"
 	iam2 "google.golang.org/api/iam/v1"
 	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/raito-io/cli-plugin-gcp/internal/common"
 	"github.com/raito-io/cli-plugin-gcp/internal/iam"
@@ -25,6 +26,8 @@ type projectClient interface {
 	ListProjects(ctx context.Context, req *resourcemanagerpb.ListProjectsRequest, opts ...gax.CallOption) *resourcemanager.ProjectIterator
 	GetIamPolicy(ctx context.Context, req *iampb.GetIamPolicyRequest, opts ...gax.CallOption) (*iampb.Policy, error)
 	SetIamPolicy(ctx context.Context, req *iampb.SetIamPolicyRequest, opts ...gax.CallOption) (*iampb.Policy, error)
+	GetProject(ctx context.Context, req *resourcemanagerpb.GetProjectRequest, opts ...gax.CallOption) (*resourcemanagerpb.Project, error)
+	UpdateProject(ctx context.Context, req *resourcemanagerpb.UpdateProjectRequest, opts ...gax.CallOption) (*resourcemanager.UpdateProjectOperation, error)
 }
 
 type serviceAccountClient interface {
@@ -110,6 +113,42 @@ func (r *ProjectRepository) UpdateBinding(ctx context.Context, dataObject *iam.D
 	return updateBindings(ctx, r.projectClient, dataObject, bindingsToAdd, bindingsToDelete)
 }
 
+// UpdateProjectLabels updates the labels of a project. The update is guarded by the etag of the project.
+func (r *ProjectRepository) UpdateProjectLabels(ctx context.Context, projectId string, labels map[string]*string) error {
+	project, err := r.projectClient.GetProject(ctx, &resourcemanagerpb.GetProjectRequest{Name: _resourceName(TypeProject, projectId)})
+	if err != nil {
+		return fmt.Errorf("get project %q: %w", projectId, err)
+	}
+
+	newLabels, changed, err := ApplyLabelUpdates(project.Labels, labels)
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		return nil
+	}
+
+	op, err := r.projectClient.UpdateProject(ctx, &resourcemanagerpb.UpdateProjectRequest{
+		Project: &resourcemanagerpb.Project{
+			Name:   project.Name,
+			Labels: newLabels,
+			Etag:   project.Etag,
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"labels"}},
+	})
+	if err != nil {
+		return fmt.Errorf("update labels of project %q: %w", projectId, err)
+	}
+
+	_, err = op.Wait(ctx)
+	if err != nil {
+		return fmt.Errorf("wait for update of labels of project %q: %w", projectId, err)
+	}
+
+	return nil
+}
+
 func (r *ProjectRepository) GetUsers(ctx context.Context, projectEntryName string, fn func(ctx context.Context, entity *iam.UserEntity) error) error {
 	nextPageToken := ""
 
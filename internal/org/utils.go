@@ -3,6 +3,7 @@ package org
 import (
 	"context"
 	"fmt"
+	"regexp"
 
 	"cloud.google.com/go/iam/apiv1/iampb"
 	"github.com/googleapis/gax-go/v2"
@@ -71,6 +72,8 @@ func updateBindings(ctx context.Context, policyClient setPolicyClient, dataObjec
 
 	common.Logger.Debug(fmt.Sprintf("Updating bindings for policy %q. Adding: %+v; Deleting: %+v", resourceName, membersToAddToRole, membersToRemoveFromRole))
 
+	hadOwner := hasOwner(resourcePolicy)
+
 	for i := range resourcePolicy.Bindings {
 		// Remove old assignees
 		if membersToRemove, found := membersToRemoveFromRole[resourcePolicy.Bindings[i].Role]; found {
@@ -99,6 +102,15 @@ func updateBindings(ctx context.Context, policyClient setPolicyClient, dataObjec
 		})
 	}
 
+	if hadOwner && !hasOwner(resourcePolicy) {
+		return fmt.Errorf("update iam policy for %q: %w", resourceName, iam.ErrLastOwnerRemoval)
+	}
+
+	err = iam.ValidatePolicySize(resourceName, iam.PolicyBindings(resourcePolicy, dataObject.FullName, dataObject.ObjectType))
+	if err != nil {
+		return err
+	}
+
 	common.Logger.Debug(fmt.Sprintf("Setting IAM policy for %q: %+v", resourceName, resourcePolicy))
 
 	_, err = policyClient.SetIamPolicy(ctx, &iampb.SetIamPolicyRequest{Resource: resourceName, Policy: resourcePolicy})
@@ -109,6 +121,59 @@ func updateBindings(ctx context.Context, policyClient setPolicyClient, dataObjec
 	return nil
 }
 
+// hasOwner returns true if the policy grants the owner role to at least one principal without a condition.
+func hasOwner(policy *iampb.Policy) bool {
+	for _, binding := range policy.Bindings {
+		if binding.Role == ownerRole && binding.Condition == nil && len(binding.Members) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+var labelKeyRegex = regexp.MustCompile(`^\p{Ll}[\p{Ll}\p{Lo}\p{N}_-]{0,62}$`)
+var labelValueRegex = regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}_-]{0,63}$`)
+
+// ApplyLabelUpdates applies the label updates on a copy of the current labels. A label with a nil value is removed.
+// It returns the new labels and whether they differ from the current labels.
+func ApplyLabelUpdates(current map[string]string, updates map[string]*string) (map[string]string, bool, error) {
+	result := make(map[string]string, len(current)+len(updates))
+	for k, v := range current {
+		result[k] = v
+	}
+
+	changed := false
+
+	for key, value := range updates {
+		if value == nil {
+			if _, found := result[key]; found {
+				delete(result, key)
+
+				changed = true
+			}
+
+			continue
+		}
+
+		if !labelKeyRegex.MatchString(key) {
+			return nil, false, fmt.Errorf("invalid label key %q", key)
+		}
+
+		if !labelValueRegex.MatchString(*value) {
+			return nil, false, fmt.Errorf("invalid value %q for label %q", *value, key)
+		}
+
+		if currentValue, found := result[key]; !found || currentValue != *value {
+			result[key] = *value
+
+			changed = true
+		}
+	}
+
+	return result, changed, nil
+}
+
 func _resourceName(resourceType string, resourceId string) string {
 	return fmt.Sprintf("%ss/%s", resourceType, resourceId)
 }
@@ -0,0 +1,77 @@
+package org
+
+import (
+	"testing"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/type/expr"
+)
+
+func TestApplyLabelUpdates(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     map[string]string
+		updates     map[string]*string
+		want        map[string]string
+		wantChanged bool
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:        "add, update and remove labels",
+			current:     map[string]string{"team": "sales", "env": "prod"},
+			updates:     map[string]*string{"team": ptr.String("finance"), "env": nil, "owner": ptr.String("john")},
+			want:        map[string]string{"team": "finance", "owner": "john"},
+			wantChanged: true,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "no changes",
+			current:     map[string]string{"team": "sales"},
+			updates:     map[string]*string{"team": ptr.String("sales"), "unknown": nil},
+			want:        map[string]string{"team": "sales"},
+			wantChanged: false,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:    "invalid label key",
+			current: map[string]string{},
+			updates: map[string]*string{"Team": ptr.String("sales")},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "invalid label value",
+			current: map[string]string{},
+			updates: map[string]*string{"team": ptr.String("Sales Team")},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed, err := ApplyLabelUpdates(tt.current, tt.updates)
+			if !tt.wantErr(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantChanged, changed)
+		})
+	}
+}
+
+func TestHasOwner(t *testing.T) {
+	assert.True(t, hasOwner(&iampb.Policy{Bindings: []*iampb.Binding{
+		{Role: "roles/viewer", Members: []string{"user:ruben@raito.io"}},
+		{Role: "roles/owner", Members: []string{"user:ruben@raito.io"}},
+	}}))
+
+	assert.False(t, hasOwner(&iampb.Policy{Bindings: []*iampb.Binding{
+		{Role: "roles/viewer", Members: []string{"user:ruben@raito.io"}},
+		{Role: "roles/owner", Members: []string{}},
+	}}))
+
+	assert.False(t, hasOwner(&iampb.Policy{Bindings: []*iampb.Binding{
+		{Role: "roles/owner", Members: []string{"user:ruben@raito.io"}, Condition: &expr.Expr{Expression: "request.time < timestamp('2030-01-01T00:00:00Z')"}},
+	}}))
+}
@@ -23,6 +23,7 @@ var Wired = wire.NewSet(
 	NewOrganizationRepository,
 	NewGcpDataObjectIterator,
 	NewOrgIdentityStoreSyncer,
+	NewPreflightSampler,
 
 	wire.Bind(new(projectClient), new(*resourcemanager.ProjectsClient)),
 	wire.Bind(new(folderClient), new(*resourcemanager.FoldersClient)),
@@ -0,0 +1,143 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/raito-io/cli/base/util/config"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+)
+
+const adminApi = "admin.googleapis.com"
+
+//go:generate go run github.com/vektra/mockery/v2 --name=ResourceSampler --with-expecter --inpackage
+type ResourceSampler interface {
+	// PreflightResources returns a sample of the resources of which the permissions are tested.
+	PreflightResources(ctx context.Context) ([]Resource, error)
+	// PreflightApis returns the APIs that must be enabled.
+	PreflightApis() []Api
+}
+
+//go:generate go run github.com/vektra/mockery/v2 --name=ServiceChecker --with-expecter --inpackage
+type ServiceChecker interface {
+	IsServiceEnabled(ctx context.Context, service string) (bool, error)
+}
+
+// Checker verifies that the plugin has the permissions and APIs required by each feature, before any sync is executed.
+type Checker struct {
+	sampler  ResourceSampler
+	services ServiceChecker
+
+	gsuiteIdentityStoreSync bool
+}
+
+func NewChecker(sampler ResourceSampler, services ServiceChecker, configMap *config.ConfigMap) *Checker {
+	return &Checker{
+		sampler:  sampler,
+		services: services,
+
+		gsuiteIdentityStoreSync: configMap.GetBoolWithDefault(common.GsuiteIdentityStoreSync, false),
+	}
+}
+
+func (c *Checker) Check(ctx context.Context) (*Report, error) {
+	resources, err := c.sampler.PreflightResources(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sample resources: %w", err)
+	}
+
+	report := &Report{}
+
+	for i := range resources {
+		report.Resources = append(report.Resources, resources[i].Name)
+
+		c.checkResource(ctx, &resources[i], report)
+	}
+
+	apis := c.sampler.PreflightApis()
+
+	if c.gsuiteIdentityStoreSync {
+		apis = append(apis, Api{Name: adminApi, Features: []Feature{IdentityStore}})
+		report.Notes = append(report.Notes, "The GSuite identity store sync and managed groups require domain wide delegation, which cannot be verified by the preflight check.")
+	}
+
+	for _, api := range apis {
+		c.checkApi(ctx, api, report)
+	}
+
+	return report, nil
+}
+
+func (c *Checker) checkResource(ctx context.Context, resource *Resource, report *Report) {
+	c.reportUnverified(resource, report)
+
+	var permissions []string
+
+	for _, feature := range Features {
+		for _, permission := range resource.Requirements[feature] {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+
+	if len(permissions) == 0 {
+		return
+	}
+
+	granted, err := resource.TestPermissions(ctx, permissions)
+
+	for _, feature := range Features {
+		required := resource.Requirements[feature]
+		if len(required) == 0 {
+			continue
+		}
+
+		if err != nil {
+			report.Findings = append(report.Findings, Finding{Feature: feature, Message: fmt.Sprintf("unable to test the permissions on %s: %s", resource.Name, err.Error())})
+
+			continue
+		}
+
+		var missing []string
+
+		for _, permission := range required {
+			if !slices.Contains(granted, permission) {
+				missing = append(missing, permission)
+			}
+		}
+
+		if len(missing) > 0 {
+			report.Findings = append(report.Findings, Finding{Feature: feature, Message: fmt.Sprintf("%s: missing %s", resource.Name, strings.Join(missing, ", "))})
+		}
+	}
+}
+
+func (c *Checker) reportUnverified(resource *Resource, report *Report) {
+	var unverified []string
+
+	for _, feature := range Features {
+		if len(resource.Unverified[feature]) > 0 {
+			unverified = append(unverified, fmt.Sprintf("%s (%s)", strings.Join(resource.Unverified[feature], ", "), feature))
+		}
+	}
+
+	if len(unverified) > 0 {
+		report.Notes = append(report.Notes, fmt.Sprintf("%s: the permissions %s cannot be tested on this resource and are not verified.", resource.Name, strings.Join(unverified, ", ")))
+	}
+}
+
+func (c *Checker) checkApi(ctx context.Context, api Api, report *Report) {
+	enabled, err := c.services.IsServiceEnabled(ctx, api.Name)
+
+	for _, feature := range api.Features {
+		if err != nil {
+			report.Findings = append(report.Findings, Finding{Feature: feature, Message: fmt.Sprintf("unable to verify that API %s is enabled: %s", api.Name, err.Error())})
+		} else if !enabled {
+			report.Findings = append(report.Findings, Finding{Feature: feature, Message: fmt.Sprintf("API %s is not enabled", api.Name)})
+		}
+	}
+}
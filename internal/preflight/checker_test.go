@@ -0,0 +1,156 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+)
+
+func TestChecker_Check(t *testing.T) {
+	granted := func(permissions ...string) func(ctx context.Context, _ []string) ([]string, error) {
+		return func(ctx context.Context, _ []string) ([]string, error) {
+			return permissions, nil
+		}
+	}
+
+	project := Resource{
+		Name: `project "project1"`,
+		Requirements: map[Feature][]string{
+			DataSource:  {"resourcemanager.projects.get", "bigquery.datasets.get"},
+			AccessWrite: {"resourcemanager.projects.getIamPolicy", "resourcemanager.projects.setIamPolicy"},
+			Usage:       {"bigquery.jobs.listAll"},
+		},
+	}
+
+	apis := []Api{{Name: "bigquery.googleapis.com", Features: []Feature{DataSource, Usage}}}
+
+	tests := []struct {
+		name         string
+		testFn       func(ctx context.Context, permissions []string) ([]string, error)
+		unverified   map[Feature][]string
+		apiEnabled   bool
+		gsuite       bool
+		wantFindings []Finding
+		wantNotes    []string
+	}{
+		{
+			name:       "All permissions granted",
+			testFn:     granted("resourcemanager.projects.get", "bigquery.datasets.get", "resourcemanager.projects.getIamPolicy", "resourcemanager.projects.setIamPolicy", "bigquery.jobs.listAll"),
+			apiEnabled: true,
+		},
+		{
+			name:       "Missing permissions and disabled API",
+			testFn:     granted("resourcemanager.projects.get", "bigquery.datasets.get", "resourcemanager.projects.getIamPolicy"),
+			apiEnabled: false,
+			wantFindings: []Finding{
+				{Feature: AccessWrite, Message: `project "project1": missing resourcemanager.projects.setIamPolicy`},
+				{Feature: Usage, Message: `project "project1": missing bigquery.jobs.listAll`},
+				{Feature: DataSource, Message: "API bigquery.googleapis.com is not enabled"},
+				{Feature: Usage, Message: "API bigquery.googleapis.com is not enabled"},
+			},
+		},
+		{
+			name: "Permission test fails",
+			testFn: func(ctx context.Context, _ []string) ([]string, error) {
+				return nil, errors.New("boom")
+			},
+			apiEnabled: true,
+			wantFindings: []Finding{
+				{Feature: DataSource, Message: `unable to test the permissions on project "project1": boom`},
+				{Feature: AccessWrite, Message: `unable to test the permissions on project "project1": boom`},
+				{Feature: Usage, Message: `unable to test the permissions on project "project1": boom`},
+			},
+		},
+		{
+			name:       "GSuite identity store sync",
+			testFn:     granted("resourcemanager.projects.get", "bigquery.datasets.get", "resourcemanager.projects.getIamPolicy", "resourcemanager.projects.setIamPolicy", "bigquery.jobs.listAll"),
+			apiEnabled: true,
+			gsuite:     true,
+			wantNotes:  []string{"The GSuite identity store sync and managed groups require domain wide delegation, which cannot be verified by the preflight check."},
+		},
+		{
+			name:       "Unverified permissions",
+			testFn:     granted("resourcemanager.projects.get", "bigquery.datasets.get", "resourcemanager.projects.getIamPolicy", "resourcemanager.projects.setIamPolicy", "bigquery.jobs.listAll"),
+			unverified: map[Feature][]string{AccessWrite: {"bigquery.datasets.update"}, DataSource: {"bigquery.tables.list"}},
+			apiEnabled: true,
+			wantNotes:  []string{`project "project1": the permissions bigquery.tables.list (data source), bigquery.datasets.update (access write) cannot be tested on this resource and are not verified.`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler := NewMockResourceSampler(t)
+			services := NewMockServiceChecker(t)
+
+			resource := project
+			resource.TestPermissions = tt.testFn
+			resource.Unverified = tt.unverified
+
+			sampler.EXPECT().PreflightResources(mock.Anything).Return([]Resource{resource}, nil)
+			sampler.EXPECT().PreflightApis().Return(apis)
+			services.EXPECT().IsServiceEnabled(mock.Anything, "bigquery.googleapis.com").Return(tt.apiEnabled, nil)
+
+			if tt.gsuite {
+				services.EXPECT().IsServiceEnabled(mock.Anything, adminApi).Return(true, nil)
+			}
+
+			checker := NewChecker(sampler, services, &config.ConfigMap{Parameters: map[string]string{common.GsuiteIdentityStoreSync: boolString(tt.gsuite)}})
+
+			report, err := checker.Check(context.Background())
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{`project "project1"`}, report.Resources)
+			assert.Equal(t, tt.wantFindings, report.Findings)
+			assert.Equal(t, tt.wantNotes, report.Notes)
+			assert.Equal(t, len(tt.wantFindings) == 0, report.Ok())
+		})
+	}
+}
+
+func TestParseParameters(t *testing.T) {
+	configMap, err := parseParameters([]string{"--gcp-project-id", "project1", "--gcp-preflight-sample-size=5", "--gsuite-identity-store-sync"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"gcp-project-id":             "project1",
+		"gcp-preflight-sample-size":  "5",
+		"gsuite-identity-store-sync": "true",
+	}, configMap.Parameters)
+
+	_, err = parseParameters([]string{"project1"})
+	assert.Error(t, err)
+}
+
+func TestReport_String(t *testing.T) {
+	report := Report{
+		Resources: []string{`project "project1"`},
+		Findings:  []Finding{{Feature: Masking, Message: "API datacatalog.googleapis.com is not enabled"}},
+	}
+
+	assert.Equal(t, `Preflight check
+Checked resources: project "project1"
+
+[OK]      data source
+[OK]      access write
+[MISSING] masking
+          - API datacatalog.googleapis.com is not enabled
+[OK]      filtering
+[OK]      usage
+[OK]      identity store
+`, report.String())
+}
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+
+	return "false"
+}
@@ -0,0 +1,81 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/iam/apiv1/iampb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/raito-io/cli/base/util/config"
+	"google.golang.org/api/option"
+	"google.golang.org/api/serviceusage/v1"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/credentials"
+)
+
+// DefaultSampleSize is the default number of resources of each type of which the permissions are tested.
+const DefaultSampleSize = 3
+
+// IamPermissionTester is implemented by the resource manager clients.
+type IamPermissionTester interface {
+	TestIamPermissions(ctx context.Context, req *iampb.TestIamPermissionsRequest, opts ...gax.CallOption) (*iampb.TestIamPermissionsResponse, error)
+}
+
+// TestIamPermissions returns a function that tests the permissions on a resource (e.g. projects/my-project) using the given client.
+func TestIamPermissions(client IamPermissionTester, resource string) func(ctx context.Context, permissions []string) ([]string, error) {
+	return func(ctx context.Context, permissions []string) ([]string, error) {
+		response, err := client.TestIamPermissions(ctx, &iampb.TestIamPermissionsRequest{
+			Resource:    resource,
+			Permissions: permissions,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("test iam permissions on %q: %w", resource, err)
+		}
+
+		return response.Permissions, nil
+	}
+}
+
+// ServiceUsageChecker verifies that APIs are enabled in the project of the plugin.
+// This is the configured project, or the project of the service account if no project is configured.
+type ServiceUsageChecker struct {
+	services  *serviceusage.ServicesService
+	projectId string
+}
+
+func NewServiceUsageChecker(ctx context.Context, configMap *config.ConfigMap) (*ServiceUsageChecker, error) {
+	creds, err := credentials.New(ctx, configMap)
+	if err != nil {
+		return nil, fmt.Errorf("service usage client credentials: %w", err)
+	}
+
+	service, err := serviceusage.NewService(ctx, option.WithCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("new service usage client: %w", err)
+	}
+
+	projectId := configMap.GetString(common.GcpProjectId)
+	if projectId == "" {
+		projectId = creds.ProjectID
+	}
+
+	return &ServiceUsageChecker{
+		services:  service.Services,
+		projectId: projectId,
+	}, nil
+}
+
+func (c *ServiceUsageChecker) IsServiceEnabled(ctx context.Context, service string) (bool, error) {
+	if c.projectId == "" {
+		return false, errors.New("unable to determine the project of the service account")
+	}
+
+	s, err := c.services.Get(fmt.Sprintf("projects/%s/services/%s", c.projectId, service)).Context(ctx).Do()
+	if err != nil {
+		return false, fmt.Errorf("get service %q: %w", service, err)
+	}
+
+	return s.State == "ENABLED", nil
+}
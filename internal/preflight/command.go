@@ -0,0 +1,77 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/raito-io/cli/base/util/config"
+)
+
+// Command is the name of the command line argument that runs the preflight check instead of the plugin.
+const Command = "preflight"
+
+// Run executes the preflight check with the parameters of the command line (e.g. --gcp-project-id my-project) and writes the report to out.
+// It returns the exit code of the command: 0 if no problems were found, 1 otherwise.
+func Run(ctx context.Context, args []string, out io.Writer, initialize func(ctx context.Context, configMap *config.ConfigMap) (*Checker, func(), error)) int {
+	configMap, err := parseParameters(args)
+	if err != nil {
+		fmt.Fprintf(out, "Invalid arguments: %s\n", err.Error())
+
+		return 1
+	}
+
+	checker, cleanup, err := initialize(ctx, configMap)
+	if err != nil {
+		fmt.Fprintf(out, "Unable to initialize the preflight check: %s\n", err.Error())
+
+		return 1
+	}
+
+	defer cleanup()
+
+	report, err := checker.Check(ctx)
+	if err != nil {
+		fmt.Fprintf(out, "Preflight check failed: %s\n", err.Error())
+
+		return 1
+	}
+
+	fmt.Fprint(out, report.String())
+
+	if !report.Ok() {
+		return 1
+	}
+
+	return 0
+}
+
+// parseParameters converts arguments like --name value or --name=value into a config map.
+func parseParameters(args []string) (*config.ConfigMap, error) {
+	parameters := make(map[string]string)
+
+	for i := 0; i < len(args); i++ {
+		name, found := strings.CutPrefix(args[i], "--")
+		if !found || name == "" {
+			return nil, fmt.Errorf("unexpected argument %q", args[i])
+		}
+
+		if key, value, hasValue := strings.Cut(name, "="); hasValue {
+			parameters[key] = value
+
+			continue
+		}
+
+		if i+1 >= len(args) || strings.HasPrefix(args[i+1], "--") {
+			parameters[name] = "true"
+
+			continue
+		}
+
+		parameters[name] = args[i+1]
+		i++
+	}
+
+	return &config.ConfigMap{Parameters: parameters}, nil
+}
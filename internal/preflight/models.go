@@ -0,0 +1,95 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type Feature string
+
+const (
+	DataSource    Feature = "data source"
+	AccessWrite   Feature = "access write"
+	Masking       Feature = "masking"
+	Filtering     Feature = "filtering"
+	Usage         Feature = "usage"
+	IdentityStore Feature = "identity store"
+)
+
+// Features lists all features in the order of the report.
+var Features = []Feature{DataSource, AccessWrite, Masking, Filtering, Usage, IdentityStore}
+
+// Resource is a resource on which the permissions of the features are tested.
+type Resource struct {
+	Name         string // e.g. project "my-project"
+	Requirements map[Feature][]string
+
+	// Unverified are the permissions that are required on the resource, but cannot be tested on it. They are reported as a note.
+	Unverified map[Feature][]string
+
+	// TestPermissions returns the subset of the permissions that is granted on the resource.
+	TestPermissions func(ctx context.Context, permissions []string) ([]string, error)
+}
+
+// Api is a Google API that must be enabled to use the features.
+type Api struct {
+	Name     string // e.g. bigquery.googleapis.com
+	Features []Feature
+}
+
+// Finding is a problem that prevents a feature from working.
+type Finding struct {
+	Feature Feature
+	Message string
+}
+
+type Report struct {
+	Resources []string
+	Findings  []Finding
+	Notes     []string
+}
+
+// Ok returns true if no problems were found.
+func (r *Report) Ok() bool {
+	return len(r.Findings) == 0
+}
+
+func (r *Report) String() string {
+	sb := strings.Builder{}
+
+	sb.WriteString("Preflight check\n")
+	sb.WriteString(fmt.Sprintf("Checked resources: %s\n\n", strings.Join(r.Resources, ", ")))
+
+	for _, feature := range Features {
+		var messages []string
+
+		for _, finding := range r.Findings {
+			if finding.Feature == feature {
+				messages = append(messages, finding.Message)
+			}
+		}
+
+		if len(messages) == 0 {
+			sb.WriteString(fmt.Sprintf("[OK]      %s\n", feature))
+
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("[MISSING] %s\n", feature))
+
+		for _, msg := range messages {
+			sb.WriteString(fmt.Sprintf("          - %s\n", msg))
+		}
+	}
+
+	if len(r.Notes) > 0 {
+		sb.WriteString("\nNotes:\n")
+
+		for _, note := range r.Notes {
+			sb.WriteString(fmt.Sprintf("- %s\n", note))
+		}
+	}
+
+	return sb.String()
+}
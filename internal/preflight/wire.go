@@ -0,0 +1,15 @@
+//go:build wireinject
+// +build wireinject
+
+package preflight
+
+import (
+	"github.com/google/wire"
+)
+
+var Wired = wire.NewSet(
+	NewChecker,
+	NewServiceUsageChecker,
+
+	wire.Bind(new(ServiceChecker), new(*ServiceUsageChecker)),
+)
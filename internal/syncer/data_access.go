@@ -2,16 +2,21 @@ package syncer
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/smithy-go/ptr"
 	"github.com/hashicorp/go-multierror"
 	"github.com/raito-io/cli/base/access_provider"
 	"github.com/raito-io/cli/base/access_provider/types"
 	"github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/tag"
 	"github.com/raito-io/cli/base/wrappers"
 	"github.com/raito-io/golang-set/set"
 
@@ -29,6 +34,7 @@ import (
 //go:generate go run github.com/vektra/mockery/v2 --name=BindingRepository --with-expecter --inpackage
 type BindingRepository interface {
 	Bindings(ctx context.Context, config *data_source.DataSourceSyncConfig, fn func(ctx context.Context, dataObject *org.GcpOrgEntity, bindings []iam.IamBinding) error) error
+	GetBindings(ctx context.Context, dataObject *iam.DataObjectReference) ([]iam.IamBinding, error)
 	UpdateBindings(ctx context.Context, dataObject *iam.DataObjectReference, addBindings []iam.IamBinding, removeBindings []iam.IamBinding) error
 
 	DataSourceType() string
@@ -43,7 +49,11 @@ type ProjectRepo interface {
 type MaskingService interface {
 	ImportMasks(ctx context.Context, accessProviderHandler wrappers.AccessProviderHandler, locations set.Set[string], maskingTags map[string][]string, raitoMasks set.Set[string]) error
 	ExportMasks(ctx context.Context, accessProvider *importer.AccessProvider, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler) ([]string, error)
-	MaskedBinding(ctx context.Context, members []string) ([]iam.IamBinding, error)
+	ExportColumnAccess(ctx context.Context, accessProvider *importer.AccessProvider, feedback *importer.AccessProviderSyncFeedback) []string
+	ApplyColumnPolicyTags(ctx context.Context) error
+	MaskedReaderDataPolicies(ctx context.Context, dataObject *iam.DataObjectReference) ([]string, error)
+	UpdateMaskedReaders(ctx context.Context, dataPolicyId string, membersToAdd []string, membersToRemove []string) error
+	LegacyMaskedReaderResource() *iam.DataObjectReference
 }
 
 //go:generate go run github.com/vektra/mockery/v2 --name=FilteringService --with-expecter --inpackage
@@ -57,6 +67,7 @@ type AccessSyncer struct {
 	projectRepo      ProjectRepo
 	maskingService   MaskingService
 	filteringService FilteringService
+	managedGroupRepo ManagedGroupRepository
 	metadata         *data_source.MetaData
 
 	maskingSupport  bool
@@ -64,13 +75,29 @@ type AccessSyncer struct {
 
 	filteringSupport bool
 
+	// managedGroups is nil if access providers are not materialized as managed Google Groups
+	managedGroups *managedGroupNaming
+
+	guardrails *accessGuardrails
+	protected  *protectedBindings
+
+	// state is kept between access syncs, e.g. to remove the legacy masked reader bindings only once. It is nil if no state directory is configured.
+	state *common.Cache[time.Time]
+
 	// cache
+	// Ownership of bindings is deliberately not persisted in GCP: IAM bindings only carry metadata in the title and description of a condition,
+	// and adding a condition changes the binding into a conditional binding, which basic roles and several resources do not support
+	// and which counts against the limit of conditional bindings per policy. Managed groups recognise Raito bindings across syncs instead.
 	raitoManagedBindings set.Set[iam.IamBinding]
 	raitoMasks           set.Set[string]
 	raitoFilters         set.Set[string]
+
+	// existingManagedGroups are the groups managed by Raito and unmanagedGroups the groups that only follow the naming of managed groups, keyed by their lowercase email address
+	existingManagedGroups set.Set[string]
+	unmanagedGroups       set.Set[string]
 }
 
-func NewDataAccessSyncer(bindingRepo BindingRepository, projectRepo ProjectRepo, maskingService MaskingService, filteringService FilteringService, metadata *data_source.MetaData, configmap *config.ConfigMap) *AccessSyncer {
+func NewDataAccessSyncer(bindingRepo BindingRepository, projectRepo ProjectRepo, maskingService MaskingService, filteringService FilteringService, managedGroupRepo ManagedGroupRepository, metadata *data_source.MetaData, configmap *config.ConfigMap) *AccessSyncer {
 	maskingSupport := false
 	filteringSupport := false
 
@@ -83,20 +110,37 @@ func NewDataAccessSyncer(bindingRepo BindingRepository, projectRepo ProjectRepo,
 	}
 
 	return &AccessSyncer{
-		bindingRepo:          bindingRepo,
-		projectRepo:          projectRepo,
-		maskingService:       maskingService,
-		filteringService:     filteringService,
-		metadata:             metadata,
-		maskingSupport:       maskingSupport,
-		addMaskedReader:      configmap.GetBoolWithDefault(common.GcpMaskedReader, false) || configmap.GetBoolWithDefault(common.BqCatalogEnabled, false),
-		filteringSupport:     filteringSupport,
-		raitoManagedBindings: set.NewSet[iam.IamBinding](),
-		raitoMasks:           set.NewSet[string](),
-		raitoFilters:         set.NewSet[string](),
+		bindingRepo:           bindingRepo,
+		projectRepo:           projectRepo,
+		maskingService:        maskingService,
+		filteringService:      filteringService,
+		managedGroupRepo:      managedGroupRepo,
+		metadata:              metadata,
+		maskingSupport:        maskingSupport,
+		addMaskedReader:       configmap.GetBoolWithDefault(common.GcpMaskedReader, false) || configmap.GetBoolWithDefault(common.BqCatalogEnabled, false),
+		filteringSupport:      filteringSupport,
+		managedGroups:         newManagedGroupNaming(configmap),
+		guardrails:            newAccessGuardrails(configmap),
+		protected:             newProtectedBindings(configmap),
+		state:                 newAccessSyncState(configmap),
+		raitoManagedBindings:  set.NewSet[iam.IamBinding](),
+		raitoMasks:            set.NewSet[string](),
+		raitoFilters:          set.NewSet[string](),
+		existingManagedGroups: set.NewSet[string](),
+		unmanagedGroups:       set.NewSet[string](),
 	}
 }
 
+// newAccessSyncState returns the state that is kept between access syncs in the configured state directory, or nil if no directory is configured.
+func newAccessSyncState(configmap *config.ConfigMap) *common.Cache[time.Time] {
+	dir := configmap.GetString(common.GcpStateDir)
+	if dir == "" {
+		return nil
+	}
+
+	return common.NewCache[time.Time](0, dir)
+}
+
 func (a *AccessSyncer) SyncAccessProvidersFromTarget(ctx context.Context, accessProviderHandler wrappers.AccessProviderHandler, configMap *config.ConfigMap) error {
 	var allBindings []iam.IamBinding
 	locations := set.NewSet[string]()
@@ -104,7 +148,14 @@ func (a *AccessSyncer) SyncAccessProvidersFromTarget(ctx context.Context, access
 
 	syncConfig := data_source.DataSourceSyncConfig{ConfigMap: configMap}
 
-	err := a.bindingRepo.Bindings(ctx, &syncConfig, func(ctx context.Context, dataObject *org.GcpOrgEntity, bindings []iam.IamBinding) error {
+	existingManagedGroups, unmanagedGroups, err := a.loadManagedGroups(ctx)
+	if err != nil {
+		return fmt.Errorf("list managed groups: %w", err)
+	}
+
+	a.existingManagedGroups, a.unmanagedGroups = existingManagedGroups, unmanagedGroups
+
+	err = a.bindingRepo.Bindings(ctx, &syncConfig, func(ctx context.Context, dataObject *org.GcpOrgEntity, bindings []iam.IamBinding) error {
 		allBindings = append(allBindings, bindings...)
 
 		if a.maskingSupport && dataObject.Type == data_source.Column && len(dataObject.PolicyTags) > 0 {
@@ -150,34 +201,21 @@ func (a *AccessSyncer) SyncAccessProvidersFromTarget(ctx context.Context, access
 	return nil
 }
 
-func (a *AccessSyncer) SyncAccessProviderToTarget(ctx context.Context, accessProviders *importer.AccessProviderImport, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler, _ *config.ConfigMap) error {
+func (a *AccessSyncer) SyncAccessProviderToTarget(ctx context.Context, accessProviders *importer.AccessProviderImport, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler, _ *config.ConfigMap) (err error) {
 	common.Logger.Info(fmt.Sprintf("Start converting %d access providers to bindings", len(accessProviders.AccessProviders)))
 
 	grants := make([]*importer.AccessProvider, 0, len(accessProviders.AccessProviders))
 
-	// Handle masks
+	var masks, filters []*importer.AccessProvider
+
 	for _, ap := range accessProviders.AccessProviders {
 		switch ap.Action {
 		case types.Grant, types.Purpose:
 			grants = append(grants, ap)
 		case types.Mask:
-			raitoMask, err := a.maskingService.ExportMasks(ctx, ap, accessProviderFeedbackHandler)
-			if err != nil {
-				return fmt.Errorf("export masks: %w", err)
-			}
-
-			if raitoMask != nil {
-				a.raitoMasks.Add(raitoMask...)
-			}
+			masks = append(masks, ap)
 		case types.Filtered:
-			raitoFilter, err := a.filteringService.ExportFilter(ctx, ap, accessProviderFeedbackHandler)
-			if err != nil {
-				return fmt.Errorf("export filters: %w", err)
-			}
-
-			if raitoFilter != nil {
-				a.raitoFilters.Add(*raitoFilter)
-			}
+			filters = append(filters, ap)
 		default:
 			err := accessProviderFeedbackHandler.AddAccessProviderFeedback(importer.AccessProviderSyncFeedback{
 				AccessProvider: ap.Id,
@@ -190,16 +228,24 @@ func (a *AccessSyncer) SyncAccessProviderToTarget(ctx context.Context, accessPro
 		}
 	}
 
-	// Handle grants
-	bindings := a.convertAccessProviderToBindings(ctx, grants)
+	apFeedback := make(map[string]*importer.AccessProviderSyncFeedback)
 
-	common.Logger.Info("Done converting access providers to bindings.")
+	existingManagedGroups, unmanagedGroups, err := a.loadManagedGroups(ctx)
+	if err != nil {
+		return fmt.Errorf("list managed groups: %w", err)
+	}
 
-	apFeedback := make(map[string]*importer.AccessProviderSyncFeedback)
+	a.existingManagedGroups, a.unmanagedGroups = existingManagedGroups, unmanagedGroups
+
+	managedGroupAps := a.managedGroupAccessProviders(grants)
 
 	for _, ap := range grants {
 		apFeedback[ap.Id] = &importer.AccessProviderSyncFeedback{AccessProvider: ap.Id, ActualName: ap.Id, Type: ptr.String(access_provider.AclSet)}
 
+		if managedGroupAps.Contains(ap.Id) {
+			apFeedback[ap.Id].ActualName = a.managedGroups.groupEmail(ap.Id)
+		}
+
 		if !ap.Delete {
 			apFeedback[ap.Id].State = &importer.AccessProviderFeedbackState{
 				Who: importer.AccessProviderWhoFeedbackState{
@@ -210,10 +256,109 @@ func (a *AccessSyncer) SyncAccessProviderToTarget(ctx context.Context, accessPro
 		}
 	}
 
+	// The binding changes are computed and checked against the guardrails before anything is updated
+	bindings := a.convertAccessProviderToBindings(ctx, grants, managedGroupAps)
+
+	common.Logger.Info("Done converting access providers to bindings.")
+
+	a.protected.filter(bindings, apFeedback)
+
+	// The other changes are planned as well, so the guardrails count all removals
+	changes := &guardrailChanges{}
+
+	if a.guardrails.needsCurrentBindings() {
+		changes.currentBindings, err = a.currentBindings(ctx, bindings)
+		if err != nil {
+			return fmt.Errorf("load current bindings: %w", err)
+		}
+	}
+
+	var groupUpdates []*managedGroupUpdate
+
+	if a.managedGroups != nil {
+		groupUpdates = a.planManagedGroups(ctx, grants, managedGroupAps, apFeedback)
+
+		for _, update := range groupUpdates {
+			changes.removedGroupMembers += len(update.membersToRemove)
+			changes.currentGroupMembers += update.currentMembers
+		}
+	}
+
+	maskedReaders := a.planMaskedReaders(ctx, grants, managedGroupAps, apFeedback)
+	legacyMaskedReaders := a.planLegacyMaskedReaders(ctx, grants, bindings, apFeedback)
+	changes.removedMaskedReaders = a.maskedReaderRemovals(maskedReaders.updates(set.NewSet[string]())) + legacyMaskedReaders.removals()
+
+	changes.removedMasks = countDeleted(masks)
+	changes.removedFilters = countDeleted(filters)
+
+	blocked, blockAll := a.guardrails.check(bindings, changes, apFeedback)
+	if blockAll {
+		return a.skipAccessProviders(masks, filters, apFeedback, accessProviderFeedbackHandler)
+	}
+
+	blockedAps := blockedAccessProviders(bindings, blocked)
+
+	// The feedback of exported masks is only sent when the column policy tags are applied,
+	// so they are also applied if the export stops early.
+	columnPolicyTagsApplied := false
+
+	defer func() {
+		if columnPolicyTagsApplied {
+			return
+		}
+
+		if applyErr := a.maskingService.ApplyColumnPolicyTags(ctx); applyErr != nil {
+			err = multierror.Append(err, fmt.Errorf("apply column policy tags: %w", applyErr))
+		}
+	}()
+
+	for _, ap := range masks {
+		raitoMask, err := a.maskingService.ExportMasks(ctx, ap, accessProviderFeedbackHandler)
+		if err != nil {
+			return fmt.Errorf("export masks: %w", err)
+		}
+
+		if raitoMask != nil {
+			a.raitoMasks.Add(raitoMask...)
+		}
+	}
+
+	for _, ap := range filters {
+		raitoFilter, err := a.filteringService.ExportFilter(ctx, ap, accessProviderFeedbackHandler)
+		if err != nil {
+			return fmt.Errorf("export filters: %w", err)
+		}
+
+		if raitoFilter != nil {
+			a.raitoFilters.Add(*raitoFilter)
+		}
+	}
+
+	a.exportColumnAccess(ctx, grants, blockedAps, apFeedback)
+
+	// Tag the columns of all masks and column accesses at once, to update each table only once
+	columnPolicyTagsApplied = true
+
+	err = a.maskingService.ApplyColumnPolicyTags(ctx)
+	if err != nil {
+		return fmt.Errorf("apply column policy tags: %w", err)
+	}
+
+	if a.managedGroups != nil {
+		a.syncManagedGroups(ctx, groupUpdates, blockedAps, apFeedback)
+	}
+
+	a.exportMaskedReaders(ctx, maskedReaders.updates(blockedAps), apFeedback)
+	a.removeLegacyMaskedReaders(ctx, legacyMaskedReaders, blockedAps, apFeedback)
+
 	wg := sync.WaitGroup{}
 	mutex := sync.Mutex{}
 
 	for do := range bindings.bindings {
+		if blocked.Contains(do) {
+			continue
+		}
+
 		wg.Add(1)
 
 		go func(do iam.DataObjectReference) {
@@ -230,7 +375,11 @@ func (a *AccessSyncer) SyncAccessProviderToTarget(ctx context.Context, accessPro
 			mutex.Lock()
 			defer mutex.Unlock()
 
-			if err != nil {
+			var policySizeErr *iam.PolicySizeError
+
+			if errors.As(err, &policySizeErr) {
+				a.handlePolicySizeError(do, policySizeErr, bindings.bindings[do], apFeedback)
+			} else if err != nil {
 				handleErrors(fmt.Errorf("update bindings of %s %q: %w", do.ObjectType, do.FullName, err), apFeedback, bindings.bindings[do].GetAllAccessProviders())
 			}
 
@@ -241,6 +390,89 @@ func (a *AccessSyncer) SyncAccessProviderToTarget(ctx context.Context, accessPro
 
 	wg.Wait()
 
+	if a.managedGroups != nil {
+		a.deleteManagedGroups(ctx, grants, managedGroupAps, apFeedback)
+	}
+
+	return addAccessProviderFeedback(apFeedback, accessProviderFeedbackHandler)
+}
+
+// currentBindings returns the number of bindings on each data object of which the bindings are updated.
+func (a *AccessSyncer) currentBindings(ctx context.Context, bindings *BindingContainer) (map[iam.DataObjectReference]int, error) {
+	result := make(map[iam.DataObjectReference]int, len(bindings.bindings))
+
+	wg := sync.WaitGroup{}
+	mutex := sync.Mutex{}
+
+	var merr error
+
+	for do := range bindings.bindings {
+		wg.Add(1)
+
+		go func(do iam.DataObjectReference) {
+			defer wg.Done()
+
+			current, err := a.bindingRepo.GetBindings(ctx, &do)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if err != nil {
+				merr = multierror.Append(merr, fmt.Errorf("bindings of %s %q: %w", do.ObjectType, do.FullName, err))
+
+				return
+			}
+
+			result[do] = len(current)
+		}(do)
+	}
+
+	wg.Wait()
+
+	if merr != nil {
+		return nil, merr
+	}
+
+	return result, nil
+}
+
+func countDeleted(accessProviders []*importer.AccessProvider) int {
+	deleted := 0
+
+	for _, ap := range accessProviders {
+		if ap.Delete {
+			deleted++
+		}
+	}
+
+	return deleted
+}
+
+// skipAccessProviders reports that none of the access providers is updated, as the whole access sync is blocked by a guardrail.
+func (a *AccessSyncer) skipAccessProviders(masks []*importer.AccessProvider, filters []*importer.AccessProvider, apFeedback map[string]*importer.AccessProviderSyncFeedback, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler) error {
+	msg := a.guardrails.skippedMessage()
+
+	for _, feedback := range apFeedback {
+		if len(feedback.Errors) == 0 {
+			feedback.Errors = append(feedback.Errors, msg)
+		}
+	}
+
+	for _, ap := range append(masks, filters...) {
+		common.Logger.Info(fmt.Sprintf("Access guardrail breached: access provider %q is not exported", ap.Id))
+
+		actualName := ap.Id
+		if ap.ActualName != nil {
+			actualName = *ap.ActualName
+		}
+
+		apFeedback[ap.Id] = &importer.AccessProviderSyncFeedback{AccessProvider: ap.Id, ActualName: actualName, Errors: []string{msg}}
+	}
+
+	return addAccessProviderFeedback(apFeedback, accessProviderFeedbackHandler)
+}
+
+func addAccessProviderFeedback(apFeedback map[string]*importer.AccessProviderSyncFeedback, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler) error {
 	var merr error
 
 	for _, apsf := range apFeedback {
@@ -253,6 +485,37 @@ func (a *AccessSyncer) SyncAccessProviderToTarget(ctx context.Context, accessPro
 	return merr
 }
 
+// exportColumnAccess grants access to the columns of the access providers through policy tags, as columns have no IAM policy. Blocked access providers are skipped.
+func (a *AccessSyncer) exportColumnAccess(ctx context.Context, grants []*importer.AccessProvider, blockedAps set.Set[string], apFeedback map[string]*importer.AccessProviderSyncFeedback) {
+	for _, ap := range grants {
+		if !hasColumnWhatItems(ap) || blockedAps.Contains(ap.Id) {
+			continue
+		}
+
+		if !a.maskingSupport {
+			apFeedback[ap.Id].Errors = append(apFeedback[ap.Id].Errors, "column-level access is not supported by this data source")
+
+			continue
+		}
+
+		policyTags := a.maskingService.ExportColumnAccess(ctx, ap, apFeedback[ap.Id])
+
+		a.raitoMasks.Add(policyTags...)
+	}
+}
+
+func hasColumnWhatItems(ap *importer.AccessProvider) bool {
+	for _, whatItems := range [][]importer.WhatItem{ap.What, ap.DeleteWhat} {
+		for _, w := range whatItems {
+			if w.DataObject != nil && w.DataObject.Type == data_source.Column {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (a *AccessSyncer) ConvertBindingsToAccessProviders(ctx context.Context, configMap *config.ConfigMap, bindings []iam.IamBinding) ([]*exporter.AccessProvider, error) {
 	rolesToGroupByIdentity := set.NewSet[string]()
 
@@ -280,6 +543,16 @@ func (a *AccessSyncer) ConvertBindingsToAccessProviders(ctx context.Context, con
 			continue
 		}
 
+		if a.protected.isProtected(binding) {
+			common.Logger.Debug(fmt.Sprintf("Skipping role %s for %s on %s %s as it is protected", binding.Role, binding.Member, binding.Resource, binding.ResourceType))
+			continue
+		}
+
+		if a.isExistingManagedGroup(binding.Member) {
+			common.Logger.Debug(fmt.Sprintf("Skipping role %s for managed group %s on %s %s as it is managed by raito", binding.Role, binding.Member, binding.Resource, binding.ResourceType))
+			continue
+		}
+
 		if a.raitoManagedBindings.Contains(binding) {
 			common.Logger.Debug(fmt.Sprintf("Skipping role %s for %s on %s %s as it is managed by raito", binding.Role, binding.Member, binding.Resource, binding.ResourceType))
 			continue
@@ -288,7 +561,9 @@ func (a *AccessSyncer) ConvertBindingsToAccessProviders(ctx context.Context, con
 		dataSourceSpecificBinding := binding
 		dataSourceSpecificBinding.ResourceType = a.translateResourceTypeToDataSourceType(dataSourceSpecificBinding.ResourceType)
 
-		if strings.HasPrefix(binding.Member, "special_group:") {
+		if binding.Condition != "" {
+			a.generateAccessProvider(binding.ResourceType, dataSourceSpecificBinding, accessProviderMap, managed)
+		} else if strings.HasPrefix(binding.Member, "special_group:") {
 			a.generateSpecialGroupOwnerAccessProvider(dataSourceSpecificBinding, specialGroupAccessProviderMap, projectOwnersWho, projectEditorWho, projectReaderWho)
 		} else if rolesToGroupByIdentity.Contains(binding.Role) {
 			a.generateGroupedByIdentityAcccessProvider(dataSourceSpecificBinding, groupedByIdentityAccessProviderMap)
@@ -328,12 +603,25 @@ func (a *AccessSyncer) generateAccessProvider(actualResourceType string, binding
 	displayName := generateAccessProviderDisplayName(actualResourceType, binding)
 	apName := fmt.Sprintf("%s_%s_%s", actualResourceType, binding.Resource, strings.Replace(binding.Role, "/", "_", -1))
 
+	var tags []*tag.Tag
+
+	// Conditional bindings cannot be expressed in Raito, so they are imported as separate, read-only access providers
+	if binding.Condition != "" {
+		conditionHash := sha256.Sum256([]byte(binding.Condition))
+
+		displayName = fmt.Sprintf("%s (conditional)", displayName)
+		apName = fmt.Sprintf("%s_%s", apName, hex.EncodeToString(conditionHash[:])[:8])
+		managed = false
+		tags = []*tag.Tag{{Key: "gcp.condition", Value: binding.Condition, Source: common.TagSource}}
+	}
+
 	if _, f := accessProviderMap[apName]; !f {
 		accessProviderMap[apName] = &exporter.AccessProvider{
 			ExternalId:        apName,
 			Name:              displayName,
 			NamingHint:        generateNamingHint(apName),
 			NotInternalizable: !managed,
+			Tags:              tags,
 			WhoLocked:         ptr.Bool(false),
 			WhatLocked:        ptr.Bool(false),
 			Action:            types.Grant,
@@ -509,9 +797,35 @@ func handleErrors(err error, apFeedback map[string]*importer.AccessProviderSyncF
 	}
 }
 
+// handlePolicySizeError reports, for each access provider of the data object, how many principals it adds to the policy that exceeds the IAM limits.
+func (a *AccessSyncer) handlePolicySizeError(do iam.DataObjectReference, policySizeErr *iam.PolicySizeError, bindings *BindingsForDataObject, apFeedback map[string]*importer.AccessProviderSyncFeedback) {
+	common.Logger.Error(fmt.Sprintf("error while updating bindings of %s %q: %s", do.ObjectType, do.FullName, policySizeErr.Error()))
+
+	addedPrincipals := make(map[string]int)
+
+	for binding := range bindings.bindingsToAdd {
+		for _, ap := range bindings.accessProviders[binding] {
+			addedPrincipals[ap.Id]++
+		}
+	}
+
+	suggestion := fmt.Sprintf("Enable %s to replace the principals of each access control by a single managed group.", common.GcpManagedGroups)
+	if a.managedGroups != nil && a.managedGroups.threshold > 0 {
+		suggestion = fmt.Sprintf("Lower %s to replace the principals of more access controls by a managed group.", common.GcpManagedGroupsThreshold)
+	}
+
+	for _, ap := range bindings.GetAllAccessProviders() {
+		msg := fmt.Sprintf("update bindings of %s %q: %s. This access control adds %d principal(s) to the policy. %s", do.ObjectType, do.FullName, policySizeErr.Error(), addedPrincipals[ap.Id], suggestion)
+
+		if !slices.Contains(apFeedback[ap.Id].Errors, msg) {
+			apFeedback[ap.Id].Errors = append(apFeedback[ap.Id].Errors, msg)
+		}
+	}
+}
+
 func (a *AccessSyncer) isRaitoManagedBinding(binding iam.IamBinding) bool {
 	for _, doType := range a.metadata.DataObjectTypes {
-		doTypeType := doType.Type
+		doTypeType := doType.Name
 		// Dirty hack to map the datasource dataobject type to 'project' in case of bigquery datasource
 		if doTypeType == data_source.Datasource && a.metadata.Type == "bigquery" {
 			doTypeType = "project"
@@ -537,43 +851,20 @@ func (a *AccessSyncer) translateResourceTypeToDataSourceType(doType string) stri
 	return doType
 }
 
-func (a *AccessSyncer) convertAccessProviderToBindings(ctx context.Context, accessProviders []*importer.AccessProvider) *BindingContainer {
+func (a *AccessSyncer) convertAccessProviderToBindings(_ context.Context, accessProviders []*importer.AccessProvider, managedGroupAps set.Set[string]) *BindingContainer {
 	bindings := NewBindingContainer()
 
 	for _, ap := range accessProviders {
 		// Process the Who items
-		members := []string{}
-
-		for _, m := range ap.Who.Users {
-			if strings.Contains(m, "gserviceaccount.com") {
-				members = append(members, "serviceAccount:"+m)
-			} else {
-				members = append(members, "user:"+m)
-			}
-		}
-
-		for _, m := range ap.Who.Groups {
-			members = append(members, "group:"+m)
-		}
-
-		deleteMembers := []string{}
-
-		if ap.DeletedWho != nil {
-			for _, m := range ap.DeletedWho.Users {
-				if strings.Contains(m, "gserviceaccount.com") {
-					deleteMembers = append(deleteMembers, "serviceAccount:"+m)
-				} else {
-					deleteMembers = append(deleteMembers, "user:"+m)
-				}
-			}
-
-			for _, m := range ap.DeletedWho.Groups {
-				deleteMembers = append(deleteMembers, "group:"+m)
-			}
-		}
+		members, deleteMembers := a.grantMembers(ap, managedGroupAps)
 
 		// Process the What Items
 		for _, w := range ap.What {
+			if w.DataObject.Type == data_source.Column {
+				// Column access is granted through policy tags
+				continue
+			}
+
 			objectType := w.DataObject.Type
 			if objectType == data_source.Datasource {
 				objectType = a.bindingRepo.DataSourceType()
@@ -615,25 +906,13 @@ func (a *AccessSyncer) convertAccessProviderToBindings(ctx context.Context, acce
 			}
 		}
 
-		if a.addMaskedReader && !ap.Delete && len(ap.What) > 0 {
-			additionalMaskBindings, err := a.maskingService.MaskedBinding(ctx, members)
-			if err != nil {
-				common.Logger.Error(fmt.Sprintf("error while masking binding: %s", err.Error()))
-			}
-
-			for _, b := range additionalMaskBindings {
-				dataObjectReference := iam.DataObjectReference{
-					FullName:   b.Resource,
-					ObjectType: b.ResourceType,
-				}
-
-				bindings.BindingToAdd(dataObjectReference, b, ap)
-			}
-		}
-
 		// process the Deleted WhatItems
 		if ap.DeleteWhat != nil {
 			for _, w := range ap.DeleteWhat {
+				if w.DataObject.Type == data_source.Column {
+					continue
+				}
+
 				dataObjectReference := iam.DataObjectReference{
 					FullName:   w.DataObject.FullName,
 					ObjectType: w.DataObject.Type,
@@ -659,6 +938,74 @@ func (a *AccessSyncer) convertAccessProviderToBindings(ctx context.Context, acce
 	return bindings
 }
 
+// grantMembers returns the members that should be granted access and the members of which the access should be removed.
+// If the access provider is materialized as managed group, the group is the only member.
+func (a *AccessSyncer) grantMembers(ap *importer.AccessProvider, managedGroupAps set.Set[string]) ([]string, []string) {
+	members, deleteMembers := accessProviderMembers(ap)
+
+	if a.managedGroups != nil {
+		groupMember := "group:" + a.managedGroups.groupEmail(ap.Id)
+
+		switch {
+		case !managedGroupAps.Contains(ap.Id):
+			// The access provider is granted directly, so the bindings of a previously created group are removed
+			if a.groupMaterialized(ap) {
+				deleteMembers = append(deleteMembers, groupMember)
+			}
+		case ap.Delete && a.managedGroups.threshold > 0:
+			// A deleted access provider could have been granted directly or through its group
+			if a.groupMaterialized(ap) {
+				members = append(members, groupMember)
+			}
+		case a.managedGroups.previouslyGrantedDirectly(ap):
+			// The access provider moves to its group, so the direct bindings of its members are removed
+			deleteMembers = append(deleteMembers, members...)
+			members = []string{groupMember}
+		default:
+			// The members of the access provider are managed as members of its group
+			members = []string{groupMember}
+			deleteMembers = nil
+		}
+	}
+
+	return members, deleteMembers
+}
+
+// accessProviderMembers returns the members that should be granted access and the members of which the access should be removed.
+func accessProviderMembers(ap *importer.AccessProvider) ([]string, []string) {
+	members := []string{}
+
+	for _, m := range ap.Who.Users {
+		if strings.Contains(m, "gserviceaccount.com") {
+			members = append(members, "serviceAccount:"+m)
+		} else {
+			members = append(members, "user:"+m)
+		}
+	}
+
+	for _, m := range ap.Who.Groups {
+		members = append(members, "group:"+m)
+	}
+
+	deleteMembers := []string{}
+
+	if ap.DeletedWho != nil {
+		for _, m := range ap.DeletedWho.Users {
+			if strings.Contains(m, "gserviceaccount.com") {
+				deleteMembers = append(deleteMembers, "serviceAccount:"+m)
+			} else {
+				deleteMembers = append(deleteMembers, "user:"+m)
+			}
+		}
+
+		for _, m := range ap.DeletedWho.Groups {
+			deleteMembers = append(deleteMembers, "group:"+m)
+		}
+	}
+
+	return members, deleteMembers
+}
+
 func generateNamingHint(name string) string {
 	const maxLength = 128
 
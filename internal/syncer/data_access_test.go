@@ -12,6 +12,7 @@ import (
 	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
 	"github.com/raito-io/cli/base/access_provider/types"
 	"github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/tag"
 	"github.com/raito-io/cli/base/util/config"
 	"github.com/raito-io/cli/base/wrappers"
 	"github.com/raito-io/cli/base/wrappers/mocks"
@@ -22,7 +23,6 @@ import (
 
 	bigquery "github.com/raito-io/cli-plugin-gcp/internal/bq"
 	"github.com/raito-io/cli-plugin-gcp/internal/common"
-	"github.com/raito-io/cli-plugin-gcp/internal/common/roles"
 	"github.com/raito-io/cli-plugin-gcp/internal/gcp"
 	"github.com/raito-io/cli-plugin-gcp/internal/iam"
 	"github.com/raito-io/cli-plugin-gcp/internal/org"
@@ -74,7 +74,7 @@ func TestAccessSyncer_GenerateAccessProviderDisplayName(t *testing.T) {
 func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 	bqMetadata, err := bigquery.NewDataSourceMetaData(context.Background(), &config.ConfigMap{Parameters: map[string]string{
 		common.BqCatalogEnabled: "true",
-	}})
+	}}, noMaskingRoutines{})
 
 	require.NoError(t, err)
 
@@ -101,7 +101,7 @@ func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 				mockSetup: func(gcpRepo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
 					gcpRepo.EXPECT().Bindings(mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				},
-				metadata: gcp.NewDataSourceMetaData(),
+				metadata: gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 			},
 			args: args{
 				ctx:       context.Background(),
@@ -143,7 +143,7 @@ func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 						)
 					})
 				},
-				metadata:             gcp.NewDataSourceMetaData(),
+				metadata:             gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				raitoManagedBindings: []iam.IamBinding{},
 			},
 			args: args{
@@ -232,7 +232,7 @@ func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 						)
 					})
 				},
-				metadata:             gcp.NewDataSourceMetaData(),
+				metadata:             gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				raitoManagedBindings: []iam.IamBinding{},
 			},
 			args: args{
@@ -377,7 +377,7 @@ func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 						return errors.New("boom")
 					})
 				},
-				metadata:             gcp.NewDataSourceMetaData(),
+				metadata:             gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				raitoManagedBindings: []iam.IamBinding{},
 			},
 			args: args{
@@ -703,7 +703,7 @@ func TestAccessSyncer_ConvertBindingsToAccessProviders(t *testing.T) {
 				mocksSetup: func(gcpRepo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
 
 				},
-				metadata:             gcp.NewDataSourceMetaData(),
+				metadata:             gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				raitoManagedBindings: set.NewSet[iam.IamBinding](),
 			},
 			args: args{
@@ -794,13 +794,67 @@ func TestAccessSyncer_ConvertBindingsToAccessProviders(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "Conditional binding to read-only Access Provider",
+			fields: fields{
+				mocksSetup: func(gcpRepo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
+
+				},
+				metadata:             gcp.NewDataSourceMetaData(&config.ConfigMap{}),
+				raitoManagedBindings: set.NewSet[iam.IamBinding](),
+			},
+			args: args{
+				ctx:       context.Background(),
+				configMap: &config.ConfigMap{},
+				bindings: []iam.IamBinding{
+					{
+						Member:       "user:dieter@raito.io",
+						Resource:     "folder1",
+						ResourceType: "folder",
+						Role:         "roles/viewer",
+						Condition:    "request.time < timestamp('2030-01-01T00:00:00Z')",
+					},
+				},
+			},
+			want: []*sync_from_target.AccessProvider{
+				{
+					ExternalId: "folder_folder1_roles_viewer_250c20f0",
+					Name:       "Folder folder1 - Viewer (conditional)",
+					NamingHint: "folder_folder1_roles_viewer_250c20f0",
+					Type:       ptr.String(access_provider.AclSet),
+					Action:     types.Grant,
+					Who: &sync_from_target.WhoItem{
+						Users:           []string{"dieter@raito.io"},
+						Groups:          []string{},
+						AccessProviders: []string{},
+					},
+					NotInternalizable: true,
+					WhoLocked:         ptr.Bool(false),
+					WhatLocked:        ptr.Bool(false),
+					NameLocked:        ptr.Bool(false),
+					DeleteLocked:      ptr.Bool(false),
+					ActualName:        "folder_folder1_roles_viewer_250c20f0",
+					What: []sync_from_target.WhatItem{
+						{
+							DataObject: &data_source.DataObjectReference{
+								FullName: "folder1",
+								Type:     "folder",
+							},
+							Permissions: []string{"roles/viewer"},
+						},
+					},
+					Tags: []*tag.Tag{{Key: "gcp.condition", Value: "request.time < timestamp('2030-01-01T00:00:00Z')", Source: "gcp"}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "Regular bindings to Access Provider and managed bindings",
 			fields: fields{
 				mocksSetup: func(gcpRepo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
 
 				},
-				metadata: gcp.NewDataSourceMetaData(),
+				metadata: gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				raitoManagedBindings: set.NewSet[iam.IamBinding](
 					iam.IamBinding{
 						Member:       "user:ruben@raito.io",
@@ -910,7 +964,7 @@ func TestAccessSyncer_ConvertBindingsToAccessProviders(t *testing.T) {
 				mocksSetup: func(gcpRepo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
 
 				},
-				metadata:             gcp.NewDataSourceMetaData(),
+				metadata:             gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				raitoManagedBindings: set.NewSet[iam.IamBinding](),
 			},
 			args: args{
@@ -998,7 +1052,7 @@ func TestAccessSyncer_ConvertBindingsToAccessProviders(t *testing.T) {
 				mocksSetup: func(gcpRepo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
 					projectRepo.EXPECT().GetProjectOwner(mock.Anything, mock.Anything).Return([]string{"user:owner@raito.io"}, []string{"user:editor@raito.io"}, []string{"user:viewer@raito.io"}, nil).Once()
 				},
-				metadata:             gcp.NewDataSourceMetaData(),
+				metadata:             gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				raitoManagedBindings: set.NewSet[iam.IamBinding](),
 			},
 			args: args{
@@ -1114,7 +1168,7 @@ func TestAccessSyncer_ConvertBindingsToAccessProviders(t *testing.T) {
 				mocksSetup: func(gcpRepo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
 
 				},
-				metadata:             gcp.NewDataSourceMetaData(),
+				metadata:             gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				raitoManagedBindings: set.NewSet[iam.IamBinding](),
 			},
 			args: args{
@@ -1243,6 +1297,45 @@ func TestAccessSyncer_ConvertBindingsToAccessProviders(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "Protected principals and roles are not imported",
+			fields: fields{
+				mocksSetup: func(gcpRepo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
+
+				},
+				metadata:             gcp.NewDataSourceMetaData(&config.ConfigMap{}),
+				raitoManagedBindings: set.NewSet[iam.IamBinding](),
+			},
+			args: args{
+				ctx: context.Background(),
+				configMap: &config.ConfigMap{Parameters: map[string]string{
+					common.GcpProtectedPrincipals: "user:break-glass@raito.io",
+					common.GcpProtectedRoles:      "roles/owner",
+				}},
+				bindings: []iam.IamBinding{
+					{
+						Member:       "user:ruben@raito.io",
+						Resource:     "project1",
+						ResourceType: "project",
+						Role:         "roles/owner",
+					},
+					{
+						Member:       "user:break-glass@raito.io",
+						Resource:     "project1",
+						ResourceType: "project",
+						Role:         "roles/viewer",
+					},
+					{
+						Member:       "serviceAccount:service-123456@gcp-sa-bigquerydatatransfer.iam.gserviceaccount.com",
+						Resource:     "project1",
+						ResourceType: "project",
+						Role:         "roles/viewer",
+					},
+				},
+			},
+			want:    []*sync_from_target.AccessProvider{},
+			wantErr: assert.NoError,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1264,7 +1357,7 @@ func TestAccessSyncer_ConvertBindingsToAccessProviders(t *testing.T) {
 func TestAccessSyncer_SyncAccessProviderToTarget(t *testing.T) {
 	bqMetadata, err := bigquery.NewDataSourceMetaData(context.Background(), &config.ConfigMap{Parameters: map[string]string{
 		common.BqCatalogEnabled: "true",
-	}})
+	}}, noMaskingRoutines{})
 
 	require.NoError(t, err)
 
@@ -1347,7 +1440,7 @@ func TestAccessSyncer_SyncAccessProviderToTarget(t *testing.T) {
 				mocksSetup: func(gcpRepo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
 
 				},
-				metadata: gcp.NewDataSourceMetaData(),
+				metadata: gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 			},
 			args: args{
 				ctx:             context.Background(),
@@ -1415,7 +1508,7 @@ func TestAccessSyncer_SyncAccessProviderToTarget(t *testing.T) {
 						}, addBindings)
 					}).Return(nil)
 				},
-				metadata: gcp.NewDataSourceMetaData(),
+				metadata: gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 			},
 			args: args{
 				ctx: context.Background(),
@@ -1568,7 +1661,7 @@ func TestAccessSyncer_SyncAccessProviderToTarget(t *testing.T) {
 						}, removeBindings)
 					}).Return(nil)
 				},
-				metadata: gcp.NewDataSourceMetaData(),
+				metadata: gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 			},
 			args: args{
 				ctx: context.Background(),
@@ -1815,6 +1908,80 @@ func TestAccessSyncer_SyncAccessProviderToTarget(t *testing.T) {
 			expectedRaitoFilters: set.NewSet[string](),
 			wantErr:              assert.NoError,
 		},
+		{
+			name: "Grant with column access",
+			fields: fields{
+				mocksSetup: func(repo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
+					maskingService.EXPECT().ExportColumnAccess(mock.Anything, mock.MatchedBy(func(ap *importer.AccessProvider) bool { return ap.Id == "apId1" }), mock.Anything).RunAndReturn(func(ctx context.Context, ap *importer.AccessProvider, feedback *importer.AccessProviderSyncFeedback) []string {
+						feedback.ExternalId = ptr.String("projects/project1/locations/eu/taxonomies/1/policyTags/10")
+
+						return []string{"projects/project1/locations/eu/taxonomies/1/policyTags/10"}
+					})
+
+					repo.EXPECT().DataSourceType().Return("project")
+
+					repo.EXPECT().UpdateBindings(mock.Anything, &iam.DataObjectReference{FullName: "project1", ObjectType: "project"}, []iam.IamBinding{{Member: "user:ruben@raito.io", Role: "roles/bigquery.jobUser", Resource: "project1", ResourceType: "project"}}, []iam.IamBinding{}).Return(nil)
+				},
+				metadata: bqMetadata,
+			},
+			args: args{
+				ctx: context.Background(),
+				accessProviders: &importer.AccessProviderImport{AccessProviders: []*importer.AccessProvider{
+					{
+						Id:         "apId1",
+						Name:       "ap1",
+						NamingHint: "ap1",
+						Action:     types.Grant,
+						Who: importer.WhoItem{
+							Users: []string{
+								"ruben@raito.io",
+							},
+						},
+						What: []importer.WhatItem{
+							{
+								DataObject: &data_source.DataObjectReference{
+									FullName: "project1",
+									Type:     "datasource",
+								},
+								Permissions: []string{"roles/bigquery.jobUser"},
+							},
+							{
+								DataObject: &data_source.DataObjectReference{
+									FullName: "project1.dataset1.table1.column1",
+									Type:     "column",
+								},
+								Permissions: []string{"roles/datacatalog.categoryFineGrainedReader"},
+							},
+						},
+					},
+				}},
+				configMap: &config.ConfigMap{Parameters: map[string]string{}},
+			},
+			want: []importer.AccessProviderSyncFeedback{
+				{
+					AccessProvider: "apId1",
+					ActualName:     "apId1",
+					ExternalId:     ptr.String("projects/project1/locations/eu/taxonomies/1/policyTags/10"),
+					Type:           ptr.String(access_provider.AclSet),
+					State: &importer.AccessProviderFeedbackState{
+						Who: importer.AccessProviderWhoFeedbackState{
+							Users: []string{"ruben@raito.io"},
+						},
+					},
+				},
+			},
+			expectedBindings: set.NewSet[iam.IamBinding](
+				iam.IamBinding{
+					Member:       "user:ruben@raito.io",
+					Role:         "roles/bigquery.jobUser",
+					Resource:     "project1",
+					ResourceType: "project",
+				},
+			),
+			expectedRaitoMasks:   set.NewSet[string]("projects/project1/locations/eu/taxonomies/1/policyTags/10"),
+			expectedRaitoFilters: set.NewSet[string](),
+			wantErr:              assert.NoError,
+		},
 		{
 			name: "Grants and filters",
 			fields: fields{
@@ -1923,6 +2090,7 @@ func TestAccessSyncer_SyncAccessProviderToTarget(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			a, gcpMock, projectRepoMock, maskingService, filteringService := createAccessSyncer(t, tt.fields.metadata, tt.args.configMap)
 			tt.fields.mocksSetup(gcpMock, projectRepoMock, maskingService, filteringService)
+			maskingService.EXPECT().ApplyColumnPolicyTags(mock.Anything).Return(nil).Once()
 
 			feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
 
@@ -2074,7 +2242,7 @@ func TestAccessSyncer_convertAccessProviderToBindings(t *testing.T) {
 			fields: fields{
 				mocksSetup: func(repo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
 				},
-				metadata:  gcp.NewDataSourceMetaData(),
+				metadata:  gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				configMap: &config.ConfigMap{Parameters: map[string]string{}},
 			},
 			args: args{
@@ -2088,7 +2256,7 @@ func TestAccessSyncer_convertAccessProviderToBindings(t *testing.T) {
 			fields: fields{
 				mocksSetup: func(repo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
 				},
-				metadata:  gcp.NewDataSourceMetaData(),
+				metadata:  gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				configMap: &config.ConfigMap{Parameters: map[string]string{}},
 			},
 			args: args{
@@ -2115,7 +2283,7 @@ func TestAccessSyncer_convertAccessProviderToBindings(t *testing.T) {
 				mocksSetup: func(repo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
 					repo.EXPECT().DataSourceType().Return("datasource_real_type")
 				},
-				metadata:  gcp.NewDataSourceMetaData(),
+				metadata:  gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				configMap: &config.ConfigMap{Parameters: map[string]string{}},
 			},
 			args: args{
@@ -2141,7 +2309,7 @@ func TestAccessSyncer_convertAccessProviderToBindings(t *testing.T) {
 			fields: fields{
 				mocksSetup: func(repo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
 				},
-				metadata:  gcp.NewDataSourceMetaData(),
+				metadata:  gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				configMap: &config.ConfigMap{Parameters: map[string]string{}},
 			},
 			args: args{
@@ -2170,7 +2338,7 @@ func TestAccessSyncer_convertAccessProviderToBindings(t *testing.T) {
 			fields: fields{
 				mocksSetup: func(repo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
 				},
-				metadata:  gcp.NewDataSourceMetaData(),
+				metadata:  gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				configMap: &config.ConfigMap{Parameters: map[string]string{}},
 			},
 			args: args{
@@ -2201,19 +2369,11 @@ func TestAccessSyncer_convertAccessProviderToBindings(t *testing.T) {
 			},
 		},
 		{
-			name: "Grant with masked reader",
+			name: "Grant with masked reader does not bind the masked reader role on the data object",
 			fields: fields{
 				mocksSetup: func(repo *MockBindingRepository, projectRepo *MockProjectRepo, maskingService *MockMaskingService, filteringService *MockFilteringService) {
-					maskingService.EXPECT().MaskedBinding(mock.Anything, []string{"user:ruben@raito.io"}).Return([]iam.IamBinding{
-						{
-							Member:       "user:ruben@raito.io",
-							Role:         roles.RolesBigQueryMaskedReader.Name,
-							Resource:     "project1",
-							ResourceType: "project",
-						},
-					}, nil)
 				},
-				metadata:  gcp.NewDataSourceMetaData(),
+				metadata:  gcp.NewDataSourceMetaData(&config.ConfigMap{}),
 				configMap: &config.ConfigMap{Parameters: map[string]string{common.GcpMaskedReader: "true"}},
 			},
 			args: args{
@@ -2225,8 +2385,7 @@ func TestAccessSyncer_convertAccessProviderToBindings(t *testing.T) {
 			want: &BindingContainer{
 				bindings: map[iam.DataObjectReference]*BindingsForDataObject{
 					iam.DataObjectReference{FullName: "project1", ObjectType: "project"}: {
-						bindingsToAdd: set.NewSet(iam.IamBinding{Member: "user:ruben@raito.io", Role: "roles/owner", Resource: "project1", ResourceType: "project"},
-							iam.IamBinding{Member: "user:ruben@raito.io", Role: roles.RolesBigQueryMaskedReader.Name, Resource: "project1", ResourceType: "project"}),
+						bindingsToAdd:    set.NewSet(iam.IamBinding{Member: "user:ruben@raito.io", Role: "roles/owner", Resource: "project1", ResourceType: "project"}),
 						bindingsToDelete: set.NewSet[iam.IamBinding](),
 						accessProviders: map[iam.IamBinding][]*importer.AccessProvider{
 							iam.IamBinding{Member: "user:ruben@raito.io", Role: "roles/owner", Resource: "project1", ResourceType: "project"}: {accessProviders[0]},
@@ -2241,7 +2400,7 @@ func TestAccessSyncer_convertAccessProviderToBindings(t *testing.T) {
 			syncer, repoMock, projectRepoMock, maskingService, filteringService := createAccessSyncer(t, tt.fields.metadata, tt.fields.configMap)
 			tt.fields.mocksSetup(repoMock, projectRepoMock, maskingService, filteringService)
 
-			result := syncer.convertAccessProviderToBindings(tt.args.ctx, tt.args.accessProviders)
+			result := syncer.convertAccessProviderToBindings(tt.args.ctx, tt.args.accessProviders, syncer.managedGroupAccessProviders(tt.args.accessProviders))
 
 			assert.Equal(t, len(result.bindings), len(tt.want.bindings))
 
@@ -2264,8 +2423,89 @@ func createAccessSyncer(t *testing.T, dsMetadata *data_source.MetaData, configMa
 	projectRepo := NewMockProjectRepo(t)
 	maskingService := NewMockMaskingService(t)
 	filteringService := NewMockFilteringService(t)
+	managedGroupRepo := NewMockManagedGroupRepository(t)
+
+	return NewDataAccessSyncer(gcpRepo, projectRepo, maskingService, filteringService, managedGroupRepo, dsMetadata, configMap), gcpRepo, projectRepo, maskingService, filteringService
+}
+
+func TestAccessSyncer_SyncAccessProviderToTarget_PolicySizeExceeded(t *testing.T) {
+	configMap := &config.ConfigMap{Parameters: map[string]string{}}
+
+	what := []importer.WhatItem{
+		{
+			DataObject:  &data_source.DataObjectReference{FullName: "project1", Type: "project"},
+			Permissions: []string{"roles/viewer"},
+		},
+	}
+
+	accessProviders := &importer.AccessProviderImport{AccessProviders: []*importer.AccessProvider{
+		{
+			Id:     "apId1",
+			Name:   "ap1",
+			Action: types.Grant,
+			Who:    importer.WhoItem{Users: []string{"ruben@raito.io", "bart@raito.io"}},
+			What:   what,
+		},
+		{
+			Id:     "apId2",
+			Name:   "ap2",
+			Action: types.Grant,
+			Delete: true,
+			Who:    importer.WhoItem{Groups: []string{"sales@raito.io"}},
+			What:   what,
+		},
+	}}
+
+	bindingRepo := NewMockBindingRepository(t)
+	bindingRepo.EXPECT().UpdateBindings(mock.Anything, &iam.DataObjectReference{FullName: "project1", ObjectType: "project"}, mock.Anything, mock.Anything).Return(fmt.Errorf("update project bindings: %w", &iam.PolicySizeError{Resource: "projects/project1", Principals: 1501})).Once()
+
+	maskingService := NewMockMaskingService(t)
+	maskingService.EXPECT().ApplyColumnPolicyTags(mock.Anything).Return(nil).Once()
+
+	a := NewDataAccessSyncer(bindingRepo, NewMockProjectRepo(t), maskingService, NewMockFilteringService(t), NewMockManagedGroupRepository(t), gcp.NewDataSourceMetaData(&config.ConfigMap{}), configMap)
+
+	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+	err := a.SyncAccessProviderToTarget(context.Background(), accessProviders, feedbackHandler, configMap)
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []importer.AccessProviderSyncFeedback{
+		{
+			AccessProvider: "apId1",
+			ActualName:     "apId1",
+			Type:           ptr.String(access_provider.AclSet),
+			Errors:         []string{`update bindings of project "project1": IAM policy of "projects/project1" would contain 1501 principals (limit 1500). This access control adds 2 principal(s) to the policy. Enable gcp-managed-groups to replace the principals of each access control by a single managed group.`},
+			State: &importer.AccessProviderFeedbackState{
+				Who: importer.AccessProviderWhoFeedbackState{Users: []string{"ruben@raito.io", "bart@raito.io"}},
+			},
+		},
+		{
+			AccessProvider: "apId2",
+			ActualName:     "apId2",
+			Type:           ptr.String(access_provider.AclSet),
+			Errors:         []string{`update bindings of project "project1": IAM policy of "projects/project1" would contain 1501 principals (limit 1500). This access control adds 0 principal(s) to the policy. Enable gcp-managed-groups to replace the principals of each access control by a single managed group.`},
+		},
+	}, feedbackHandler.AccessProviderFeedback)
+}
+
+func TestAccessSyncer_SyncAccessProviderToTarget_ApplyColumnPolicyTagsOnExportError(t *testing.T) {
+	configMap := &config.ConfigMap{Parameters: map[string]string{}}
+
+	mask := &importer.AccessProvider{Id: "maskId", Name: "mask", Action: types.Mask}
+	filter := &importer.AccessProvider{Id: "filterId", Name: "filter", Action: types.Filtered}
+
+	maskingService := NewMockMaskingService(t)
+	maskingService.EXPECT().ExportMasks(mock.Anything, mask, mock.Anything).Return([]string{"dataPolicy1"}, nil).Once()
+	maskingService.EXPECT().ApplyColumnPolicyTags(mock.Anything).Return(nil).Once()
+
+	filteringService := NewMockFilteringService(t)
+	filteringService.EXPECT().ExportFilter(mock.Anything, filter, mock.Anything).Return(nil, errors.New("boom")).Once()
 
-	return NewDataAccessSyncer(gcpRepo, projectRepo, maskingService, filteringService, dsMetadata, configMap), gcpRepo, projectRepo, maskingService, filteringService
+	a := NewDataAccessSyncer(NewMockBindingRepository(t), NewMockProjectRepo(t), maskingService, filteringService, NewMockManagedGroupRepository(t), gcp.NewDataSourceMetaData(&config.ConfigMap{}), configMap)
+
+	err := a.SyncAccessProviderToTarget(context.Background(), &importer.AccessProviderImport{AccessProviders: []*importer.AccessProvider{mask, filter}}, mocks.NewSimpleAccessProviderFeedbackHandler(t), configMap)
+
+	require.ErrorContains(t, err, "export filters: boom")
 }
 
 func Test_handleErrors(t *testing.T) {
@@ -2452,3 +2692,9 @@ func Test_generateProjectWhoItem(t *testing.T) {
 		})
 	}
 }
+
+type noMaskingRoutines struct{}
+
+func (noMaskingRoutines) ListMaskingRoutines(_ context.Context) ([]bigquery.BQMaskingRoutine, error) {
+	return nil, nil
+}
@@ -2,6 +2,7 @@ package syncer
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	ds "github.com/raito-io/cli/base/data_source"
@@ -16,6 +17,7 @@ import (
 //go:generate go run github.com/vektra/mockery/v2 --name=DataSourceRepository --with-expecter --inpackage
 type DataSourceRepository interface {
 	DataObjects(ctx context.Context, config *ds.DataSourceSyncConfig, fn func(ctx context.Context, object *org.GcpOrgEntity) error) error
+	UpdateMetadata(ctx context.Context, update *org.MetadataUpdate) error
 }
 
 type DataSourceSyncer struct {
@@ -28,6 +30,8 @@ func NewDataSourceSyncer(repository DataSourceRepository, metadata *ds.MetaData)
 }
 
 func (s *DataSourceSyncer) SyncDataSource(ctx context.Context, dataSourceHandler wrappers.DataSourceObjectHandler, config *ds.DataSourceSyncConfig) error {
+	s.writeBackMetadata(ctx, config.ConfigMap)
+
 	err := s.repoProvider.DataObjects(ctx, config, func(_ context.Context, object *org.GcpOrgEntity) error {
 		err := dataSourceHandler.AddDataObjects(handleGcpOrgEntities(object))
 		if err != nil {
@@ -44,6 +48,41 @@ func (s *DataSourceSyncer) SyncDataSource(ctx context.Context, dataSourceHandler
 	return nil
 }
 
+// writeBackMetadata applies the descriptions and labels passed in the data source sync config before the data objects are synced.
+// All updates are attempted. Failures are reported as warnings, so they never block the data source sync.
+func (s *DataSourceSyncer) writeBackMetadata(ctx context.Context, configMap *config.ConfigMap) {
+	metadata := configMap.GetString(common.GcpMetadataWriteBack)
+	if metadata == "" {
+		return
+	}
+
+	var updates []*org.MetadataUpdate
+
+	err := json.Unmarshal([]byte(metadata), &updates)
+	if err != nil {
+		common.Logger.Warn(fmt.Sprintf("Unable to parse the metadata to write back, no metadata is written back: %s", err.Error()))
+
+		return
+	}
+
+	common.Logger.Info(fmt.Sprintf("Writing back metadata of %d data objects", len(updates)))
+
+	failed := 0
+
+	for _, update := range updates {
+		err = s.repoProvider.UpdateMetadata(ctx, update)
+		if err != nil {
+			common.Logger.Warn(fmt.Sprintf("Unable to write back metadata of %s %q: %s", update.Type, update.FullName, err.Error()))
+
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		common.Logger.Warn(fmt.Sprintf("Unable to write back metadata of %d out of %d data objects", failed, len(updates)))
+	}
+}
+
 func handleGcpOrgEntities(entity *org.GcpOrgEntity) *ds.DataObject {
 	var parent string
 	if entity.Parent != nil {
@@ -74,8 +113,13 @@ func handleGcpOrgEntities(entity *org.GcpOrgEntity) *ds.DataObject {
 	}
 }
 
-func (s *DataSourceSyncer) GetDataSourceMetaData(_ context.Context, _ *config.ConfigMap) (*ds.MetaData, error) {
+func (s *DataSourceSyncer) GetDataSourceMetaData(_ context.Context, configMap *config.ConfigMap) (*ds.MetaData, error) {
 	common.Logger.Info("DataSource meta data sync")
 
+	// Access provider inheritance is supported by nesting the managed groups of the access providers
+	if configMap != nil {
+		s.metadata.SupportsApInheritance = newManagedGroupNaming(configMap) != nil
+	}
+
 	return s.metadata, nil
 }
@@ -5,11 +5,13 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/aws/smithy-go/ptr"
 	"github.com/raito-io/cli/base/data_source"
 	"github.com/raito-io/cli/base/util/config"
 	"github.com/raito-io/cli/base/wrappers/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/raito-io/cli-plugin-gcp/internal/common"
 	"github.com/raito-io/cli-plugin-gcp/internal/gcp"
@@ -184,10 +186,47 @@ func TestDataSourceSyncer_SyncDataSource(t *testing.T) {
 	}
 }
 
+func TestDataSourceSyncer_SyncDataSource_WriteBackMetadata(t *testing.T) {
+	metadata := `[
+		{"fullName": "projectId1", "type": "project", "labels": {"team": "finance", "deprecated": null}},
+		{"fullName": "folderId1", "type": "folder", "description": "not supported"}
+	]`
+
+	s, repo := createTestDataSourceSyncer(t)
+
+	repo.EXPECT().UpdateMetadata(mock.Anything, &org.MetadataUpdate{
+		FullName: "projectId1",
+		Type:     "project",
+		Labels:   map[string]*string{"team": ptr.String("finance"), "deprecated": nil},
+	}).Return(nil).Once()
+	repo.EXPECT().UpdateMetadata(mock.Anything, &org.MetadataUpdate{
+		FullName:    "folderId1",
+		Type:        "folder",
+		Description: ptr.String("not supported"),
+	}).Return(errors.New("not supported")).Once()
+	repo.EXPECT().DataObjects(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	dataSourceObjectHandler := mocks.NewSimpleDataSourceObjectHandler(t, 1)
+	err := s.SyncDataSource(context.Background(), dataSourceObjectHandler, &data_source.DataSourceSyncConfig{ConfigMap: &config.ConfigMap{Parameters: map[string]string{common.GcpMetadataWriteBack: metadata}}})
+
+	require.NoError(t, err)
+}
+
+func TestDataSourceSyncer_SyncDataSource_InvalidWriteBackMetadata(t *testing.T) {
+	s, repo := createTestDataSourceSyncer(t)
+
+	repo.EXPECT().DataObjects(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	dataSourceObjectHandler := mocks.NewSimpleDataSourceObjectHandler(t, 1)
+	err := s.SyncDataSource(context.Background(), dataSourceObjectHandler, &data_source.DataSourceSyncConfig{ConfigMap: &config.ConfigMap{Parameters: map[string]string{common.GcpMetadataWriteBack: `{"fullName": "projectId1"`}}})
+
+	require.NoError(t, err)
+}
+
 func createTestDataSourceSyncer(t *testing.T) (*DataSourceSyncer, *MockDataSourceRepository) {
 	t.Helper()
 
 	repoMock := NewMockDataSourceRepository(t)
 
-	return NewDataSourceSyncer(repoMock, gcp.NewDataSourceMetaData()), repoMock
+	return NewDataSourceSyncer(repoMock, gcp.NewDataSourceMetaData(&config.ConfigMap{})), repoMock
 }
@@ -0,0 +1,210 @@
+package syncer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/raito-io/golang-set/set"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/iam"
+)
+
+const (
+	GuardrailModeAbort  = "abort"
+	GuardrailModeDryRun = "dry-run"
+)
+
+// accessGuardrails limits the number of bindings that can be removed by a single access sync. A limit of 0 disables the guardrail.
+type accessGuardrails struct {
+	maxRemovedBindings              int
+	maxRemovedBindingsPercentage    int
+	maxRemovedBindingsPerDataObject int
+
+	// dryRun disables all binding updates of the run if a guardrail is breached, instead of only the updates that breach it.
+	dryRun bool
+}
+
+func newAccessGuardrails(configMap *config.ConfigMap) *accessGuardrails {
+	mode := configMap.GetStringWithDefault(common.GcpAccessGuardrailMode, GuardrailModeAbort)
+	if mode != GuardrailModeAbort && mode != GuardrailModeDryRun {
+		common.Logger.Warn(fmt.Sprintf("Unknown guardrail mode %q. Using %q instead.", mode, GuardrailModeAbort))
+
+		mode = GuardrailModeAbort
+	}
+
+	return &accessGuardrails{
+		maxRemovedBindings:              configMap.GetIntWithDefault(common.GcpAccessMaxRemovedBindings, 0),
+		maxRemovedBindingsPercentage:    configMap.GetIntWithDefault(common.GcpAccessMaxRemovedBindingsPercentage, 0),
+		maxRemovedBindingsPerDataObject: configMap.GetIntWithDefault(common.GcpAccessMaxRemovedBindingsPerDataObject, 0),
+		dryRun:                          mode == GuardrailModeDryRun,
+	}
+}
+
+// guardrailChanges are the planned changes of an access sync that are counted by the guardrails besides the binding updates.
+type guardrailChanges struct {
+	// currentBindings is the number of bindings on each updated data object before the update. It is only loaded if the percentage guardrail is enabled.
+	currentBindings map[iam.DataObjectReference]int
+
+	removedGroupMembers  int
+	currentGroupMembers  int
+	removedMaskedReaders int
+	removedMasks         int
+	removedFilters       int
+}
+
+// removals returns the number of removals of the access sync and describes them.
+func (c *guardrailChanges) removals(removedBindings int) (int, string) {
+	total := removedBindings
+	parts := []string{fmt.Sprintf("%d bindings", removedBindings)}
+
+	for _, other := range []struct {
+		count int
+		name  string
+	}{
+		{c.removedGroupMembers, "managed group membership"},
+		{c.removedMaskedReaders, "masked reader role"},
+		{c.removedMasks, "mask"},
+		{c.removedFilters, "filter"},
+	} {
+		if other.count == 0 {
+			continue
+		}
+
+		total += other.count
+
+		if other.count == 1 {
+			parts = append(parts, fmt.Sprintf("1 %s", other.name))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d %ss", other.count, other.name))
+		}
+	}
+
+	if len(parts) == 1 {
+		return total, parts[0]
+	}
+
+	return total, fmt.Sprintf("%d accesses (%s)", total, strings.Join(parts, ", "))
+}
+
+// current returns the number of bindings on the updated data objects and memberships of the managed groups before the update.
+func (c *guardrailChanges) current() int {
+	current := c.currentGroupMembers
+
+	for _, n := range c.currentBindings {
+		current += n
+	}
+
+	return current
+}
+
+// needsCurrentBindings returns true if the number of bindings on the updated data objects is needed to check the guardrails.
+func (g *accessGuardrails) needsCurrentBindings() bool {
+	return g.maxRemovedBindingsPercentage > 0
+}
+
+// check returns the data objects of which the bindings may not be updated, and reports the breached guardrails to the feedback of the affected access providers.
+// The run-level limits count all removals of the access sync and block all data objects, the per data object limit only counts the removed bindings and only blocks the data objects that exceed it.
+// The returned boolean is true if the whole access sync is blocked, in which case no updates at all may be executed.
+func (g *accessGuardrails) check(bindings *BindingContainer, changes *guardrailChanges, apFeedback map[string]*importer.AccessProviderSyncFeedback) (set.Set[iam.DataObjectReference], bool) {
+	blocked := set.NewSet[iam.DataObjectReference]()
+	reasons := make(map[iam.DataObjectReference]string)
+
+	removedBindings := 0
+
+	for do, doBindings := range bindings.bindings {
+		removed := len(doBindings.bindingsToDelete)
+
+		removedBindings += removed
+
+		if g.maxRemovedBindingsPerDataObject > 0 && removed > g.maxRemovedBindingsPerDataObject {
+			blocked.Add(do)
+			reasons[do] = fmt.Sprintf("%d bindings would be removed from %s %q (maximum %d per data object)", removed, do.ObjectType, do.FullName, g.maxRemovedBindingsPerDataObject)
+		}
+	}
+
+	totalRemoved, removedDescription := changes.removals(removedBindings)
+	current := changes.current()
+
+	var runReason string
+
+	if g.maxRemovedBindings > 0 && totalRemoved > g.maxRemovedBindings {
+		runReason = fmt.Sprintf("%s would be removed (maximum %d)", removedDescription, g.maxRemovedBindings)
+	} else if g.maxRemovedBindingsPercentage > 0 && current > 0 && totalRemoved*100 > g.maxRemovedBindingsPercentage*current {
+		runReason = fmt.Sprintf("%s of the %d existing bindings and managed group memberships would be removed (maximum %d%%)", removedDescription, current, g.maxRemovedBindingsPercentage)
+	}
+
+	// Run-level breaches and breaches in dry-run mode block all data objects
+	blockAll := runReason != "" || (g.dryRun && len(blocked) > 0)
+
+	if blockAll {
+		if runReason != "" {
+			common.Logger.Error(fmt.Sprintf("access guardrail breached: %s. The access sync %s", runReason, g.action()))
+		}
+
+		for do := range bindings.bindings {
+			if runReason != "" {
+				reasons[do] = runReason
+			} else if _, found := reasons[do]; !found {
+				reasons[do] = "a guardrail was breached on another data object"
+			}
+
+			blocked.Add(do)
+		}
+	}
+
+	return g.report(bindings, blocked, reasons, apFeedback), blockAll
+}
+
+// blockedAccessProviders returns the ids of the access providers with bindings on the blocked data objects.
+// Their managed group, column access and masked readers are not updated either.
+func blockedAccessProviders(bindings *BindingContainer, blocked set.Set[iam.DataObjectReference]) set.Set[string] {
+	result := set.NewSet[string]()
+
+	for do := range blocked {
+		for _, ap := range bindings.bindings[do].GetAllAccessProviders() {
+			result.Add(ap.Id)
+		}
+	}
+
+	return result
+}
+
+// action describes what happens with the access sync when a guardrail is breached.
+func (g *accessGuardrails) action() string {
+	if g.dryRun {
+		return "switched to dry-run"
+	}
+
+	return "aborted"
+}
+
+// skippedMessage is reported to the access providers that are not updated because the whole access sync is blocked by a guardrail.
+func (g *accessGuardrails) skippedMessage() string {
+	return fmt.Sprintf("access guardrail breached: the access sync %s, so this access provider is not updated", g.action())
+}
+
+func (g *accessGuardrails) report(bindings *BindingContainer, blocked set.Set[iam.DataObjectReference], reasons map[iam.DataObjectReference]string, apFeedback map[string]*importer.AccessProviderSyncFeedback) set.Set[iam.DataObjectReference] {
+	for do := range blocked {
+		doBindings := bindings.bindings[do]
+
+		if g.dryRun {
+			common.Logger.Info(fmt.Sprintf("Dry-run: bindings of %s %q not updated. Adding: %+v; Deleting: %+v", do.ObjectType, do.FullName, doBindings.bindingsToAdd.Slice(), doBindings.bindingsToDelete.Slice()))
+		}
+
+		msg := fmt.Sprintf("access guardrail breached: %s. The access sync %s, so the bindings of %s %q are not updated", reasons[do], g.action(), do.ObjectType, do.FullName)
+
+		common.Logger.Error(msg)
+
+		for _, ap := range doBindings.GetAllAccessProviders() {
+			if !slices.Contains(apFeedback[ap.Id].Errors, msg) {
+				apFeedback[ap.Id].Errors = append(apFeedback[ap.Id].Errors, msg)
+			}
+		}
+	}
+
+	return blocked
+}
@@ -0,0 +1,213 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/raito-io/cli/base/access_provider"
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/access_provider/types"
+	"github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/raito-io/cli/base/wrappers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/gcp"
+	"github.com/raito-io/cli-plugin-gcp/internal/iam"
+)
+
+func TestNewAccessGuardrails(t *testing.T) {
+	assert.Equal(t, &accessGuardrails{}, newAccessGuardrails(&config.ConfigMap{Parameters: map[string]string{}}))
+	assert.Equal(t, &accessGuardrails{}, newAccessGuardrails(&config.ConfigMap{Parameters: map[string]string{common.GcpAccessGuardrailMode: "unknown"}}))
+	assert.Equal(t, &accessGuardrails{maxRemovedBindings: 10, maxRemovedBindingsPercentage: 20, maxRemovedBindingsPerDataObject: 5, dryRun: true}, newAccessGuardrails(&config.ConfigMap{Parameters: map[string]string{
+		common.GcpAccessMaxRemovedBindings:              "10",
+		common.GcpAccessMaxRemovedBindingsPercentage:    "20",
+		common.GcpAccessMaxRemovedBindingsPerDataObject: "5",
+		common.GcpAccessGuardrailMode:                   GuardrailModeDryRun,
+	}}))
+}
+
+func TestAccessGuardrails_Check(t *testing.T) {
+	project := iam.DataObjectReference{FullName: "project1", ObjectType: "project"}
+	dataset := iam.DataObjectReference{FullName: "project1.dataset1", ObjectType: "dataset"}
+
+	ap1 := &importer.AccessProvider{Id: "apId1"}
+	ap2 := &importer.AccessProvider{Id: "apId2"}
+
+	// ap1 removes 3 bindings from the project, ap2 adds 1 binding to the dataset
+	bindings := NewBindingContainer()
+	for i := 0; i < 3; i++ {
+		bindings.BindingToDelete(project, iam.IamBinding{Member: fmt.Sprintf("user:user%d@raito.io", i), Role: "roles/viewer", Resource: "project1", ResourceType: "project"}, ap1)
+	}
+
+	bindings.BindingToAdd(dataset, iam.IamBinding{Member: "user:ruben@raito.io", Role: "roles/bigquery.dataViewer", Resource: "project1.dataset1", ResourceType: "dataset"}, ap2)
+
+	// The project and dataset currently have 12 bindings
+	currentBindings := map[iam.DataObjectReference]int{project: 10, dataset: 2}
+
+	tests := []struct {
+		name        string
+		guardrails  accessGuardrails
+		changes     guardrailChanges
+		wantBlocked []iam.DataObjectReference
+		wantAll     bool
+		wantErrors  map[string][]string
+	}{
+		{
+			name:        "No guardrails",
+			guardrails:  accessGuardrails{},
+			wantBlocked: []iam.DataObjectReference{},
+			wantErrors:  map[string][]string{},
+		},
+		{
+			name:        "Within limits",
+			guardrails:  accessGuardrails{maxRemovedBindings: 3, maxRemovedBindingsPercentage: 25, maxRemovedBindingsPerDataObject: 3},
+			changes:     guardrailChanges{currentBindings: currentBindings},
+			wantBlocked: []iam.DataObjectReference{},
+			wantErrors:  map[string][]string{},
+		},
+		{
+			name:        "Maximum removed bindings",
+			guardrails:  accessGuardrails{maxRemovedBindings: 2},
+			wantBlocked: []iam.DataObjectReference{project, dataset},
+			wantAll:     true,
+			wantErrors: map[string][]string{
+				"apId1": {`access guardrail breached: 3 bindings would be removed (maximum 2). The access sync aborted, so the bindings of project "project1" are not updated`},
+				"apId2": {`access guardrail breached: 3 bindings would be removed (maximum 2). The access sync aborted, so the bindings of dataset "project1.dataset1" are not updated`},
+			},
+		},
+		{
+			name:        "Maximum removed accesses",
+			guardrails:  accessGuardrails{maxRemovedBindings: 5},
+			changes:     guardrailChanges{removedGroupMembers: 2, removedFilters: 1},
+			wantBlocked: []iam.DataObjectReference{project, dataset},
+			wantAll:     true,
+			wantErrors: map[string][]string{
+				"apId1": {`access guardrail breached: 6 accesses (3 bindings, 2 managed group memberships, 1 filter) would be removed (maximum 5). The access sync aborted, so the bindings of project "project1" are not updated`},
+				"apId2": {`access guardrail breached: 6 accesses (3 bindings, 2 managed group memberships, 1 filter) would be removed (maximum 5). The access sync aborted, so the bindings of dataset "project1.dataset1" are not updated`},
+			},
+		},
+		{
+			name:        "Maximum percentage of removed bindings",
+			guardrails:  accessGuardrails{maxRemovedBindingsPercentage: 20},
+			changes:     guardrailChanges{currentBindings: currentBindings},
+			wantBlocked: []iam.DataObjectReference{project, dataset},
+			wantAll:     true,
+			wantErrors: map[string][]string{
+				"apId1": {`access guardrail breached: 3 bindings of the 12 existing bindings and managed group memberships would be removed (maximum 20%). The access sync aborted, so the bindings of project "project1" are not updated`},
+				"apId2": {`access guardrail breached: 3 bindings of the 12 existing bindings and managed group memberships would be removed (maximum 20%). The access sync aborted, so the bindings of dataset "project1.dataset1" are not updated`},
+			},
+		},
+		{
+			name:        "Maximum percentage of removed bindings and memberships",
+			guardrails:  accessGuardrails{maxRemovedBindingsPercentage: 40},
+			changes:     guardrailChanges{currentBindings: currentBindings, removedGroupMembers: 4, currentGroupMembers: 4, removedMaskedReaders: 2},
+			wantBlocked: []iam.DataObjectReference{project, dataset},
+			wantAll:     true,
+			wantErrors: map[string][]string{
+				"apId1": {`access guardrail breached: 9 accesses (3 bindings, 4 managed group memberships, 2 masked reader roles) of the 16 existing bindings and managed group memberships would be removed (maximum 40%). The access sync aborted, so the bindings of project "project1" are not updated`},
+				"apId2": {`access guardrail breached: 9 accesses (3 bindings, 4 managed group memberships, 2 masked reader roles) of the 16 existing bindings and managed group memberships would be removed (maximum 40%). The access sync aborted, so the bindings of dataset "project1.dataset1" are not updated`},
+			},
+		},
+		{
+			name:        "Maximum removed bindings per data object",
+			guardrails:  accessGuardrails{maxRemovedBindingsPerDataObject: 2},
+			wantBlocked: []iam.DataObjectReference{project},
+			wantErrors: map[string][]string{
+				"apId1": {`access guardrail breached: 3 bindings would be removed from project "project1" (maximum 2 per data object). The access sync aborted, so the bindings of project "project1" are not updated`},
+			},
+		},
+		{
+			name:        "Maximum removed bindings per data object in dry-run mode",
+			guardrails:  accessGuardrails{maxRemovedBindingsPerDataObject: 2, dryRun: true},
+			wantBlocked: []iam.DataObjectReference{project, dataset},
+			wantAll:     true,
+			wantErrors: map[string][]string{
+				"apId1": {`access guardrail breached: 3 bindings would be removed from project "project1" (maximum 2 per data object). The access sync switched to dry-run, so the bindings of project "project1" are not updated`},
+				"apId2": {`access guardrail breached: a guardrail was breached on another data object. The access sync switched to dry-run, so the bindings of dataset "project1.dataset1" are not updated`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apFeedback := map[string]*importer.AccessProviderSyncFeedback{
+				"apId1": {AccessProvider: "apId1"},
+				"apId2": {AccessProvider: "apId2"},
+			}
+
+			blocked, all := tt.guardrails.check(bindings, &tt.changes, apFeedback)
+
+			assert.ElementsMatch(t, tt.wantBlocked, blocked.Slice())
+			assert.Equal(t, tt.wantAll, all)
+
+			for apId, feedback := range apFeedback {
+				assert.Equal(t, tt.wantErrors[apId], feedback.Errors, apId)
+			}
+		})
+	}
+}
+
+func TestAccessSyncer_SyncAccessProviderToTarget_GuardrailBlocksAllUpdates(t *testing.T) {
+	configMap := &config.ConfigMap{Parameters: map[string]string{
+		common.GcpAccessMaxRemovedBindings: "1",
+		common.GcpAccessGuardrailMode:      GuardrailModeDryRun,
+		common.GcpManagedGroups:            "true",
+		common.GcpManagedGroupsDomain:      "raito.io",
+		common.GcpMaskedReader:             "true",
+	}}
+
+	what := []importer.WhatItem{
+		{
+			DataObject:  &data_source.DataObjectReference{FullName: "project1", Type: "project"},
+			Permissions: []string{"roles/viewer", "roles/browser"},
+		},
+	}
+
+	accessProviders := &importer.AccessProviderImport{AccessProviders: []*importer.AccessProvider{
+		{Id: "apId1", Name: "ap1", Action: types.Grant, Delete: true, ActualName: ptr.String("raito-apid1@raito.io"), Who: importer.WhoItem{Users: []string{"ruben@raito.io"}}, What: what},
+		{Id: "apId2", Name: "ap2", Action: types.Grant, Who: importer.WhoItem{Users: []string{"bart@raito.io"}}},
+		{Id: "mask1", Name: "mask1", Action: types.Mask, ActualName: ptr.String("raito_mask1")},
+		{Id: "filter1", Name: "filter1", Action: types.Filtered},
+	}}
+
+	// The changes are planned, but none of them may be executed
+	managedGroupRepo := NewMockManagedGroupRepository(t)
+	managedGroupRepo.EXPECT().ListGroups(mock.Anything, "raito.io", "raito-").Return(map[string]string{}, nil).Once()
+
+	maskingService := NewMockMaskingService(t)
+	maskingService.EXPECT().MaskedReaderDataPolicies(mock.Anything, &iam.DataObjectReference{FullName: "project1", ObjectType: "project"}).Return([]string{"policy1"}, nil).Once()
+	maskingService.EXPECT().LegacyMaskedReaderResource().Return(nil).Once()
+
+	a := NewDataAccessSyncer(NewMockBindingRepository(t), NewMockProjectRepo(t), maskingService, NewMockFilteringService(t), managedGroupRepo, gcp.NewDataSourceMetaData(&config.ConfigMap{}), configMap)
+
+	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+	err := a.SyncAccessProviderToTarget(context.Background(), accessProviders, feedbackHandler, configMap)
+
+	skipped := "access guardrail breached: the access sync switched to dry-run, so this access provider is not updated"
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []importer.AccessProviderSyncFeedback{
+		{
+			AccessProvider: "apId1",
+			ActualName:     "raito-apid1@raito.io",
+			Type:           ptr.String(access_provider.AclSet),
+			Errors:         []string{`access guardrail breached: 3 accesses (2 bindings, 1 masked reader role) would be removed (maximum 1). The access sync switched to dry-run, so the bindings of project "project1" are not updated`},
+		},
+		{
+			AccessProvider: "apId2",
+			ActualName:     "raito-apid2@raito.io",
+			Type:           ptr.String(access_provider.AclSet),
+			Errors:         []string{skipped},
+			State: &importer.AccessProviderFeedbackState{
+				Who: importer.AccessProviderWhoFeedbackState{Users: []string{"bart@raito.io"}},
+			},
+		},
+		{AccessProvider: "mask1", ActualName: "raito_mask1", Errors: []string{skipped}},
+		{AccessProvider: "filter1", ActualName: "filter1", Errors: []string{skipped}},
+	}, feedbackHandler.AccessProviderFeedback)
+}
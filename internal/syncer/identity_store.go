@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/smithy-go/ptr"
 	is "github.com/raito-io/cli/base/identity_store"
+	"github.com/raito-io/cli/base/tag"
 	"github.com/raito-io/cli/base/util/config"
 	"github.com/raito-io/cli/base/wrappers"
 	"github.com/raito-io/golang-set/set"
@@ -49,7 +50,7 @@ func (s *IdentityStoreSyncer) SyncIdentityStore(ctx context.Context, identityHan
 		var groupMembership map[string]set.Set[string]
 		var err error
 
-		groupMembership, _, err = s.syncGcpGroups(ctx, identityHandler)
+		groupMembership, _, err = s.syncGcpGroups(ctx, identityHandler, newManagedGroupNaming(configMap))
 		if err != nil {
 			return err
 		}
@@ -104,7 +105,7 @@ func (s *IdentityStoreSyncer) syncGcpUsers(ctx context.Context, identityHandler
 	return userIds, nil
 }
 
-func (s *IdentityStoreSyncer) syncGcpGroups(ctx context.Context, identityHandler wrappers.IdentityStoreIdentityHandler) (map[string]set.Set[string], map[string]*is.Group, error) {
+func (s *IdentityStoreSyncer) syncGcpGroups(ctx context.Context, identityHandler wrappers.IdentityStoreIdentityHandler, managedGroups *managedGroupNaming) (map[string]set.Set[string], map[string]*is.Group, error) {
 	groupMembership := make(map[string]set.Set[string])
 	groups := map[string]*is.Group{}
 
@@ -118,6 +119,10 @@ func (s *IdentityStoreSyncer) syncGcpGroups(ctx context.Context, identityHandler
 			DisplayName: entity.Email,
 		}
 
+		if managedGroups.isManagedGroup(entity.Email, entity.Description) {
+			groups[entity.ExternalId].Tags = []*tag.Tag{{Key: managedGroupTagKey, Value: "true", Source: common.TagSource}}
+		}
+
 		for _, m := range entity.Members {
 			if _, f := groupMembership[m]; !f {
 				groupMembership[m] = set.NewSet[string](entity.ExternalId)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/aws/smithy-go/ptr"
 	"github.com/raito-io/cli/base/identity_store"
+	"github.com/raito-io/cli/base/tag"
 	"github.com/raito-io/cli/base/util/config"
 	"github.com/raito-io/cli/base/wrappers/mocks"
 	"github.com/stretchr/testify/assert"
@@ -204,6 +205,55 @@ func TestIdentityStoreSyncer_SyncIdentityStore(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "Managed groups are tagged",
+			fields: fields{mockSetup: func(adminRepoMock *MockAdminRepository) {
+				adminRepoMock.EXPECT().GetGroups(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, fn func(context.Context, *iam.GroupEntity) error) error {
+					err := fn(ctx, &iam.GroupEntity{ExternalId: "group:raito-apid1@raito.io", Email: "raito-apid1@raito.io", Description: "[Managed by Raito]", Members: []string{"user:ruben@raito.io"}})
+					if err != nil {
+						return err
+					}
+
+					err = fn(ctx, &iam.GroupEntity{ExternalId: "group:raito-admins@raito.io", Email: "raito-admins@raito.io", Description: "Administrators"})
+					if err != nil {
+						return err
+					}
+
+					return fn(ctx, &iam.GroupEntity{ExternalId: "group:engineers@raito.io", Email: "engineers@raito.io"})
+				})
+				adminRepoMock.EXPECT().GetUsers(mock.Anything, mock.Anything).Return(nil)
+			}},
+			args: args{
+				ctx: context.Background(),
+				configMap: &config.ConfigMap{Parameters: map[string]string{
+					common.GsuiteIdentityStoreSync: "true",
+					common.GcpManagedGroups:        "true",
+					common.GcpManagedGroupsDomain:  "raito.io",
+				}},
+			},
+			expected: expected{
+				groups: []identity_store.Group{
+					{
+						ExternalId:  "group:raito-apid1@raito.io",
+						Name:        "raito-apid1@raito.io",
+						DisplayName: "raito-apid1@raito.io",
+						Tags:        []*tag.Tag{{Key: "gcp.managed_group", Value: "true", Source: common.TagSource}},
+					},
+					{
+						ExternalId:  "group:raito-admins@raito.io",
+						Name:        "raito-admins@raito.io",
+						DisplayName: "raito-admins@raito.io",
+					},
+					{
+						ExternalId:  "group:engineers@raito.io",
+						Name:        "engineers@raito.io",
+						DisplayName: "engineers@raito.io",
+					},
+				},
+				users: []identity_store.User{},
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "Error during processing",
 			fields: fields{mockSetup: func(adminRepoMock *MockAdminRepository) {
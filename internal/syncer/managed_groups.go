@@ -0,0 +1,380 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/raito-io/golang-set/set"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+)
+
+const (
+	defaultManagedGroupPrefix = "raito-"
+	managedGroupTagKey        = "gcp.managed_group"
+
+	// managedGroupMarker is added to the description of the groups created by Raito. Groups that only match the naming of managed groups are not managed by Raito.
+	managedGroupMarker = "[Managed by Raito]"
+
+	// legacyManagedGroupMarker ends the description of groups created before the marker was introduced
+	legacyManagedGroupMarker = "Managed by Raito"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=ManagedGroupRepository --with-expecter --inpackage
+type ManagedGroupRepository interface {
+	UpsertGroup(ctx context.Context, email string, name string, description string) error
+	ListGroups(ctx context.Context, domain string, prefix string) (map[string]string, error)
+	GroupMembers(ctx context.Context, email string) ([]string, error)
+	AddGroupMember(ctx context.Context, email string, member string) error
+	RemoveGroupMember(ctx context.Context, email string, member string) error
+	DeleteGroup(ctx context.Context, email string) error
+}
+
+// managedGroupNaming defines the email addresses of the Google Groups that are created by Raito to materialize access providers.
+type managedGroupNaming struct {
+	prefix string
+	domain string
+
+	// threshold is the minimum number of users and groups of an access provider to be materialized as managed group. 0 means all access providers.
+	threshold int
+}
+
+// newManagedGroupNaming returns nil if managed groups are disabled.
+func newManagedGroupNaming(configMap *config.ConfigMap) *managedGroupNaming {
+	if !configMap.GetBoolWithDefault(common.GcpManagedGroups, false) {
+		return nil
+	}
+
+	domain := strings.ToLower(configMap.GetString(common.GcpManagedGroupsDomain))
+	if domain == "" {
+		common.Logger.Warn(fmt.Sprintf("Managed groups require %s to be set. Managed groups are disabled.", common.GcpManagedGroupsDomain))
+
+		return nil
+	}
+
+	return &managedGroupNaming{
+		prefix:    strings.ToLower(configMap.GetStringWithDefault(common.GcpManagedGroupsPrefix, defaultManagedGroupPrefix)),
+		domain:    domain,
+		threshold: configMap.GetIntWithDefault(common.GcpManagedGroupsThreshold, 0),
+	}
+}
+
+// groupEmail returns the email address of the managed group of an access provider.
+func (n *managedGroupNaming) groupEmail(apId string) string {
+	localPart := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+
+		return '-'
+	}, strings.ToLower(apId))
+
+	return fmt.Sprintf("%s%s@%s", n.prefix, localPart, n.domain)
+}
+
+// inheritedGroupEmail returns the email address of the managed group of an inherited access provider.
+// Access providers that are not synced yet are referenced by their id (ID:<id>), all others by their actual name.
+// The actual name is the email address of their group, or their id if they were granted directly.
+func (n *managedGroupNaming) inheritedGroupEmail(inheritFrom string) string {
+	if apId, found := strings.CutPrefix(inheritFrom, "ID:"); found {
+		return n.groupEmail(apId)
+	}
+
+	if !strings.Contains(inheritFrom, "@") {
+		return n.groupEmail(inheritFrom)
+	}
+
+	return strings.ToLower(inheritFrom)
+}
+
+// isManagedGroup returns true if the group is managed by Raito: its email address follows the naming of managed groups and its description holds the managed group marker.
+func (n *managedGroupNaming) isManagedGroup(email string, description string) bool {
+	return n.hasManagedGroupName(email) && isManagedGroupDescription(description)
+}
+
+// hasManagedGroupName returns true if the email address follows the naming of managed groups. This does not guarantee that the group is managed by Raito.
+func (n *managedGroupNaming) hasManagedGroupName(email string) bool {
+	if n == nil {
+		return false
+	}
+
+	email = strings.ToLower(strings.TrimPrefix(email, "group:"))
+
+	return strings.HasPrefix(email, n.prefix) && strings.HasSuffix(email, "@"+n.domain)
+}
+
+// previouslyGrantedDirectly returns true if the access provider was granted directly during the previous sync, i.e. its actual name is not a managed group.
+func (n *managedGroupNaming) previouslyGrantedDirectly(ap *importer.AccessProvider) bool {
+	return ap.ActualName != nil && *ap.ActualName != "" && !n.hasManagedGroupName(*ap.ActualName)
+}
+
+// previouslyMaterialized returns true if the access provider was materialized as managed group during a previous sync.
+func (n *managedGroupNaming) previouslyMaterialized(ap *importer.AccessProvider) bool {
+	return ap.ActualName != nil && strings.EqualFold(*ap.ActualName, n.groupEmail(ap.Id))
+}
+
+func isManagedGroupDescription(description string) bool {
+	return strings.HasSuffix(description, managedGroupMarker) || description == legacyManagedGroupMarker || strings.HasSuffix(description, ". "+legacyManagedGroupMarker)
+}
+
+func managedGroupDescription(ap *importer.AccessProvider) string {
+	if ap.Description == "" {
+		return managedGroupMarker
+	}
+
+	return ap.Description + "\n" + managedGroupMarker
+}
+
+// desiredMembers returns the members of the managed group of an access provider, keyed by their lowercase email address.
+// Inherited access providers are added as nested group.
+func (n *managedGroupNaming) desiredMembers(ap *importer.AccessProvider) map[string]string {
+	members := make(map[string]string)
+
+	for _, u := range ap.Who.Users {
+		if strings.Contains(u, "gserviceaccount.com") {
+			members[strings.ToLower(u)] = "serviceAccount:" + u
+		} else {
+			members[strings.ToLower(u)] = "user:" + u
+		}
+	}
+
+	for _, g := range ap.Who.Groups {
+		members[strings.ToLower(g)] = "group:" + g
+	}
+
+	for _, inheritFrom := range ap.Who.InheritFrom {
+		email := n.inheritedGroupEmail(inheritFrom)
+		members[email] = "group:" + email
+	}
+
+	return members
+}
+
+// loadManagedGroups lists the groups of the managed groups domain and returns the groups that are managed by Raito, i.e. that hold the managed group marker,
+// and the groups that only follow the naming of managed groups.
+func (a *AccessSyncer) loadManagedGroups(ctx context.Context) (set.Set[string], set.Set[string], error) {
+	managed := set.NewSet[string]()
+	unmanaged := set.NewSet[string]()
+
+	if a.managedGroups == nil {
+		return managed, unmanaged, nil
+	}
+
+	groups, err := a.managedGroupRepo.ListGroups(ctx, a.managedGroups.domain, a.managedGroups.prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for email, description := range groups {
+		if a.managedGroups.isManagedGroup(email, description) {
+			managed.Add(email)
+		} else if a.managedGroups.hasManagedGroupName(email) {
+			unmanaged.Add(email)
+		}
+	}
+
+	return managed, unmanaged, nil
+}
+
+// isExistingManagedGroup returns true if the member is a group that is managed by Raito.
+func (a *AccessSyncer) isExistingManagedGroup(member string) bool {
+	email, found := strings.CutPrefix(member, "group:")
+
+	return found && a.existingManagedGroups.Contains(strings.ToLower(email))
+}
+
+// groupMaterialized returns true if the managed group of an access provider was created by a previous sync, i.e. the group exists or is the actual name of the access provider.
+func (a *AccessSyncer) groupMaterialized(ap *importer.AccessProvider) bool {
+	return a.managedGroups.previouslyMaterialized(ap) || a.existingManagedGroups.Contains(a.managedGroups.groupEmail(ap.Id))
+}
+
+// managedGroupAccessProviders returns the ids of the access providers that are materialized as managed group.
+// If a threshold is configured, access providers with fewer users and groups are granted directly, unless they inherit or are inherited by another access provider.
+// Deleted access providers are always included, as they could have been materialized as managed group before.
+func (a *AccessSyncer) managedGroupAccessProviders(accessProviders []*importer.AccessProvider) set.Set[string] {
+	result := set.NewSet[string]()
+
+	if a.managedGroups == nil {
+		return result
+	}
+
+	inherited := set.NewSet[string]()
+
+	for _, ap := range accessProviders {
+		for _, inheritFrom := range ap.Who.InheritFrom {
+			inherited.Add(a.managedGroups.inheritedGroupEmail(inheritFrom))
+		}
+	}
+
+	for _, ap := range accessProviders {
+		if ap.Delete || len(ap.Who.InheritFrom) > 0 || inherited.Contains(a.managedGroups.groupEmail(ap.Id)) || len(ap.Who.Users)+len(ap.Who.Groups) >= a.managedGroups.threshold {
+			result.Add(ap.Id)
+		}
+	}
+
+	return result
+}
+
+// managedGroupUpdate contains the member changes of the managed group of an access provider.
+type managedGroupUpdate struct {
+	ap              *importer.AccessProvider
+	email           string
+	membersToAdd    []string
+	membersToRemove []string
+
+	// currentMembers is the number of members the group has before the update
+	currentMembers int
+}
+
+// planManagedGroups computes the member changes of the managed group of each access provider, so they can be counted by the guardrails before they are executed.
+// Groups that do not exist yet have no members. Protected principals are never added or removed, and are reported as warning instead.
+func (a *AccessSyncer) planManagedGroups(ctx context.Context, accessProviders []*importer.AccessProvider, managedGroupAps set.Set[string], apFeedback map[string]*importer.AccessProviderSyncFeedback) []*managedGroupUpdate {
+	var updates []*managedGroupUpdate
+
+	for _, ap := range accessProviders {
+		if ap.Delete || !managedGroupAps.Contains(ap.Id) {
+			continue
+		}
+
+		email := a.managedGroups.groupEmail(ap.Id)
+
+		if a.unmanagedGroups.Contains(email) {
+			handleErrors(fmt.Errorf("group %q already exists and is not managed by Raito", email), apFeedback, []*importer.AccessProvider{ap})
+
+			continue
+		}
+
+		var currentMembers []string
+
+		if a.existingManagedGroups.Contains(email) {
+			var err error
+
+			currentMembers, err = a.managedGroupRepo.GroupMembers(ctx, email)
+			if err != nil {
+				handleErrors(fmt.Errorf("update members of managed group: %w", err), apFeedback, []*importer.AccessProvider{ap})
+
+				continue
+			}
+		}
+
+		update := &managedGroupUpdate{ap: ap, email: email, currentMembers: len(currentMembers)}
+
+		a.diffManagedGroupMembers(update, currentMembers, a.managedGroups.desiredMembers(ap), func(member string) {
+			msg := fmt.Sprintf("membership of %s in managed group %q is protected and is not changed", member, email)
+
+			common.Logger.Warn(msg)
+
+			apFeedback[ap.Id].Warnings = append(apFeedback[ap.Id].Warnings, msg)
+		})
+
+		updates = append(updates, update)
+	}
+
+	return updates
+}
+
+// diffManagedGroupMembers computes the members to add to and remove from a managed group. Protected principals are reported to onProtected instead.
+func (a *AccessSyncer) diffManagedGroupMembers(update *managedGroupUpdate, currentMembers []string, desiredMembers map[string]string, onProtected func(member string)) {
+	current := make(map[string]string, len(currentMembers))
+
+	for _, m := range currentMembers {
+		_, memberEmail, _ := strings.Cut(m, ":")
+		current[strings.ToLower(memberEmail)] = m
+	}
+
+	for memberEmail, m := range desiredMembers {
+		if _, found := current[memberEmail]; found {
+			continue
+		}
+
+		if a.protected.isProtectedPrincipal(m) {
+			onProtected(m)
+
+			continue
+		}
+
+		update.membersToAdd = append(update.membersToAdd, m)
+	}
+
+	for memberEmail, m := range current {
+		if _, found := desiredMembers[memberEmail]; found {
+			continue
+		}
+
+		if a.protected.isProtectedPrincipal(m) {
+			onProtected(m)
+
+			continue
+		}
+
+		update.membersToRemove = append(update.membersToRemove, m)
+	}
+
+	sort.Strings(update.membersToAdd)
+	sort.Strings(update.membersToRemove)
+}
+
+// syncManagedGroups creates or updates the managed group of each access provider and updates the members of the groups. Blocked access providers are skipped.
+// All groups are created before the members are updated, so inherited access providers can be nested regardless of the order of the access providers.
+func (a *AccessSyncer) syncManagedGroups(ctx context.Context, updates []*managedGroupUpdate, blockedAps set.Set[string], apFeedback map[string]*importer.AccessProviderSyncFeedback) {
+	upserted := make([]*managedGroupUpdate, 0, len(updates))
+
+	for _, update := range updates {
+		if blockedAps.Contains(update.ap.Id) {
+			continue
+		}
+
+		err := a.managedGroupRepo.UpsertGroup(ctx, update.email, update.ap.Name, managedGroupDescription(update.ap))
+		if err != nil {
+			handleErrors(fmt.Errorf("upsert managed group: %w", err), apFeedback, []*importer.AccessProvider{update.ap})
+
+			continue
+		}
+
+		upserted = append(upserted, update)
+	}
+
+	for _, update := range upserted {
+		err := a.updateManagedGroupMembers(ctx, update)
+		if err != nil {
+			handleErrors(fmt.Errorf("update members of managed group: %w", err), apFeedback, []*importer.AccessProvider{update.ap})
+		}
+	}
+}
+
+func (a *AccessSyncer) updateManagedGroupMembers(ctx context.Context, update *managedGroupUpdate) error {
+	for _, m := range update.membersToAdd {
+		err := a.managedGroupRepo.AddGroupMember(ctx, update.email, m)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, m := range update.membersToRemove {
+		err := a.managedGroupRepo.RemoveGroupMember(ctx, update.email, m)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteManagedGroups deletes the managed groups of the deleted access providers and of the access providers that are granted directly. This is done after the bindings are removed.
+// Only groups that were created by a previous sync, i.e. of which the actual name is the managed group, are deleted.
+func (a *AccessSyncer) deleteManagedGroups(ctx context.Context, accessProviders []*importer.AccessProvider, managedGroupAps set.Set[string], apFeedback map[string]*importer.AccessProviderSyncFeedback) {
+	for _, ap := range accessProviders {
+		if (!ap.Delete && managedGroupAps.Contains(ap.Id)) || len(apFeedback[ap.Id].Errors) > 0 || !a.managedGroups.previouslyMaterialized(ap) || a.unmanagedGroups.Contains(a.managedGroups.groupEmail(ap.Id)) {
+			continue
+		}
+
+		err := a.managedGroupRepo.DeleteGroup(ctx, a.managedGroups.groupEmail(ap.Id))
+		if err != nil {
+			handleErrors(fmt.Errorf("delete managed group: %w", err), apFeedback, []*importer.AccessProvider{ap})
+		}
+	}
+}
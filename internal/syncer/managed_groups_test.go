@@ -0,0 +1,391 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/raito-io/cli/base/access_provider"
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/access_provider/types"
+	"github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/raito-io/cli/base/wrappers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/gcp"
+	"github.com/raito-io/cli-plugin-gcp/internal/iam"
+	"github.com/raito-io/cli-plugin-gcp/internal/org"
+)
+
+func TestNewManagedGroupNaming(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters map[string]string
+		want       *managedGroupNaming
+	}{
+		{
+			name:       "Disabled",
+			parameters: map[string]string{common.GcpManagedGroupsDomain: "raito.io"},
+			want:       nil,
+		},
+		{
+			name:       "Enabled without domain",
+			parameters: map[string]string{common.GcpManagedGroups: "true"},
+			want:       nil,
+		},
+		{
+			name:       "Enabled with default prefix",
+			parameters: map[string]string{common.GcpManagedGroups: "true", common.GcpManagedGroupsDomain: "Raito.io"},
+			want:       &managedGroupNaming{prefix: "raito-", domain: "raito.io"},
+		},
+		{
+			name:       "Enabled with custom prefix",
+			parameters: map[string]string{common.GcpManagedGroups: "true", common.GcpManagedGroupsDomain: "raito.io", common.GcpManagedGroupsPrefix: "ap."},
+			want:       &managedGroupNaming{prefix: "ap.", domain: "raito.io"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newManagedGroupNaming(&config.ConfigMap{Parameters: tt.parameters}))
+		})
+	}
+}
+
+func TestManagedGroupNaming(t *testing.T) {
+	naming := &managedGroupNaming{prefix: "raito-", domain: "raito.io"}
+
+	assert.Equal(t, "raito-apid1@raito.io", naming.groupEmail("apId1"))
+	assert.Equal(t, "raito-ap-id-2@raito.io", naming.groupEmail("ap id/2"))
+
+	assert.Equal(t, "raito-apid1@raito.io", naming.inheritedGroupEmail("ID:apId1"))
+	assert.Equal(t, "raito-apid1@raito.io", naming.inheritedGroupEmail("Raito-apId1@raito.io"))
+	assert.Equal(t, "raito-apid1@raito.io", naming.inheritedGroupEmail("apId1"))
+
+	assert.True(t, naming.isManagedGroup("group:raito-apid1@raito.io", "[Managed by Raito]"))
+	assert.True(t, naming.isManagedGroup("Raito-apId1@Raito.io", "Sales data\n[Managed by Raito]"))
+	assert.True(t, naming.isManagedGroup("raito-apid1@raito.io", "Sales data. Managed by Raito"))
+	assert.False(t, naming.isManagedGroup("raito-admins@raito.io", "Administrators"))
+	assert.False(t, naming.isManagedGroup("group:sales@raito.io", "[Managed by Raito]"))
+	assert.False(t, naming.isManagedGroup("user:raito-apid1@other.io", "[Managed by Raito]"))
+
+	assert.True(t, naming.hasManagedGroupName("raito-admins@raito.io"))
+	assert.False(t, naming.hasManagedGroupName("group:sales@raito.io"))
+
+	var disabled *managedGroupNaming
+	assert.False(t, disabled.isManagedGroup("group:raito-apid1@raito.io", "[Managed by Raito]"))
+
+	assert.Equal(t, map[string]string{
+		"ruben@raito.io":               "user:ruben@raito.io",
+		"sa@raito.gserviceaccount.com": "serviceAccount:sa@raito.gserviceaccount.com",
+		"sales@raito.io":               "group:Sales@raito.io",
+		"raito-apid2@raito.io":         "group:raito-apid2@raito.io",
+		"raito-apid3@raito.io":         "group:raito-apid3@raito.io",
+	}, naming.desiredMembers(&importer.AccessProvider{
+		Who: importer.WhoItem{
+			Users:       []string{"ruben@raito.io", "sa@raito.gserviceaccount.com"},
+			Groups:      []string{"Sales@raito.io"},
+			InheritFrom: []string{"ID:apId2", "raito-apid3@raito.io"},
+		},
+	}))
+}
+
+func TestAccessSyncer_SyncAccessProviderToTarget_ManagedGroups(t *testing.T) {
+	configMap := &config.ConfigMap{Parameters: map[string]string{
+		common.GcpManagedGroups:       "true",
+		common.GcpManagedGroupsDomain: "raito.io",
+		common.GcpProtectedPrincipals: "user:admin@raito.io",
+	}}
+
+	what := []importer.WhatItem{
+		{
+			DataObject:  &data_source.DataObjectReference{FullName: "project1", Type: "project"},
+			Permissions: []string{"roles/viewer"},
+		},
+	}
+
+	accessProviders := &importer.AccessProviderImport{AccessProviders: []*importer.AccessProvider{
+		{
+			Id:          "apId1",
+			Name:        "ap1",
+			Description: "parent",
+			Action:      types.Grant,
+			Who: importer.WhoItem{
+				Users:       []string{"ruben@raito.io"},
+				InheritFrom: []string{"ID:apId2"},
+			},
+			What: what,
+		},
+		{
+			Id:     "apId2",
+			Name:   "ap2",
+			Action: types.Grant,
+			Who: importer.WhoItem{
+				Groups: []string{"sales@raito.io"},
+			},
+			What: what,
+		},
+		{
+			Id:         "apId3",
+			Name:       "ap3",
+			ActualName: ptr.String("raito-apid3@raito.io"),
+			Action:     types.Grant,
+			Delete:     true,
+			What:       what,
+		},
+		{
+			Id:         "apId4",
+			Name:       "ap4",
+			ActualName: ptr.String("apId4"),
+			Action:     types.Grant,
+			Who: importer.WhoItem{
+				Users: []string{"michael@raito.io"},
+			},
+			What: what,
+		},
+	}}
+
+	bindingRepo := NewMockBindingRepository(t)
+	managedGroupRepo := NewMockManagedGroupRepository(t)
+
+	// Only the members of existing groups are listed
+	managedGroupRepo.EXPECT().ListGroups(mock.Anything, "raito.io", "raito-").Return(map[string]string{
+		"raito-apid1@raito.io":  "parent. Managed by Raito",
+		"raito-apid3@raito.io":  "[Managed by Raito]",
+		"raito-admins@raito.io": "Administrators",
+	}, nil).Once()
+
+	// apId4 was granted directly before, so its members are moved to its group
+	managedGroupRepo.EXPECT().UpsertGroup(mock.Anything, "raito-apid4@raito.io", "ap4", "[Managed by Raito]").Return(nil).Once()
+	managedGroupRepo.EXPECT().AddGroupMember(mock.Anything, "raito-apid4@raito.io", "user:michael@raito.io").Return(nil).Once()
+
+	managedGroupRepo.EXPECT().UpsertGroup(mock.Anything, "raito-apid1@raito.io", "ap1", "parent\n[Managed by Raito]").Return(nil).Once()
+	managedGroupRepo.EXPECT().UpsertGroup(mock.Anything, "raito-apid2@raito.io", "ap2", "[Managed by Raito]").Return(nil).Once()
+
+	managedGroupRepo.EXPECT().GroupMembers(mock.Anything, "raito-apid1@raito.io").Return([]string{"user:ruben@raito.io", "user:bart@raito.io", "user:admin@raito.io"}, nil).Once()
+	managedGroupRepo.EXPECT().AddGroupMember(mock.Anything, "raito-apid1@raito.io", "group:raito-apid2@raito.io").Return(nil).Once()
+	managedGroupRepo.EXPECT().RemoveGroupMember(mock.Anything, "raito-apid1@raito.io", "user:bart@raito.io").Return(nil).Once()
+
+	managedGroupRepo.EXPECT().AddGroupMember(mock.Anything, "raito-apid2@raito.io", "group:sales@raito.io").Return(errors.New("boom")).Once()
+
+	managedGroupRepo.EXPECT().DeleteGroup(mock.Anything, "raito-apid3@raito.io").Return(nil).Once()
+
+	bindingRepo.EXPECT().UpdateBindings(mock.Anything, &iam.DataObjectReference{FullName: "project1", ObjectType: "project"}, mock.Anything, mock.Anything).Run(func(ctx context.Context, dataObject *iam.DataObjectReference, addBindings []iam.IamBinding, removeBindings []iam.IamBinding) {
+		assert.ElementsMatch(t, []iam.IamBinding{
+			{Member: "group:raito-apid1@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"},
+			{Member: "group:raito-apid2@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"},
+			{Member: "group:raito-apid4@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"},
+		}, addBindings)
+		assert.ElementsMatch(t, []iam.IamBinding{
+			{Member: "group:raito-apid3@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"},
+			{Member: "user:michael@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"},
+		}, removeBindings)
+	}).Return(nil).Once()
+
+	maskingService := NewMockMaskingService(t)
+	maskingService.EXPECT().ApplyColumnPolicyTags(mock.Anything).Return(nil).Once()
+
+	a := NewDataAccessSyncer(bindingRepo, NewMockProjectRepo(t), maskingService, NewMockFilteringService(t), managedGroupRepo, gcp.NewDataSourceMetaData(&config.ConfigMap{}), configMap)
+
+	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+	err := a.SyncAccessProviderToTarget(context.Background(), accessProviders, feedbackHandler, configMap)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []importer.AccessProviderSyncFeedback{
+		{
+			AccessProvider: "apId1",
+			ActualName:     "raito-apid1@raito.io",
+			Type:           ptr.String(access_provider.AclSet),
+			Warnings:       []string{`membership of user:admin@raito.io in managed group "raito-apid1@raito.io" is protected and is not changed`},
+			State: &importer.AccessProviderFeedbackState{
+				Who: importer.AccessProviderWhoFeedbackState{Users: []string{"ruben@raito.io"}},
+			},
+		},
+		{
+			AccessProvider: "apId2",
+			ActualName:     "raito-apid2@raito.io",
+			Type:           ptr.String(access_provider.AclSet),
+			Errors:         []string{"update members of managed group: boom"},
+			State: &importer.AccessProviderFeedbackState{
+				Who: importer.AccessProviderWhoFeedbackState{Groups: []string{"sales@raito.io"}},
+			},
+		},
+		{
+			AccessProvider: "apId3",
+			ActualName:     "raito-apid3@raito.io",
+			Type:           ptr.String(access_provider.AclSet),
+		},
+		{
+			AccessProvider: "apId4",
+			ActualName:     "raito-apid4@raito.io",
+			Type:           ptr.String(access_provider.AclSet),
+			State: &importer.AccessProviderFeedbackState{
+				Who: importer.AccessProviderWhoFeedbackState{Users: []string{"michael@raito.io"}},
+			},
+		},
+	}, feedbackHandler.AccessProviderFeedback)
+}
+
+func TestAccessSyncer_SyncAccessProviderToTarget_ManagedGroupsThreshold(t *testing.T) {
+	configMap := &config.ConfigMap{Parameters: map[string]string{
+		common.GcpManagedGroups:          "true",
+		common.GcpManagedGroupsDomain:    "raito.io",
+		common.GcpManagedGroupsThreshold: "2",
+	}}
+
+	what := []importer.WhatItem{
+		{
+			DataObject:  &data_source.DataObjectReference{FullName: "project1", Type: "project"},
+			Permissions: []string{"roles/viewer"},
+		},
+	}
+
+	accessProviders := &importer.AccessProviderImport{AccessProviders: []*importer.AccessProvider{
+		{
+			Id:     "apId1",
+			Name:   "ap1",
+			Action: types.Grant,
+			Who: importer.WhoItem{
+				Users:  []string{"ruben@raito.io"},
+				Groups: []string{"sales@raito.io"},
+			},
+			What: what,
+		},
+		{
+			Id:         "apId2",
+			Name:       "ap2",
+			ActualName: ptr.String("raito-apid2@raito.io"),
+			Action:     types.Grant,
+			Who: importer.WhoItem{
+				Users: []string{"bart@raito.io"},
+			},
+			What: what,
+		},
+		{
+			Id:     "apId3",
+			Name:   "ap3",
+			Action: types.Grant,
+			Who: importer.WhoItem{
+				InheritFrom: []string{"ID:apId4"},
+			},
+			What: what,
+		},
+		{
+			Id:     "apId4",
+			Name:   "ap4",
+			Action: types.Grant,
+			Who: importer.WhoItem{
+				Users: []string{"dieter@raito.io"},
+			},
+			What: what,
+		},
+		{
+			Id:         "apId5",
+			Name:       "ap5",
+			ActualName: ptr.String("apId5"),
+			Action:     types.Grant,
+			Delete:     true,
+			Who: importer.WhoItem{
+				Users: []string{"thomas@raito.io"},
+			},
+			What: what,
+		},
+	}}
+
+	bindingRepo := NewMockBindingRepository(t)
+	managedGroupRepo := NewMockManagedGroupRepository(t)
+
+	managedGroupRepo.EXPECT().ListGroups(mock.Anything, "raito.io", "raito-").Return(map[string]string{
+		"raito-apid2@raito.io": "[Managed by Raito]",
+	}, nil).Once()
+
+	// apId1 reaches the threshold, apId3 inherits and apId4 is inherited
+	for _, ap := range []string{"apid1", "apid3", "apid4"} {
+		managedGroupRepo.EXPECT().UpsertGroup(mock.Anything, "raito-"+ap+"@raito.io", mock.Anything, "[Managed by Raito]").Return(nil).Once()
+	}
+
+	managedGroupRepo.EXPECT().AddGroupMember(mock.Anything, "raito-apid1@raito.io", "user:ruben@raito.io").Return(nil).Once()
+	managedGroupRepo.EXPECT().AddGroupMember(mock.Anything, "raito-apid1@raito.io", "group:sales@raito.io").Return(nil).Once()
+	managedGroupRepo.EXPECT().AddGroupMember(mock.Anything, "raito-apid3@raito.io", "group:raito-apid4@raito.io").Return(nil).Once()
+	managedGroupRepo.EXPECT().AddGroupMember(mock.Anything, "raito-apid4@raito.io", "user:dieter@raito.io").Return(nil).Once()
+
+	// apId2 is granted directly, so its previously created group is removed. apId5 was granted directly and has no group, so no group bindings are removed.
+	managedGroupRepo.EXPECT().DeleteGroup(mock.Anything, "raito-apid2@raito.io").Return(nil).Once()
+
+	bindingRepo.EXPECT().UpdateBindings(mock.Anything, &iam.DataObjectReference{FullName: "project1", ObjectType: "project"}, mock.Anything, mock.Anything).Run(func(ctx context.Context, dataObject *iam.DataObjectReference, addBindings []iam.IamBinding, removeBindings []iam.IamBinding) {
+		assert.ElementsMatch(t, []iam.IamBinding{
+			{Member: "group:raito-apid1@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"},
+			{Member: "user:bart@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"},
+			{Member: "group:raito-apid3@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"},
+			{Member: "group:raito-apid4@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"},
+		}, addBindings)
+		assert.ElementsMatch(t, []iam.IamBinding{
+			{Member: "group:raito-apid2@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"},
+			{Member: "user:thomas@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"},
+		}, removeBindings)
+	}).Return(nil).Once()
+
+	maskingService := NewMockMaskingService(t)
+	maskingService.EXPECT().ApplyColumnPolicyTags(mock.Anything).Return(nil).Once()
+
+	a := NewDataAccessSyncer(bindingRepo, NewMockProjectRepo(t), maskingService, NewMockFilteringService(t), managedGroupRepo, gcp.NewDataSourceMetaData(&config.ConfigMap{}), configMap)
+
+	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+	err := a.SyncAccessProviderToTarget(context.Background(), accessProviders, feedbackHandler, configMap)
+
+	assert.NoError(t, err)
+
+	actualNames := make(map[string]string)
+	for _, feedback := range feedbackHandler.AccessProviderFeedback {
+		assert.Empty(t, feedback.Errors)
+
+		actualNames[feedback.AccessProvider] = feedback.ActualName
+	}
+
+	assert.Equal(t, map[string]string{
+		"apId1": "raito-apid1@raito.io",
+		"apId2": "apId2",
+		"apId3": "raito-apid3@raito.io",
+		"apId4": "raito-apid4@raito.io",
+		"apId5": "raito-apid5@raito.io",
+	}, actualNames)
+}
+
+func TestAccessSyncer_SyncAccessProvidersFromTarget_ManagedGroups(t *testing.T) {
+	configMap := &config.ConfigMap{Parameters: map[string]string{
+		common.GcpManagedGroups:       "true",
+		common.GcpManagedGroupsDomain: "raito.io",
+	}}
+
+	bindingRepo := NewMockBindingRepository(t)
+	managedGroupRepo := NewMockManagedGroupRepository(t)
+
+	managedGroupRepo.EXPECT().ListGroups(mock.Anything, "raito.io", "raito-").Return(map[string]string{
+		"raito-apid1@raito.io":  "[Managed by Raito]",
+		"raito-admins@raito.io": "Administrators",
+	}, nil).Once()
+
+	bindingRepo.EXPECT().Bindings(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, config *data_source.DataSourceSyncConfig, f func(context.Context, *org.GcpOrgEntity, []iam.IamBinding) error) error {
+		return f(ctx, &org.GcpOrgEntity{Id: "folder1", Type: "folder", Name: "folder1"}, []iam.IamBinding{
+			{Member: "group:raito-apid1@raito.io", Resource: "folder1", ResourceType: "folder", Role: "roles/viewer"},
+			{Member: "group:raito-admins@raito.io", Resource: "folder1", ResourceType: "folder", Role: "roles/viewer"},
+		})
+	}).Once()
+
+	a := NewDataAccessSyncer(bindingRepo, NewMockProjectRepo(t), NewMockMaskingService(t), NewMockFilteringService(t), managedGroupRepo, gcp.NewDataSourceMetaData(&config.ConfigMap{}), configMap)
+
+	accessProviderHandler := mocks.NewSimpleAccessProviderHandler(t, 1)
+
+	err := a.SyncAccessProvidersFromTarget(context.Background(), accessProviderHandler, configMap)
+
+	// Only the bindings of the group created by Raito are skipped. raito-admins only follows the naming of managed groups.
+	require.NoError(t, err)
+	require.Len(t, accessProviderHandler.AccessProviders, 1)
+	assert.Equal(t, []string{"raito-admins@raito.io"}, accessProviderHandler.AccessProviders[0].Who.Groups)
+}
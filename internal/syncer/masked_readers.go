@@ -0,0 +1,381 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/golang-set/set"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/common/roles"
+	"github.com/raito-io/cli-plugin-gcp/internal/iam"
+)
+
+// maskedReaderUpdate contains the desired masked readers of a data policy, the members of which the masked reader role is removed, and the access providers causing the update.
+// Kept members are not added, but their masked reader role is not removed either.
+type maskedReaderUpdate struct {
+	desiredMembers  set.Set[string]
+	membersToRemove set.Set[string]
+	keptMembers     set.Set[string]
+	accessProviders []*importer.AccessProvider
+}
+
+// maskedReaderUpdates groups the masked reader updates by data policy.
+type maskedReaderUpdates map[string]*maskedReaderUpdate
+
+func (u maskedReaderUpdates) get(dataPolicyId string) *maskedReaderUpdate {
+	update, found := u[dataPolicyId]
+	if !found {
+		update = &maskedReaderUpdate{desiredMembers: set.NewSet[string](), membersToRemove: set.NewSet[string](), keptMembers: set.NewSet[string]()}
+		u[dataPolicyId] = update
+	}
+
+	return update
+}
+
+func (u maskedReaderUpdates) getForAccessProvider(dataPolicyId string, ap *importer.AccessProvider) *maskedReaderUpdate {
+	update := u.get(dataPolicyId)
+
+	if len(update.accessProviders) == 0 || update.accessProviders[len(update.accessProviders)-1] != ap {
+		update.accessProviders = append(update.accessProviders, ap)
+	}
+
+	return update
+}
+
+func (u maskedReaderUpdates) add(dataPolicyIds []string, members []string, ap *importer.AccessProvider) {
+	for _, dataPolicyId := range dataPolicyIds {
+		u.getForAccessProvider(dataPolicyId, ap).desiredMembers.Add(members...)
+	}
+}
+
+func (u maskedReaderUpdates) remove(dataPolicyIds []string, members []string, ap *importer.AccessProvider) {
+	for _, dataPolicyId := range dataPolicyIds {
+		u.getForAccessProvider(dataPolicyId, ap).membersToRemove.Add(members...)
+	}
+}
+
+func (u maskedReaderUpdates) keep(dataPolicyIds []string, members []string) {
+	for _, dataPolicyId := range dataPolicyIds {
+		u.get(dataPolicyId).keptMembers.Add(members...)
+	}
+}
+
+// maskedReaderGrant contains the members and the data policies of a grant.
+type maskedReaderGrant struct {
+	ap                  *importer.AccessProvider
+	members             []string
+	deleteMembers       []string
+	dataPolicies        []string
+	deletedDataPolicies []string
+}
+
+// maskedReaderPlan contains the masked reader changes of all grants of an access sync, so they can be counted by the guardrails before they are executed.
+type maskedReaderPlan struct {
+	grants []maskedReaderGrant
+
+	// desiredStateComplete is false if the data policies of a grant cannot be determined, in which case the role is not removed from anyone
+	desiredStateComplete bool
+}
+
+// updates returns the masked reader updates of each data policy. The bindings of blocked access providers are not updated, so their members keep their masked reader role.
+// The desired masked readers of each data policy are the members of all grants that are not deleted, as each sync contains all access providers of the data source.
+// The role is removed for deleted grants, deleted who items and deleted what items, unless it is still desired.
+func (p *maskedReaderPlan) updates(blockedAps set.Set[string]) maskedReaderUpdates {
+	updates := maskedReaderUpdates{}
+
+	for i := range p.grants {
+		g := &p.grants[i]
+
+		switch {
+		case blockedAps.Contains(g.ap.Id):
+			updates.keep(slices.Concat(g.dataPolicies, g.deletedDataPolicies), slices.Concat(g.members, g.deleteMembers))
+		case g.ap.Delete:
+			updates.remove(slices.Concat(g.dataPolicies, g.deletedDataPolicies), slices.Concat(g.members, g.deleteMembers), g.ap)
+		default:
+			updates.add(g.dataPolicies, g.members, g.ap)
+			updates.remove(g.dataPolicies, g.deleteMembers, g.ap)
+			updates.remove(g.deletedDataPolicies, slices.Concat(g.members, g.deleteMembers), g.ap)
+		}
+	}
+
+	for _, update := range updates {
+		if p.desiredStateComplete {
+			update.membersToRemove.RemoveAll(update.desiredMembers.Slice()...)
+			update.membersToRemove.RemoveAll(update.keptMembers.Slice()...)
+		} else {
+			update.membersToRemove = set.NewSet[string]()
+		}
+	}
+
+	return updates
+}
+
+// planMaskedReaders determines the data policies that mask the data objects of each grant, of which the members receive the masked reader role.
+func (a *AccessSyncer) planMaskedReaders(ctx context.Context, grants []*importer.AccessProvider, managedGroupAps set.Set[string], apFeedback map[string]*importer.AccessProviderSyncFeedback) *maskedReaderPlan {
+	plan := &maskedReaderPlan{desiredStateComplete: true}
+
+	if !a.addMaskedReader {
+		return plan
+	}
+
+	for _, ap := range grants {
+		members, deleteMembers := a.grantMembers(ap, managedGroupAps)
+
+		dataPolicies, err := a.maskedReaderDataPolicies(ctx, ap.What)
+		if err != nil {
+			handleErrors(err, apFeedback, []*importer.AccessProvider{ap})
+
+			if !ap.Delete {
+				plan.desiredStateComplete = false
+			}
+
+			continue
+		}
+
+		deletedDataPolicies, err := a.maskedReaderDataPolicies(ctx, ap.DeleteWhat)
+		if err != nil {
+			handleErrors(err, apFeedback, []*importer.AccessProvider{ap})
+
+			continue
+		}
+
+		plan.grants = append(plan.grants, maskedReaderGrant{ap: ap, members: members, deleteMembers: deleteMembers, dataPolicies: dataPolicies, deletedDataPolicies: deletedDataPolicies})
+	}
+
+	if !plan.desiredStateComplete {
+		common.Logger.Warn("The data policies of some grants cannot be determined. No masked readers are removed in this sync.")
+	}
+
+	return plan
+}
+
+// maskedReaderRemovals returns the number of masked reader roles that would be removed by the updates.
+func (a *AccessSyncer) maskedReaderRemovals(updates maskedReaderUpdates) int {
+	removals := 0
+
+	for dataPolicyId, update := range updates {
+		removals += len(a.unprotectedMaskedReaders(dataPolicyId, update.membersToRemove))
+	}
+
+	return removals
+}
+
+// exportMaskedReaders grants the members of the grants the masked reader role on the data policies that mask their data objects, and removes it where it is no longer granted.
+func (a *AccessSyncer) exportMaskedReaders(ctx context.Context, updates maskedReaderUpdates, apFeedback map[string]*importer.AccessProviderSyncFeedback) {
+	dataPolicyIds := make([]string, 0, len(updates))
+	for dataPolicyId := range updates {
+		dataPolicyIds = append(dataPolicyIds, dataPolicyId)
+	}
+
+	sort.Strings(dataPolicyIds)
+
+	for _, dataPolicyId := range dataPolicyIds {
+		update := updates[dataPolicyId]
+
+		membersToAdd := a.unprotectedMaskedReaders(dataPolicyId, update.desiredMembers)
+		membersToRemove := a.unprotectedMaskedReaders(dataPolicyId, update.membersToRemove)
+
+		if len(membersToAdd) == 0 && len(membersToRemove) == 0 {
+			continue
+		}
+
+		common.Logger.Debug(fmt.Sprintf("Update masked readers of data policy %q. Adding: %v; Removing: %v", dataPolicyId, membersToAdd, membersToRemove))
+
+		err := a.maskingService.UpdateMaskedReaders(ctx, dataPolicyId, membersToAdd, membersToRemove)
+		if err != nil {
+			handleErrors(fmt.Errorf("update masked readers of data policy %q: %w", dataPolicyId, err), apFeedback, update.accessProviders)
+		}
+	}
+}
+
+// legacyMaskedReaderPlan contains the masked reader bindings that earlier versions granted on the project or organization of the data source and that are removed once.
+type legacyMaskedReaderPlan struct {
+	resource        *iam.DataObjectReference
+	accessProviders map[iam.IamBinding][]*importer.AccessProvider
+}
+
+// planLegacyMaskedReaders returns the legacy masked reader bindings to remove, or nil if they were already removed.
+// Earlier versions granted the role to the who items of each grant with what items, so only the existing masked reader bindings of those members are removed,
+// unless a grant explicitly gives the role on the project or organization.
+func (a *AccessSyncer) planLegacyMaskedReaders(ctx context.Context, grants []*importer.AccessProvider, bindings *BindingContainer, apFeedback map[string]*importer.AccessProviderSyncFeedback) *legacyMaskedReaderPlan {
+	if !a.addMaskedReader {
+		return nil
+	}
+
+	resource := a.maskingService.LegacyMaskedReaderResource()
+	if resource == nil {
+		return nil
+	}
+
+	if a.state == nil {
+		common.Logger.Warn(fmt.Sprintf("The masked reader bindings of earlier versions on %s %q are not removed, as %s is not set", resource.ObjectType, resource.FullName, common.GcpStateDir))
+
+		return nil
+	}
+
+	if _, done := a.state.Get(legacyMaskedReadersStateKey(resource)); done {
+		return nil
+	}
+
+	current, err := a.bindingRepo.GetBindings(ctx, resource)
+	if err != nil {
+		common.Logger.Warn(fmt.Sprintf("Unable to load the bindings of %s %q. The legacy masked reader bindings are removed in a later sync: %s", resource.ObjectType, resource.FullName, err.Error()))
+
+		return nil
+	}
+
+	existing := set.NewSet[iam.IamBinding]()
+
+	for _, binding := range current {
+		if binding.Role == roles.RolesBigQueryMaskedReader.Name {
+			existing.Add(iam.IamBinding{Member: binding.Member, Role: binding.Role, Resource: resource.FullName, ResourceType: resource.ObjectType})
+		}
+	}
+
+	var explicitBindings set.Set[iam.IamBinding]
+	if resourceBindings, found := bindings.bindings[*resource]; found {
+		explicitBindings = resourceBindings.bindingsToAdd
+	}
+
+	plan := &legacyMaskedReaderPlan{resource: resource, accessProviders: make(map[iam.IamBinding][]*importer.AccessProvider)}
+
+	for _, ap := range grants {
+		if len(ap.What) == 0 {
+			continue
+		}
+
+		members, deleteMembers := accessProviderMembers(ap)
+
+		for _, member := range slices.Concat(members, deleteMembers) {
+			binding := iam.IamBinding{Member: member, Role: roles.RolesBigQueryMaskedReader.Name, Resource: resource.FullName, ResourceType: resource.ObjectType}
+
+			if !existing.Contains(binding) || (explicitBindings != nil && explicitBindings.Contains(binding)) {
+				continue
+			}
+
+			if a.protected.isProtected(binding) {
+				msg := fmt.Sprintf("legacy masked reader binding for %s on %s %q is protected and is not removed", member, resource.ObjectType, resource.FullName)
+
+				common.Logger.Warn(msg)
+
+				if !slices.Contains(apFeedback[ap.Id].Warnings, msg) {
+					apFeedback[ap.Id].Warnings = append(apFeedback[ap.Id].Warnings, msg)
+				}
+
+				continue
+			}
+
+			plan.accessProviders[binding] = append(plan.accessProviders[binding], ap)
+		}
+	}
+
+	return plan
+}
+
+// removals returns the number of legacy masked reader bindings that would be removed.
+func (p *legacyMaskedReaderPlan) removals() int {
+	if p == nil {
+		return 0
+	}
+
+	return len(p.accessProviders)
+}
+
+// removeLegacyMaskedReaders removes the legacy masked reader bindings and records that they were removed, so they are only removed once.
+// Bindings of which all access providers are blocked by a guardrail are kept, and are removed in a later sync.
+func (a *AccessSyncer) removeLegacyMaskedReaders(ctx context.Context, plan *legacyMaskedReaderPlan, blockedAps set.Set[string], apFeedback map[string]*importer.AccessProviderSyncFeedback) {
+	if plan == nil {
+		return
+	}
+
+	var legacyBindings []iam.IamBinding
+
+	var accessProviders []*importer.AccessProvider
+
+	complete := true
+
+	for binding, aps := range plan.accessProviders {
+		if !slices.ContainsFunc(aps, func(ap *importer.AccessProvider) bool { return !blockedAps.Contains(ap.Id) }) {
+			complete = false
+
+			continue
+		}
+
+		legacyBindings = append(legacyBindings, binding)
+		accessProviders = append(accessProviders, aps...)
+	}
+
+	sort.Slice(legacyBindings, func(i, j int) bool { return legacyBindings[i].Member < legacyBindings[j].Member })
+
+	if len(legacyBindings) > 0 {
+		common.Logger.Info(fmt.Sprintf("Remove %d legacy masked reader bindings of %s %q", len(legacyBindings), plan.resource.ObjectType, plan.resource.FullName))
+
+		err := a.bindingRepo.UpdateBindings(ctx, plan.resource, nil, legacyBindings)
+		if err != nil {
+			handleErrors(fmt.Errorf("remove legacy masked reader bindings of %s %q: %w", plan.resource.ObjectType, plan.resource.FullName, err), apFeedback, accessProviders)
+
+			return
+		}
+	}
+
+	if complete {
+		a.state.Set(legacyMaskedReadersStateKey(plan.resource), time.Now())
+	}
+}
+
+func legacyMaskedReadersStateKey(resource *iam.DataObjectReference) string {
+	return fmt.Sprintf("legacy-masked-readers/%s/%s", resource.ObjectType, resource.FullName)
+}
+
+// maskedReaderDataPolicies returns the data policies that mask the data objects of the what items.
+func (a *AccessSyncer) maskedReaderDataPolicies(ctx context.Context, whatItems []importer.WhatItem) ([]string, error) {
+	dataPolicies := set.NewSet[string]()
+
+	for _, w := range whatItems {
+		if w.DataObject == nil || w.DataObject.Type == data_source.Column {
+			continue
+		}
+
+		objectType := w.DataObject.Type
+		if objectType == data_source.Datasource {
+			objectType = a.bindingRepo.DataSourceType()
+		}
+
+		policies, err := a.maskingService.MaskedReaderDataPolicies(ctx, &iam.DataObjectReference{FullName: w.DataObject.FullName, ObjectType: objectType})
+		if err != nil {
+			return nil, fmt.Errorf("data policies of %s %q: %w", objectType, w.DataObject.FullName, err)
+		}
+
+		dataPolicies.Add(policies...)
+	}
+
+	result := dataPolicies.Slice()
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// unprotectedMaskedReaders returns the sorted members of which the masked reader role on the data policy can be changed.
+func (a *AccessSyncer) unprotectedMaskedReaders(dataPolicyId string, members set.Set[string]) []string {
+	result := make([]string, 0, len(members))
+
+	for member := range members {
+		if a.protected.isProtected(iam.IamBinding{Member: member, Role: roles.RolesBigQueryMaskedReader.Name, Resource: dataPolicyId}) {
+			common.Logger.Debug(fmt.Sprintf("Masked reader %q of data policy %q is protected and is not changed", member, dataPolicyId))
+
+			continue
+		}
+
+		result = append(result, member)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
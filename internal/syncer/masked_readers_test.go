@@ -0,0 +1,301 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/access_provider/types"
+	"github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/raito-io/golang-set/set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/gcp"
+	"github.com/raito-io/cli-plugin-gcp/internal/iam"
+)
+
+func TestAccessSyncer_exportMaskedReaders(t *testing.T) {
+	dataset1 := importer.WhatItem{DataObject: &data_source.DataObjectReference{FullName: "project1.dataset1", Type: "dataset"}, Permissions: []string{"roles/bigquery.dataViewer"}}
+	dataset2 := importer.WhatItem{DataObject: &data_source.DataObjectReference{FullName: "project1.dataset2", Type: "dataset"}, Permissions: []string{"roles/bigquery.dataViewer"}}
+
+	type args struct {
+		grants     []*importer.AccessProvider
+		blockedAps []string
+		configMap  map[string]string
+		mocksSetup func(maskingService *MockMaskingService)
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantRemovals int
+		wantErrors   map[string][]string
+	}{
+		{
+			name: "masked reader disabled",
+			args: args{
+				grants: []*importer.AccessProvider{
+					{Id: "ap1", Action: types.Grant, Who: importer.WhoItem{Users: []string{"ruben@raito.io"}}, What: []importer.WhatItem{dataset1}},
+				},
+				configMap:  map[string]string{},
+				mocksSetup: func(maskingService *MockMaskingService) {},
+			},
+		},
+		{
+			name: "grant members on data policies of the what items",
+			args: args{
+				grants: []*importer.AccessProvider{
+					{
+						Id:         "ap1",
+						Action:     types.Grant,
+						Who:        importer.WhoItem{Users: []string{"ruben@raito.io"}},
+						DeletedWho: &importer.WhoItem{Users: []string{"michael@raito.io"}},
+						What:       []importer.WhatItem{dataset1},
+						DeleteWhat: []importer.WhatItem{dataset2},
+					},
+				},
+				configMap: map[string]string{common.GcpMaskedReader: "true"},
+				mocksSetup: func(maskingService *MockMaskingService) {
+					maskingService.EXPECT().MaskedReaderDataPolicies(mock.Anything, &iam.DataObjectReference{FullName: "project1.dataset1", ObjectType: "dataset"}).Return([]string{"policy1", "policy2"}, nil)
+					maskingService.EXPECT().MaskedReaderDataPolicies(mock.Anything, &iam.DataObjectReference{FullName: "project1.dataset2", ObjectType: "dataset"}).Return([]string{"policy2", "policy3"}, nil)
+
+					maskingService.EXPECT().UpdateMaskedReaders(mock.Anything, "policy1", []string{"user:ruben@raito.io"}, []string{"user:michael@raito.io"}).Return(nil)
+					maskingService.EXPECT().UpdateMaskedReaders(mock.Anything, "policy2", []string{"user:ruben@raito.io"}, []string{"user:michael@raito.io"}).Return(nil)
+					maskingService.EXPECT().UpdateMaskedReaders(mock.Anything, "policy3", []string{}, []string{"user:michael@raito.io", "user:ruben@raito.io"}).Return(nil)
+				},
+			},
+			wantRemovals: 4,
+		},
+		{
+			name: "deleted grant removes members, unless granted by another access provider",
+			args: args{
+				grants: []*importer.AccessProvider{
+					{Id: "ap1", Action: types.Grant, Delete: true, Who: importer.WhoItem{Users: []string{"ruben@raito.io", "michael@raito.io"}}, What: []importer.WhatItem{dataset1}},
+					{Id: "ap2", Action: types.Grant, Who: importer.WhoItem{Users: []string{"ruben@raito.io"}}, What: []importer.WhatItem{dataset1}},
+				},
+				configMap: map[string]string{common.GcpMaskedReader: "true"},
+				mocksSetup: func(maskingService *MockMaskingService) {
+					maskingService.EXPECT().MaskedReaderDataPolicies(mock.Anything, &iam.DataObjectReference{FullName: "project1.dataset1", ObjectType: "dataset"}).Return([]string{"policy1"}, nil)
+
+					maskingService.EXPECT().UpdateMaskedReaders(mock.Anything, "policy1", []string{"user:ruben@raito.io"}, []string{"user:michael@raito.io"}).Return(nil)
+				},
+			},
+			wantRemovals: 1,
+		},
+		{
+			name: "members of blocked grants keep the masked reader role",
+			args: args{
+				grants: []*importer.AccessProvider{
+					{Id: "ap1", Action: types.Grant, Delete: true, Who: importer.WhoItem{Users: []string{"ruben@raito.io", "michael@raito.io"}}, What: []importer.WhatItem{dataset1}},
+					{Id: "ap2", Action: types.Grant, Who: importer.WhoItem{Users: []string{"michael@raito.io", "bart@raito.io"}}, What: []importer.WhatItem{dataset1}},
+				},
+				blockedAps: []string{"ap2"},
+				configMap:  map[string]string{common.GcpMaskedReader: "true"},
+				mocksSetup: func(maskingService *MockMaskingService) {
+					maskingService.EXPECT().MaskedReaderDataPolicies(mock.Anything, &iam.DataObjectReference{FullName: "project1.dataset1", ObjectType: "dataset"}).Return([]string{"policy1"}, nil)
+
+					maskingService.EXPECT().UpdateMaskedReaders(mock.Anything, "policy1", []string{}, []string{"user:ruben@raito.io"}).Return(nil)
+				},
+			},
+			wantRemovals: 1,
+		},
+		{
+			name: "protected members are not changed",
+			args: args{
+				grants: []*importer.AccessProvider{
+					{Id: "ap1", Action: types.Grant, Who: importer.WhoItem{Users: []string{"ruben@raito.io", "admin@raito.io"}}, What: []importer.WhatItem{dataset1}},
+				},
+				configMap: map[string]string{common.GcpMaskedReader: "true", common.GcpProtectedPrincipals: "user:admin@raito.io"},
+				mocksSetup: func(maskingService *MockMaskingService) {
+					maskingService.EXPECT().MaskedReaderDataPolicies(mock.Anything, &iam.DataObjectReference{FullName: "project1.dataset1", ObjectType: "dataset"}).Return([]string{"policy1"}, nil)
+
+					maskingService.EXPECT().UpdateMaskedReaders(mock.Anything, "policy1", []string{"user:ruben@raito.io"}, []string{}).Return(nil)
+				},
+			},
+		},
+		{
+			name: "errors are added to the feedback of the access providers",
+			args: args{
+				grants: []*importer.AccessProvider{
+					{Id: "ap1", Action: types.Grant, Who: importer.WhoItem{Users: []string{"ruben@raito.io"}}, What: []importer.WhatItem{dataset1}},
+					{Id: "ap2", Action: types.Grant, Who: importer.WhoItem{Users: []string{"michael@raito.io"}}, What: []importer.WhatItem{dataset2}},
+				},
+				configMap: map[string]string{common.GcpMaskedReader: "true"},
+				mocksSetup: func(maskingService *MockMaskingService) {
+					maskingService.EXPECT().MaskedReaderDataPolicies(mock.Anything, &iam.DataObjectReference{FullName: "project1.dataset1", ObjectType: "dataset"}).Return([]string{"policy1"}, nil)
+					maskingService.EXPECT().MaskedReaderDataPolicies(mock.Anything, &iam.DataObjectReference{FullName: "project1.dataset2", ObjectType: "dataset"}).Return(nil, errors.New("boom"))
+
+					maskingService.EXPECT().UpdateMaskedReaders(mock.Anything, "policy1", []string{"user:ruben@raito.io"}, []string{}).Return(errors.New("boom"))
+				},
+			},
+			wantErrors: map[string][]string{
+				"ap1": {`update masked readers of data policy "policy1": boom`},
+				"ap2": {`data policies of dataset "project1.dataset2": boom`},
+			},
+		},
+		{
+			name: "no members are removed if the desired state is incomplete",
+			args: args{
+				grants: []*importer.AccessProvider{
+					{Id: "ap1", Action: types.Grant, Delete: true, Who: importer.WhoItem{Users: []string{"ruben@raito.io"}}, What: []importer.WhatItem{dataset1}},
+					{Id: "ap2", Action: types.Grant, Who: importer.WhoItem{Users: []string{"ruben@raito.io"}}, What: []importer.WhatItem{dataset2}},
+				},
+				configMap: map[string]string{common.GcpMaskedReader: "true"},
+				mocksSetup: func(maskingService *MockMaskingService) {
+					maskingService.EXPECT().MaskedReaderDataPolicies(mock.Anything, &iam.DataObjectReference{FullName: "project1.dataset1", ObjectType: "dataset"}).Return([]string{"policy1"}, nil)
+					maskingService.EXPECT().MaskedReaderDataPolicies(mock.Anything, &iam.DataObjectReference{FullName: "project1.dataset2", ObjectType: "dataset"}).Return(nil, errors.New("boom"))
+				},
+			},
+			wantErrors: map[string][]string{
+				"ap2": {`data policies of dataset "project1.dataset2": boom`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncer, _, _, maskingService, _ := createAccessSyncer(t, gcp.NewDataSourceMetaData(&config.ConfigMap{}), &config.ConfigMap{Parameters: tt.args.configMap})
+			tt.args.mocksSetup(maskingService)
+
+			apFeedback := map[string]*importer.AccessProviderSyncFeedback{}
+			for _, ap := range tt.args.grants {
+				apFeedback[ap.Id] = &importer.AccessProviderSyncFeedback{AccessProvider: ap.Id}
+			}
+
+			plan := syncer.planMaskedReaders(context.Background(), tt.args.grants, syncer.managedGroupAccessProviders(tt.args.grants), apFeedback)
+			updates := plan.updates(set.NewSet(tt.args.blockedAps...))
+
+			assert.Equal(t, tt.wantRemovals, syncer.maskedReaderRemovals(updates))
+
+			syncer.exportMaskedReaders(context.Background(), updates, apFeedback)
+
+			for _, ap := range tt.args.grants {
+				assert.Equal(t, tt.wantErrors[ap.Id], apFeedback[ap.Id].Errors, ap.Id)
+			}
+		})
+	}
+}
+
+func TestAccessSyncer_removeLegacyMaskedReaders(t *testing.T) {
+	project := iam.DataObjectReference{FullName: "project1", ObjectType: "project"}
+	dataset1 := importer.WhatItem{DataObject: &data_source.DataObjectReference{FullName: "project1.dataset1", Type: "dataset"}, Permissions: []string{"roles/bigquery.dataViewer"}}
+	maskedReader := importer.WhatItem{DataObject: &data_source.DataObjectReference{FullName: "project1", Type: "project"}, Permissions: []string{"roles/bigquerydatapolicy.maskedReader"}}
+
+	currentBindings := []iam.IamBinding{
+		{Member: "user:ruben@raito.io", Role: "roles/bigquerydatapolicy.maskedReader", Resource: "project1", ResourceType: "project"},
+		{Member: "user:ruben@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"},
+		{Member: "user:admin@raito.io", Role: "roles/bigquerydatapolicy.maskedReader", Resource: "project1", ResourceType: "project"},
+		{Member: "user:michael@raito.io", Role: "roles/bigquerydatapolicy.maskedReader", Resource: "project1", ResourceType: "project"},
+		{Member: "group:sales@raito.io", Role: "roles/bigquerydatapolicy.maskedReader", Resource: "project1", ResourceType: "project"},
+		{Member: "user:dieter@raito.io", Role: "roles/bigquerydatapolicy.maskedReader", Resource: "project1", ResourceType: "project"},
+		{Member: "user:thomas@raito.io", Role: "roles/bigquerydatapolicy.maskedReader", Resource: "project1", ResourceType: "project"},
+	}
+
+	newGrants := func() []*importer.AccessProvider {
+		return []*importer.AccessProvider{
+			{Id: "ap1", Action: types.Grant, Who: importer.WhoItem{Users: []string{"ruben@raito.io", "admin@raito.io"}}, DeletedWho: &importer.WhoItem{Users: []string{"michael@raito.io"}}, What: []importer.WhatItem{dataset1}},
+			{Id: "ap2", Action: types.Grant, Delete: true, Who: importer.WhoItem{Groups: []string{"sales@raito.io"}}, What: []importer.WhatItem{dataset1}},
+			{Id: "ap3", Action: types.Grant, Who: importer.WhoItem{Users: []string{"dieter@raito.io"}}, What: []importer.WhatItem{maskedReader}},
+			{Id: "ap4", Action: types.Grant, Who: importer.WhoItem{Users: []string{"thomas@raito.io"}}},
+		}
+	}
+
+	legacyBinding := func(member string) iam.IamBinding {
+		return iam.IamBinding{Member: member, Role: "roles/bigquerydatapolicy.maskedReader", Resource: "project1", ResourceType: "project"}
+	}
+
+	type args struct {
+		blockedAps []string
+		updateErr  error
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantRemoved  []iam.IamBinding
+		wantRemovals int
+		wantDone     bool
+		wantErrors   map[string][]string
+	}{
+		{
+			name:         "remove the legacy bindings of the who items of grants",
+			wantRemoved:  []iam.IamBinding{legacyBinding("group:sales@raito.io"), legacyBinding("user:michael@raito.io"), legacyBinding("user:ruben@raito.io")},
+			wantRemovals: 3,
+			wantDone:     true,
+		},
+		{
+			name:         "keep the legacy bindings of blocked access providers",
+			args:         args{blockedAps: []string{"ap2"}},
+			wantRemoved:  []iam.IamBinding{legacyBinding("user:michael@raito.io"), legacyBinding("user:ruben@raito.io")},
+			wantRemovals: 3,
+			wantDone:     false,
+		},
+		{
+			name:         "report errors to the access providers",
+			args:         args{updateErr: errors.New("boom")},
+			wantRemoved:  []iam.IamBinding{legacyBinding("group:sales@raito.io"), legacyBinding("user:michael@raito.io"), legacyBinding("user:ruben@raito.io")},
+			wantRemovals: 3,
+			wantDone:     false,
+			wantErrors: map[string][]string{
+				"ap1": {`remove legacy masked reader bindings of project "project1": boom`},
+				"ap2": {`remove legacy masked reader bindings of project "project1": boom`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncer, bindingRepo, _, maskingService, _ := createAccessSyncer(t, gcp.NewDataSourceMetaData(&config.ConfigMap{}), &config.ConfigMap{Parameters: map[string]string{common.GcpMaskedReader: "true", common.GcpProtectedPrincipals: "admin@raito.io", common.GcpStateDir: t.TempDir()}})
+
+			maskingService.EXPECT().LegacyMaskedReaderResource().Return(&project)
+			loads := 2
+			if tt.wantDone {
+				loads = 1
+			}
+
+			bindingRepo.EXPECT().GetBindings(mock.Anything, &project).Return(currentBindings, nil).Times(loads)
+			bindingRepo.EXPECT().UpdateBindings(mock.Anything, &project, []iam.IamBinding(nil), tt.wantRemoved).Return(tt.args.updateErr).Once()
+
+			grants := newGrants()
+			apFeedback := map[string]*importer.AccessProviderSyncFeedback{}
+
+			for _, ap := range grants {
+				apFeedback[ap.Id] = &importer.AccessProviderSyncFeedback{AccessProvider: ap.Id}
+			}
+
+			managedGroupAps := syncer.managedGroupAccessProviders(grants)
+			bindings := syncer.convertAccessProviderToBindings(context.Background(), grants, managedGroupAps)
+
+			plan := syncer.planLegacyMaskedReaders(context.Background(), grants, bindings, apFeedback)
+			assert.Equal(t, tt.wantRemovals, plan.removals())
+
+			syncer.removeLegacyMaskedReaders(context.Background(), plan, set.NewSet(tt.args.blockedAps...), apFeedback)
+
+			assert.Equal(t, []string{`legacy masked reader binding for user:admin@raito.io on project "project1" is protected and is not removed`}, apFeedback["ap1"].Warnings)
+
+			for _, ap := range grants {
+				assert.Equal(t, tt.wantErrors[ap.Id], apFeedback[ap.Id].Errors, ap.Id)
+			}
+
+			// Once removed, the legacy bindings are not loaded again
+			if tt.wantDone {
+				assert.Nil(t, syncer.planLegacyMaskedReaders(context.Background(), grants, bindings, apFeedback))
+			} else {
+				assert.NotNil(t, syncer.planLegacyMaskedReaders(context.Background(), grants, bindings, apFeedback))
+			}
+		})
+	}
+}
+
+func TestAccessSyncer_planLegacyMaskedReaders_NoStateDir(t *testing.T) {
+	project := iam.DataObjectReference{FullName: "project1", ObjectType: "project"}
+
+	syncer, _, _, maskingService, _ := createAccessSyncer(t, gcp.NewDataSourceMetaData(&config.ConfigMap{}), &config.ConfigMap{Parameters: map[string]string{common.GcpMaskedReader: "true"}})
+
+	maskingService.EXPECT().LegacyMaskedReaderResource().Return(&project).Once()
+
+	plan := syncer.planLegacyMaskedReaders(context.Background(), nil, NewBindingContainer(), map[string]*importer.AccessProviderSyncFeedback{})
+
+	assert.Nil(t, plan)
+	assert.Equal(t, 0, plan.removals())
+}
@@ -119,6 +119,65 @@ func (_c *MockBindingRepository_DataSourceType_Call) RunAndReturn(run func() str
 	return _c
 }
 
+// GetBindings provides a mock function with given fields: ctx, dataObject
+func (_m *MockBindingRepository) GetBindings(ctx context.Context, dataObject *iam.DataObjectReference) ([]iam.IamBinding, error) {
+	ret := _m.Called(ctx, dataObject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBindings")
+	}
+
+	var r0 []iam.IamBinding
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *iam.DataObjectReference) ([]iam.IamBinding, error)); ok {
+		return rf(ctx, dataObject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *iam.DataObjectReference) []iam.IamBinding); ok {
+		r0 = rf(ctx, dataObject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]iam.IamBinding)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *iam.DataObjectReference) error); ok {
+		r1 = rf(ctx, dataObject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBindingRepository_GetBindings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBindings'
+type MockBindingRepository_GetBindings_Call struct {
+	*mock.Call
+}
+
+// GetBindings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dataObject *iam.DataObjectReference
+func (_e *MockBindingRepository_Expecter) GetBindings(ctx interface{}, dataObject interface{}) *MockBindingRepository_GetBindings_Call {
+	return &MockBindingRepository_GetBindings_Call{Call: _e.mock.On("GetBindings", ctx, dataObject)}
+}
+
+func (_c *MockBindingRepository_GetBindings_Call) Run(run func(ctx context.Context, dataObject *iam.DataObjectReference)) *MockBindingRepository_GetBindings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*iam.DataObjectReference))
+	})
+	return _c
+}
+
+func (_c *MockBindingRepository_GetBindings_Call) Return(_a0 []iam.IamBinding, _a1 error) *MockBindingRepository_GetBindings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBindingRepository_GetBindings_Call) RunAndReturn(run func(context.Context, *iam.DataObjectReference) ([]iam.IamBinding, error)) *MockBindingRepository_GetBindings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateBindings provides a mock function with given fields: ctx, dataObject, addBindings, removeBindings
 func (_m *MockBindingRepository) UpdateBindings(ctx context.Context, dataObject *iam.DataObjectReference, addBindings []iam.IamBinding, removeBindings []iam.IamBinding) error {
 	ret := _m.Called(ctx, dataObject, addBindings, removeBindings)
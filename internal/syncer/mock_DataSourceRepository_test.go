@@ -72,6 +72,53 @@ func (_c *MockDataSourceRepository_DataObjects_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// UpdateMetadata provides a mock function with given fields: ctx, update
+func (_m *MockDataSourceRepository) UpdateMetadata(ctx context.Context, update *org.MetadataUpdate) error {
+	ret := _m.Called(ctx, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMetadata")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *org.MetadataUpdate) error); ok {
+		r0 = rf(ctx, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDataSourceRepository_UpdateMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMetadata'
+type MockDataSourceRepository_UpdateMetadata_Call struct {
+	*mock.Call
+}
+
+// UpdateMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - update *org.MetadataUpdate
+func (_e *MockDataSourceRepository_Expecter) UpdateMetadata(ctx interface{}, update interface{}) *MockDataSourceRepository_UpdateMetadata_Call {
+	return &MockDataSourceRepository_UpdateMetadata_Call{Call: _e.mock.On("UpdateMetadata", ctx, update)}
+}
+
+func (_c *MockDataSourceRepository_UpdateMetadata_Call) Run(run func(ctx context.Context, update *org.MetadataUpdate)) *MockDataSourceRepository_UpdateMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*org.MetadataUpdate))
+	})
+	return _c
+}
+
+func (_c *MockDataSourceRepository_UpdateMetadata_Call) Return(_a0 error) *MockDataSourceRepository_UpdateMetadata_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDataSourceRepository_UpdateMetadata_Call) RunAndReturn(run func(context.Context, *org.MetadataUpdate) error) *MockDataSourceRepository_UpdateMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockDataSourceRepository creates a new instance of MockDataSourceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockDataSourceRepository(t interface {
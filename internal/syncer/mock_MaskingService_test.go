@@ -28,6 +28,102 @@ func (_m *MockMaskingService) EXPECT() *MockMaskingService_Expecter {
 	return &MockMaskingService_Expecter{mock: &_m.Mock}
 }
 
+// ApplyColumnPolicyTags provides a mock function with given fields: ctx
+func (_m *MockMaskingService) ApplyColumnPolicyTags(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyColumnPolicyTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMaskingService_ApplyColumnPolicyTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyColumnPolicyTags'
+type MockMaskingService_ApplyColumnPolicyTags_Call struct {
+	*mock.Call
+}
+
+// ApplyColumnPolicyTags is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMaskingService_Expecter) ApplyColumnPolicyTags(ctx interface{}) *MockMaskingService_ApplyColumnPolicyTags_Call {
+	return &MockMaskingService_ApplyColumnPolicyTags_Call{Call: _e.mock.On("ApplyColumnPolicyTags", ctx)}
+}
+
+func (_c *MockMaskingService_ApplyColumnPolicyTags_Call) Run(run func(ctx context.Context)) *MockMaskingService_ApplyColumnPolicyTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockMaskingService_ApplyColumnPolicyTags_Call) Return(_a0 error) *MockMaskingService_ApplyColumnPolicyTags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMaskingService_ApplyColumnPolicyTags_Call) RunAndReturn(run func(context.Context) error) *MockMaskingService_ApplyColumnPolicyTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportColumnAccess provides a mock function with given fields: ctx, accessProvider, feedback
+func (_m *MockMaskingService) ExportColumnAccess(ctx context.Context, accessProvider *sync_to_target.AccessProvider, feedback *sync_to_target.AccessProviderSyncFeedback) []string {
+	ret := _m.Called(ctx, accessProvider, feedback)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportColumnAccess")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, *sync_to_target.AccessProvider, *sync_to_target.AccessProviderSyncFeedback) []string); ok {
+		r0 = rf(ctx, accessProvider, feedback)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// MockMaskingService_ExportColumnAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportColumnAccess'
+type MockMaskingService_ExportColumnAccess_Call struct {
+	*mock.Call
+}
+
+// ExportColumnAccess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accessProvider *sync_to_target.AccessProvider
+//   - feedback *sync_to_target.AccessProviderSyncFeedback
+func (_e *MockMaskingService_Expecter) ExportColumnAccess(ctx interface{}, accessProvider interface{}, feedback interface{}) *MockMaskingService_ExportColumnAccess_Call {
+	return &MockMaskingService_ExportColumnAccess_Call{Call: _e.mock.On("ExportColumnAccess", ctx, accessProvider, feedback)}
+}
+
+func (_c *MockMaskingService_ExportColumnAccess_Call) Run(run func(ctx context.Context, accessProvider *sync_to_target.AccessProvider, feedback *sync_to_target.AccessProviderSyncFeedback)) *MockMaskingService_ExportColumnAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*sync_to_target.AccessProvider), args[2].(*sync_to_target.AccessProviderSyncFeedback))
+	})
+	return _c
+}
+
+func (_c *MockMaskingService_ExportColumnAccess_Call) Return(_a0 []string) *MockMaskingService_ExportColumnAccess_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMaskingService_ExportColumnAccess_Call) RunAndReturn(run func(context.Context, *sync_to_target.AccessProvider, *sync_to_target.AccessProviderSyncFeedback) []string) *MockMaskingService_ExportColumnAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ExportMasks provides a mock function with given fields: ctx, accessProvider, accessProviderFeedbackHandler
 func (_m *MockMaskingService) ExportMasks(ctx context.Context, accessProvider *sync_to_target.AccessProvider, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler) ([]string, error) {
 	ret := _m.Called(ctx, accessProvider, accessProviderFeedbackHandler)
@@ -138,29 +234,76 @@ func (_c *MockMaskingService_ImportMasks_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
-// MaskedBinding provides a mock function with given fields: ctx, members
-func (_m *MockMaskingService) MaskedBinding(ctx context.Context, members []string) ([]iam.IamBinding, error) {
-	ret := _m.Called(ctx, members)
+// LegacyMaskedReaderResource provides a mock function with given fields:
+func (_m *MockMaskingService) LegacyMaskedReaderResource() *iam.DataObjectReference {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LegacyMaskedReaderResource")
+	}
+
+	var r0 *iam.DataObjectReference
+	if rf, ok := ret.Get(0).(func() *iam.DataObjectReference); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*iam.DataObjectReference)
+		}
+	}
+
+	return r0
+}
+
+// MockMaskingService_LegacyMaskedReaderResource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LegacyMaskedReaderResource'
+type MockMaskingService_LegacyMaskedReaderResource_Call struct {
+	*mock.Call
+}
+
+// LegacyMaskedReaderResource is a helper method to define mock.On call
+func (_e *MockMaskingService_Expecter) LegacyMaskedReaderResource() *MockMaskingService_LegacyMaskedReaderResource_Call {
+	return &MockMaskingService_LegacyMaskedReaderResource_Call{Call: _e.mock.On("LegacyMaskedReaderResource")}
+}
+
+func (_c *MockMaskingService_LegacyMaskedReaderResource_Call) Run(run func()) *MockMaskingService_LegacyMaskedReaderResource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMaskingService_LegacyMaskedReaderResource_Call) Return(_a0 *iam.DataObjectReference) *MockMaskingService_LegacyMaskedReaderResource_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMaskingService_LegacyMaskedReaderResource_Call) RunAndReturn(run func() *iam.DataObjectReference) *MockMaskingService_LegacyMaskedReaderResource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MaskedReaderDataPolicies provides a mock function with given fields: ctx, dataObject
+func (_m *MockMaskingService) MaskedReaderDataPolicies(ctx context.Context, dataObject *iam.DataObjectReference) ([]string, error) {
+	ret := _m.Called(ctx, dataObject)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MaskedBinding")
+		panic("no return value specified for MaskedReaderDataPolicies")
 	}
 
-	var r0 []iam.IamBinding
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]iam.IamBinding, error)); ok {
-		return rf(ctx, members)
+	if rf, ok := ret.Get(0).(func(context.Context, *iam.DataObjectReference) ([]string, error)); ok {
+		return rf(ctx, dataObject)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) []iam.IamBinding); ok {
-		r0 = rf(ctx, members)
+	if rf, ok := ret.Get(0).(func(context.Context, *iam.DataObjectReference) []string); ok {
+		r0 = rf(ctx, dataObject)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]iam.IamBinding)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = rf(ctx, members)
+	if rf, ok := ret.Get(1).(func(context.Context, *iam.DataObjectReference) error); ok {
+		r1 = rf(ctx, dataObject)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -168,31 +311,80 @@ func (_m *MockMaskingService) MaskedBinding(ctx context.Context, members []strin
 	return r0, r1
 }
 
-// MockMaskingService_MaskedBinding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MaskedBinding'
-type MockMaskingService_MaskedBinding_Call struct {
+// MockMaskingService_MaskedReaderDataPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MaskedReaderDataPolicies'
+type MockMaskingService_MaskedReaderDataPolicies_Call struct {
 	*mock.Call
 }
 
-// MaskedBinding is a helper method to define mock.On call
+// MaskedReaderDataPolicies is a helper method to define mock.On call
 //   - ctx context.Context
-//   - members []string
-func (_e *MockMaskingService_Expecter) MaskedBinding(ctx interface{}, members interface{}) *MockMaskingService_MaskedBinding_Call {
-	return &MockMaskingService_MaskedBinding_Call{Call: _e.mock.On("MaskedBinding", ctx, members)}
+//   - dataObject *iam.DataObjectReference
+func (_e *MockMaskingService_Expecter) MaskedReaderDataPolicies(ctx interface{}, dataObject interface{}) *MockMaskingService_MaskedReaderDataPolicies_Call {
+	return &MockMaskingService_MaskedReaderDataPolicies_Call{Call: _e.mock.On("MaskedReaderDataPolicies", ctx, dataObject)}
 }
 
-func (_c *MockMaskingService_MaskedBinding_Call) Run(run func(ctx context.Context, members []string)) *MockMaskingService_MaskedBinding_Call {
+func (_c *MockMaskingService_MaskedReaderDataPolicies_Call) Run(run func(ctx context.Context, dataObject *iam.DataObjectReference)) *MockMaskingService_MaskedReaderDataPolicies_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]string))
+		run(args[0].(context.Context), args[1].(*iam.DataObjectReference))
 	})
 	return _c
 }
 
-func (_c *MockMaskingService_MaskedBinding_Call) Return(_a0 []iam.IamBinding, _a1 error) *MockMaskingService_MaskedBinding_Call {
+func (_c *MockMaskingService_MaskedReaderDataPolicies_Call) Return(_a0 []string, _a1 error) *MockMaskingService_MaskedReaderDataPolicies_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockMaskingService_MaskedBinding_Call) RunAndReturn(run func(context.Context, []string) ([]iam.IamBinding, error)) *MockMaskingService_MaskedBinding_Call {
+func (_c *MockMaskingService_MaskedReaderDataPolicies_Call) RunAndReturn(run func(context.Context, *iam.DataObjectReference) ([]string, error)) *MockMaskingService_MaskedReaderDataPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMaskedReaders provides a mock function with given fields: ctx, dataPolicyId, membersToAdd, membersToRemove
+func (_m *MockMaskingService) UpdateMaskedReaders(ctx context.Context, dataPolicyId string, membersToAdd []string, membersToRemove []string) error {
+	ret := _m.Called(ctx, dataPolicyId, membersToAdd, membersToRemove)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMaskedReaders")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, []string) error); ok {
+		r0 = rf(ctx, dataPolicyId, membersToAdd, membersToRemove)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMaskingService_UpdateMaskedReaders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMaskedReaders'
+type MockMaskingService_UpdateMaskedReaders_Call struct {
+	*mock.Call
+}
+
+// UpdateMaskedReaders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dataPolicyId string
+//   - membersToAdd []string
+//   - membersToRemove []string
+func (_e *MockMaskingService_Expecter) UpdateMaskedReaders(ctx interface{}, dataPolicyId interface{}, membersToAdd interface{}, membersToRemove interface{}) *MockMaskingService_UpdateMaskedReaders_Call {
+	return &MockMaskingService_UpdateMaskedReaders_Call{Call: _e.mock.On("UpdateMaskedReaders", ctx, dataPolicyId, membersToAdd, membersToRemove)}
+}
+
+func (_c *MockMaskingService_UpdateMaskedReaders_Call) Run(run func(ctx context.Context, dataPolicyId string, membersToAdd []string, membersToRemove []string)) *MockMaskingService_UpdateMaskedReaders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string), args[3].([]string))
+	})
+	return _c
+}
+
+func (_c *MockMaskingService_UpdateMaskedReaders_Call) Return(_a0 error) *MockMaskingService_UpdateMaskedReaders_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMaskingService_UpdateMaskedReaders_Call) RunAndReturn(run func(context.Context, string, []string, []string) error) *MockMaskingService_UpdateMaskedReaders_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,106 @@
+package syncer
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/util/config"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/iam"
+)
+
+// defaultProtectedPrincipals are always protected. Google-managed service agents are required by the GCP services themselves.
+var defaultProtectedPrincipals = []string{"service-*@gcp-sa-*.iam.gserviceaccount.com"}
+
+// protectedBindings defines the principals and roles of which the bindings are never imported or changed by Raito.
+// Patterns support the wildcards of path.Match. Principal patterns without a type prefix (e.g. user:) match the email address of any principal type.
+type protectedBindings struct {
+	principals []string
+	roles      []string
+}
+
+func newProtectedBindings(configMap *config.ConfigMap) *protectedBindings {
+	return &protectedBindings{
+		principals: append(slices.Clone(defaultProtectedPrincipals), parsePatterns(configMap.GetString(common.GcpProtectedPrincipals))...),
+		roles:      parsePatterns(configMap.GetString(common.GcpProtectedRoles)),
+	}
+}
+
+func parsePatterns(value string) []string {
+	var patterns []string
+
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	return patterns
+}
+
+// isProtected returns true if the member or the role of the binding is protected.
+func (p *protectedBindings) isProtected(binding iam.IamBinding) bool {
+	return p.isProtectedPrincipal(binding.Member) || matchesAny(p.roles, strings.ToLower(binding.Role))
+}
+
+func (p *protectedBindings) isProtectedPrincipal(member string) bool {
+	member = strings.ToLower(member)
+	_, email, _ := strings.Cut(member, ":")
+
+	for _, pattern := range p.principals {
+		value := email
+		if strings.Contains(pattern, ":") {
+			value = member
+		}
+
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filter removes the protected bindings from the bindings to add and remove, and adds a warning to the feedback of the access providers that tried to change them.
+func (p *protectedBindings) filter(bindings *BindingContainer, apFeedback map[string]*importer.AccessProviderSyncFeedback) {
+	for do, doBindings := range bindings.bindings {
+		for binding, aps := range doBindings.accessProviders {
+			if !p.isProtected(binding) {
+				continue
+			}
+
+			doBindings.bindingsToAdd.Remove(binding)
+			doBindings.bindingsToDelete.Remove(binding)
+			delete(doBindings.accessProviders, binding)
+
+			msg := fmt.Sprintf("binding of role %s for %s on %s %q is protected and is not changed", binding.Role, binding.Member, do.ObjectType, do.FullName)
+
+			common.Logger.Warn(msg)
+
+			for _, ap := range aps {
+				if !slices.Contains(apFeedback[ap.Id].Warnings, msg) {
+					apFeedback[ap.Id].Warnings = append(apFeedback[ap.Id].Warnings, msg)
+				}
+			}
+		}
+
+		if len(doBindings.bindingsToAdd) == 0 && len(doBindings.bindingsToDelete) == 0 {
+			delete(bindings.bindings, do)
+		}
+	}
+}
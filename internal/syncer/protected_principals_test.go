@@ -0,0 +1,80 @@
+package syncer
+
+import (
+	"testing"
+
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/raito-io/golang-set/set"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/raito-io/cli-plugin-gcp/internal/common"
+	"github.com/raito-io/cli-plugin-gcp/internal/iam"
+)
+
+func TestProtectedBindings_IsProtected(t *testing.T) {
+	p := newProtectedBindings(&config.ConfigMap{Parameters: map[string]string{
+		common.GcpProtectedPrincipals: "user:break-glass@raito.io, serviceAccount:ci-*@raito-ci.iam.gserviceaccount.com,admins@raito.io",
+		common.GcpProtectedRoles:      "roles/owner,roles/resourcemanager.*",
+	}})
+
+	tests := []struct {
+		member string
+		role   string
+		want   bool
+	}{
+		{member: "user:ruben@raito.io", role: "roles/viewer", want: false},
+		{member: "user:Break-Glass@raito.io", role: "roles/viewer", want: true},
+		{member: "group:break-glass@raito.io", role: "roles/viewer", want: false},
+		{member: "serviceAccount:ci-deploy@raito-ci.iam.gserviceaccount.com", role: "roles/viewer", want: true},
+		{member: "serviceAccount:deploy@raito-ci.iam.gserviceaccount.com", role: "roles/viewer", want: false},
+		{member: "group:admins@raito.io", role: "roles/viewer", want: true},
+		{member: "user:admins@raito.io", role: "roles/viewer", want: true},
+		{member: "serviceAccount:service-123456@gcp-sa-bigquerydatatransfer.iam.gserviceaccount.com", role: "roles/viewer", want: true},
+		{member: "user:ruben@raito.io", role: "roles/owner", want: true},
+		{member: "user:ruben@raito.io", role: "roles/resourcemanager.folderAdmin", want: true},
+		{member: "user:ruben@raito.io", role: "roles/bigquery.dataOwner", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.member+" "+tt.role, func(t *testing.T) {
+			assert.Equal(t, tt.want, p.isProtected(iam.IamBinding{Member: tt.member, Role: tt.role}))
+		})
+	}
+}
+
+func TestProtectedBindings_Filter(t *testing.T) {
+	p := newProtectedBindings(&config.ConfigMap{Parameters: map[string]string{
+		common.GcpProtectedPrincipals: "user:break-glass@raito.io",
+	}})
+
+	project := iam.DataObjectReference{FullName: "project1", ObjectType: "project"}
+	dataset := iam.DataObjectReference{FullName: "project1.dataset1", ObjectType: "dataset"}
+
+	ap1 := &importer.AccessProvider{Id: "apId1"}
+	ap2 := &importer.AccessProvider{Id: "apId2"}
+
+	allowed := iam.IamBinding{Member: "user:ruben@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"}
+	protectedRemoval := iam.IamBinding{Member: "user:break-glass@raito.io", Role: "roles/viewer", Resource: "project1", ResourceType: "project"}
+	protectedAddition := iam.IamBinding{Member: "user:break-glass@raito.io", Role: "roles/bigquery.dataViewer", Resource: "project1.dataset1", ResourceType: "dataset"}
+
+	bindings := NewBindingContainer()
+	bindings.BindingToAdd(project, allowed, ap1)
+	bindings.BindingToDelete(project, protectedRemoval, ap1)
+	bindings.BindingToAdd(dataset, protectedAddition, ap2)
+
+	apFeedback := map[string]*importer.AccessProviderSyncFeedback{
+		"apId1": {AccessProvider: "apId1"},
+		"apId2": {AccessProvider: "apId2"},
+	}
+
+	p.filter(bindings, apFeedback)
+
+	assert.Len(t, bindings.bindings, 1)
+	assert.Equal(t, set.NewSet(allowed), bindings.bindings[project].bindingsToAdd)
+	assert.Empty(t, bindings.bindings[project].bindingsToDelete)
+	assert.Equal(t, map[iam.IamBinding][]*importer.AccessProvider{allowed: {ap1}}, bindings.bindings[project].accessProviders)
+
+	assert.Equal(t, []string{`binding of role roles/viewer for user:break-glass@raito.io on project "project1" is protected and is not changed`}, apFeedback["apId1"].Warnings)
+	assert.Equal(t, []string{`binding of role roles/bigquery.dataViewer for user:break-glass@raito.io on dataset "project1.dataset1" is protected and is not changed`}, apFeedback["apId2"].Warnings)
+	assert.Empty(t, apFeedback["apId1"].Errors)
+}